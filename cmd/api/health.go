@@ -0,0 +1,40 @@
+package api
+
+import "context"
+
+// databaseBackend is the SQL database behind the repositories, for the readiness check.
+type databaseBackend struct {
+	name string
+	db   interface {
+		Ping(ctx context.Context) error
+	}
+	migrator migrator
+}
+
+func (b databaseBackend) Name() string {
+	return b.name
+}
+
+func (b databaseBackend) Ping(ctx context.Context) error {
+	return b.db.Ping(ctx)
+}
+
+func (b databaseBackend) MigrationVersion(ctx context.Context) (int64, int64, error) {
+	current, err := b.migrator.Version(ctx)
+	return current, b.migrator.Latest(), err
+}
+
+// memoryBackend is the in-memory store: always reachable and without migrations.
+type memoryBackend struct{}
+
+func (memoryBackend) Name() string {
+	return "memory"
+}
+
+func (memoryBackend) Ping(context.Context) error {
+	return nil
+}
+
+func (memoryBackend) MigrationVersion(context.Context) (int64, int64, error) {
+	return 0, 0, nil
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/juanignaciorc/microbloggin-pltf/migrations"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | status | force <version>"
+
+// migrator applies the schema migrations of the database behind DATABASE_URL.
+type migrator interface {
+	Up(ctx context.Context) ([]migrations.Migration, error)
+	Down(ctx context.Context, steps int) ([]migrations.Migration, error)
+	Status(ctx context.Context) ([]migrations.Status, error)
+	Force(ctx context.Context, version int64) error
+	// Version returns the newest applied migration, and Latest the newest one known.
+	Version(ctx context.Context) (int64, error)
+	Latest() int64
+}
+
+// RunMigrate runs the migrate subcommand against DATABASE_URL.
+func RunMigrate(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		return errors.New("DATABASE_URL must be set to run migrations")
+	}
+
+	_, migrator, err := openDatabase(ctx, databaseURL)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Fprintf(out, "applied %d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Fprintln(out, "no pending migrations")
+		}
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("steps must be a positive number, got %q", args[1])
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			fmt.Fprintf(out, "reverted %d_%s\n", migration.Version, migration.Name)
+		}
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(out, "%06d_%s\t%s\n", status.Version, status.Name, applied)
+		}
+		return nil
+	case "force":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("version must be a number, got %q", args[1])
+		}
+
+		if err := migrator.Force(ctx, version); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "schema marked at version %d\n", version)
+		return nil
+	default:
+		return errors.New(migrateUsage)
+	}
+}
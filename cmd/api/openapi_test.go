@@ -0,0 +1,186 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/handlers"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/openapi"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/repositories/in_memory_db"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/streaming"
+	"github.com/stretchr/testify/assert"
+)
+
+var pathParam = regexp.MustCompile(`:([a-z_]+)`)
+
+const adminToken = "t0ken"
+
+func TestOpenAPI_DocumentsEveryRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("DATABASE_URL", "")
+	t.Setenv("DATA_DIR", "")
+	t.Setenv("CACHE_SIZE", "")
+
+	doc, err := openapi.Load()
+	assert.NoError(t, err)
+
+	registered := make(map[string]bool)
+	for _, route := range SetupEngine().Routes() {
+		path := pathParam.ReplaceAllString(route.Path, "{$1}")
+		registered[route.Method+" "+path] = true
+
+		item := doc.Paths.Value(path)
+		if item == nil || item.GetOperation(route.Method) == nil {
+			t.Errorf("%s %s is not documented", route.Method, route.Path)
+		}
+	}
+
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			if !registered[method+" "+path] {
+				t.Errorf("%s %s is documented but not served", method, path)
+			}
+		}
+	}
+}
+
+// TestOpenAPI_ResponsesMatchTheDocument goes through every route, with the
+// validation middleware reporting what does not match the document.
+func TestOpenAPI_ResponsesMatchTheDocument(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	doc, err := openapi.Load()
+	assert.NoError(t, err)
+	validator, err := openapi.NewValidator(doc)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.Use(validator.Middleware(func(err error) { t.Error(err) }))
+	health := handlers.NewHealthHandler(memoryBackend{}, handlers.DefaultReadinessTimeout)
+	router.GET("/healthz", health.Live)
+	router.GET("/readyz", health.Ready)
+	hub := streaming.NewHub(streaming.DefaultBufferSize, streaming.DefaultHistorySize)
+	setupRoutes(router, createHandlers(NewServices(NewInMemoryRepositories(in_memory_db.NewInMemoryDB()), hub), hub, apiConfig{webSocket: handlers.DefaultWebSocketConfig, adminToken: adminToken}))
+
+	// send sends a request with the given header and returns the data of the
+	// response, checking its status.
+	send := func(header, value, method, path, body string, status int) any {
+		t.Helper()
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			request.Header.Set("Content-Type", "application/json")
+		}
+		if value != "" {
+			request.Header.Set(header, value)
+		}
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+		assert.Equal(t, status, response.Code, "%s %s: %s", method, path, response.Body.String())
+
+		var decoded map[string]any
+		if err := json.Unmarshal(response.Body.Bytes(), &decoded); err != nil {
+			return nil
+		}
+		return decoded["data"]
+	}
+	// doAs sends a request as viewer, if it is not empty.
+	doAs := func(viewer, method, path, body string, status int) any {
+		t.Helper()
+		return send("X-User-ID", viewer, method, path, body, status)
+	}
+	do := func(method, path, body string, status int) any {
+		t.Helper()
+		return doAs("", method, path, body, status)
+	}
+	asAdmin := func(method, path, body string, status int) any {
+		t.Helper()
+		return send("Authorization", "Bearer "+adminToken, method, path, body, status)
+	}
+	id := func(data any) string {
+		if object, ok := data.(map[string]any); ok {
+			if id, ok := object["id"].(string); ok {
+				return id
+			}
+		}
+		t.Fatalf("no id in %v", data)
+		return ""
+	}
+
+	const users = "/api/v1/users/"
+	const unknownID = "00000000-0000-4000-8000-000000000000"
+
+	do(http.MethodGet, "/ping", "", http.StatusOK)
+	do(http.MethodGet, "/healthz", "", http.StatusOK)
+	do(http.MethodGet, "/readyz", "", http.StatusOK)
+
+	alice := id(do(http.MethodPost, "/api/v1/users", `{"name":"alice","email":"alice@example.com"}`, http.StatusOK))
+	bob := id(do(http.MethodPost, "/api/v1/users", `{"name":"bob","email":"bob@example.com"}`, http.StatusOK))
+	carol := id(do(http.MethodPost, "/api/v1/users", `{"name":"carol","email":"carol@example.com"}`, http.StatusOK))
+	do(http.MethodPost, "/api/v1/users", `{"name":`, http.StatusBadRequest)
+	do(http.MethodGet, users+alice, "", http.StatusOK)
+	do(http.MethodGet, users+"not-a-uuid", "", http.StatusBadRequest)
+
+	doAs(alice, http.MethodPost, users+alice+"/follow/"+bob, "", http.StatusCreated)
+	doAs(bob, http.MethodPost, users+alice+"/follow/"+carol, "", http.StatusForbidden)
+	tweet := id(doAs(bob, http.MethodPost, users+bob+"/tweet", `{"message":"hello @alice"}`, http.StatusCreated))
+	doAs(bob, http.MethodPost, users+bob+"/tweet", `{"message":"`+strings.Repeat("a", 281)+`"}`, http.StatusBadRequest)
+	do(http.MethodPost, users+bob+"/tweet", `{"message":"anonymous"}`, http.StatusForbidden)
+	do(http.MethodGet, "/api/v1/tweets/"+tweet, "", http.StatusOK)
+	do(http.MethodGet, "/api/v1/tweets/"+unknownID, "", http.StatusNotFound)
+	do(http.MethodGet, users+bob+"/tweets?limit=10", "", http.StatusOK)
+	do(http.MethodGet, users+bob+"/tweets?limit=-1", "", http.StatusBadRequest)
+	doAs(alice, http.MethodGet, users+alice+"/timeline", "", http.StatusOK)
+	doAs(bob, http.MethodGet, users+alice+"/timeline", "", http.StatusForbidden)
+	doAs(bob, http.MethodGet, users+alice+"/timeline/stream", "", http.StatusForbidden)
+	do(http.MethodGet, users+bob+"/followers", "", http.StatusOK)
+	do(http.MethodGet, users+alice+"/following?offset=0", "", http.StatusOK)
+	do(http.MethodGet, users+alice+"/relationship/"+bob, "", http.StatusOK)
+
+	doAs(bob, http.MethodGet, users+bob+"/notifications", "", http.StatusOK)
+	doAs(alice, http.MethodGet, users+bob+"/notifications", "", http.StatusForbidden)
+	doAs(bob, http.MethodPost, users+bob+"/notifications/read", `{"ids":["`+unknownID+`"]}`, http.StatusOK)
+	doAs(bob, http.MethodPost, users+bob+"/notifications/read", "", http.StatusOK)
+	do(http.MethodPost, users+bob+"/notifications/read", "", http.StatusForbidden)
+
+	doAs(bob, http.MethodPatch, users+bob+"/privacy", `{"protected":true}`, http.StatusOK)
+	doAs(bob, http.MethodPatch, users+bob+"/privacy", `{}`, http.StatusBadRequest)
+	doAs(alice, http.MethodPatch, users+bob+"/privacy", `{"protected":false}`, http.StatusForbidden)
+	do(http.MethodGet, users+bob+"/tweets", "", http.StatusForbidden)
+	doAs(carol, http.MethodPost, users+carol+"/follow/"+bob, "", http.StatusAccepted)
+	doAs(bob, http.MethodGet, users+bob+"/follow-requests", "", http.StatusOK)
+	do(http.MethodGet, users+bob+"/follow-requests", "", http.StatusForbidden)
+	doAs(carol, http.MethodPost, users+bob+"/follow-requests/"+carol+"/approve", "", http.StatusForbidden)
+	doAs(bob, http.MethodPost, users+bob+"/follow-requests/"+carol+"/approve", "", http.StatusOK)
+	doAs(bob, http.MethodPost, users+bob+"/follow-requests/"+carol+"/reject", "", http.StatusNotFound)
+
+	doAs(alice, http.MethodPost, users+alice+"/mute/"+bob, "", http.StatusOK)
+	doAs(alice, http.MethodDelete, users+alice+"/mute/"+bob, "", http.StatusOK)
+	doAs(bob, http.MethodPost, users+alice+"/mute/"+carol, "", http.StatusForbidden)
+	doAs(alice, http.MethodPost, users+alice+"/block/"+alice, "", http.StatusBadRequest)
+	doAs(alice, http.MethodPost, users+alice+"/block/"+carol, "", http.StatusOK)
+	doAs(carol, http.MethodPost, users+carol+"/follow/"+alice, "", http.StatusForbidden)
+	doAs(alice, http.MethodDelete, users+alice+"/block/"+carol, "", http.StatusOK)
+
+	// Without an upgrade the handshake is rejected before reaching the WebSocket.
+	do(http.MethodGet, "/api/v1/ws", "", http.StatusUnauthorized)
+
+	do(http.MethodGet, "/api/v1/webhooks", "", http.StatusUnauthorized)
+	webhook := id(asAdmin(http.MethodPost, "/api/v1/webhooks", `{"url":"https://93.184.215.14/hook","events":["tweet.created"],"include_protected":true}`, http.StatusCreated))
+	asAdmin(http.MethodPost, "/api/v1/webhooks", `{"url":"https://93.184.215.14/hook","events":["unknown"]}`, http.StatusBadRequest)
+	asAdmin(http.MethodGet, "/api/v1/webhooks", "", http.StatusOK)
+	asAdmin(http.MethodGet, "/api/v1/webhooks/"+webhook, "", http.StatusOK)
+	asAdmin(http.MethodGet, "/api/v1/webhooks/"+webhook+"/deliveries?status=dead", "", http.StatusOK)
+	asAdmin(http.MethodGet, "/api/v1/webhooks/"+webhook+"/deliveries?status=lost", "", http.StatusBadRequest)
+	asAdmin(http.MethodPost, "/api/v1/webhooks/"+webhook+"/deliveries/"+unknownID+"/retry", "", http.StatusNotFound)
+	asAdmin(http.MethodDelete, "/api/v1/webhooks/"+webhook, "", http.StatusOK)
+	asAdmin(http.MethodGet, "/api/v1/webhooks/"+webhook, "", http.StatusNotFound)
+
+	doAs(alice, http.MethodPost, "/graphql", `{"query":"mutation { createTweet(userId: \"`+alice+`\", message: \"hi\") { id author { name } } }"}`, http.StatusOK)
+	doAs(alice, http.MethodPost, "/graphql", `{"query":"query($id: ID!) { user(id: $id) { name following { name tweets { message } } } }","variables":{"id":"`+alice+`"}}`, http.StatusOK)
+	doAs(alice, http.MethodPost, "/graphql", `{"query":"{ user(id: \"`+alice+`\") { email } }"}`, http.StatusBadRequest)
+}
@@ -1,13 +1,126 @@
 package api
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/handlers"
 )
 
-func StartServer(router *gin.Engine) {
-	if err := router.Run(":8080"); err != nil {
-		log.Fatal("error running server", []error{err})
+const (
+	addr = ":8080"
+
+	defaultShutdownTimeout = 10 * time.Second
+	tracingFlushTimeout    = 5 * time.Second
+)
+
+// Server is the API together with what has to happen when it stops.
+type Server struct {
+	Router *gin.Engine
+
+	health          *handlers.HealthHandler
+	shutdownTracing func(context.Context) error
+	// startWorkers starts the outbox dispatcher and the webhook deliverer, which
+	// run until ctx is cancelled and close the returned channel once stopped.
+	startWorkers func(ctx context.Context) <-chan struct{}
+	// closeStore closes the in-memory store, compacting its log when it is
+	// persistent. The database backends have nothing to close here.
+	closeStore func() error
+}
+
+// StartServer serves the API until the process gets SIGINT or SIGTERM, then shuts
+// it down gracefully: /readyz fails for SHUTDOWN_DELAY (0 by default), so load
+// balancers stop sending requests, and the requests in flight get up to
+// SHUTDOWN_TIMEOUT (10s by default) to finish before the remaining connections,
+// such as open timeline streams, are closed. The background workers then stop,
+// within what is left of the timeout; the events of the drained requests they don't
+// get to stay in the outbox for the next start. Last, the in-memory store is closed
+// so a persistent one starts next time from a compacted log.
+func StartServer(s *Server) {
+	delay, err := durationFromEnv("SHUTDOWN_DELAY", 0)
+	if err != nil {
+		fatal("Failed to configure the shutdown", err)
+	}
+	timeout, err := durationFromEnv("SHUTDOWN_TIMEOUT", defaultShutdownTimeout)
+	if err != nil {
+		fatal("Failed to configure the shutdown", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	workersDone := s.startWorkers(workersCtx)
+
+	server := &http.Server{Addr: addr, Handler: s.Router}
+	served := make(chan error, 1)
+	go func() {
+		slog.Info("Listening", "addr", addr)
+		served <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-served:
+		fatal("Failed to run the server", err)
+	case <-ctx.Done():
+	}
+	// A second signal kills the process right away.
+	stop()
+
+	slog.Info("Shutting down", "delay", delay.String(), "timeout", timeout.String())
+	s.health.SetShuttingDown()
+	time.Sleep(delay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("Closing the connections still open", "error", err)
+		server.Close()
+	}
+	if err := <-served; !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("Failed to run the server", "error", err)
+	}
+
+	stopWorkers()
+	select {
+	case <-workersDone:
+	case <-shutdownCtx.Done():
+		slog.Warn("Stopping without waiting for the background workers")
+	}
+
+	if err := s.closeStore(); err != nil {
+		slog.Error("Failed to close the in-memory database", "error", err)
+	}
+
+	// The spans of the last requests are exported even if draining used the whole timeout.
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), tracingFlushTimeout)
+	defer cancelFlush()
+	if err := s.shutdownTracing(flushCtx); err != nil {
+		slog.Warn("Failed to export the last spans", "error", err)
+	}
+	slog.Info("Server stopped")
+}
+
+// durationFromEnv parses the duration in the variable name, or returns fallback
+// when it is not set.
+func durationFromEnv(name string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative duration, got %q", name, value)
 	}
+	return duration, nil
 }
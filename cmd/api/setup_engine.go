@@ -2,62 +2,476 @@ package api
 
 import (
 	"context"
+	"fmt"
 	ports "github.com/juanignaciorc/microbloggin-pltf/internal/ports/repositories"
-	"log"
+	"log/slog"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/caching"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/eventbus"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/graphql"
 	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/handlers"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/metrics"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/openapi"
 	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/repositories/in_memory_db"
 	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/repositories/postgre_db"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/repositories/sqlite_db"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/streaming"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/tracing"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/webhooks"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/logging"
 	"github.com/juanignaciorc/microbloggin-pltf/internal/services"
+	"github.com/juanignaciorc/microbloggin-pltf/migrations"
 )
 
 const basePath = "/api/v1"
 
-func createHandlers(userRepo ports.UsersRepository, tweetRepo ports.TweetRepository) (*handlers.UserHandler, *handlers.TweetHandler) {
-	userService := services.NewUserService(userRepo)
-	userHandler := handlers.NewUserHandler(userService)
+// sqlitePrefix starts a DATABASE_URL naming a SQLite file, as in sqlite:./microblog.db,
+// instead of a PostgreSQL server.
+const sqlitePrefix = "sqlite:"
 
-	tweetService := services.NewTweetsService(tweetRepo)
-	tweetHandler := handlers.NewTweetHandler(tweetService, userService)
+// Repositories groups the storage adapters the services are built on.
+type Repositories struct {
+	users         ports.UsersRepository
+	tweets        ports.TweetRepository
+	notifications ports.NotificationsRepository
+	outbox        ports.OutboxRepository
+	webhooks      ports.WebhooksRepository
+	unitOfWork    ports.UnitOfWork
+	bulk          ports.BulkRepository
 
-	return userHandler, tweetHandler
+	// postgres is the connection pool behind the repositories, when they use PostgreSQL.
+	postgres *postgre_db.DB
+	// backend is what the readiness check probes.
+	backend handlers.Backend
 }
 
-func setupRoutes(router *gin.Engine, userHandler *handlers.UserHandler, tweetHandler *handlers.TweetHandler) {
+// apiConfig configures the handlers, from the environment.
+type apiConfig struct {
+	webSocket handlers.WebSocketConfig
+	// adminToken is the bearer token of the admin routes; without one they are off.
+	adminToken string
+}
+
+type apiHandlers struct {
+	// admin guards the admin routes.
+	admin          gin.HandlerFunc
+	user           *handlers.UserHandler
+	tweet          *handlers.TweetHandler
+	notification   *handlers.NotificationHandler
+	timelineStream *handlers.TimelineStreamHandler
+	websocket      *handlers.WebSocketHandler
+	webhook        *handlers.WebhookHandler
+	graphql        *graphql.Handler
+}
+
+func (r Repositories) Users() ports.UsersRepository {
+	return r.users
+}
+
+func (r Repositories) Tweets() ports.TweetRepository {
+	return r.tweets
+}
+
+func (r Repositories) Bulk() ports.BulkRepository {
+	return r.bulk
+}
+
+// WithMetrics times the operations of the users, tweets and notifications
+// repositories, and exposes the statistics of the PostgreSQL pool if there is one.
+func (r Repositories) WithMetrics(m *metrics.Metrics) Repositories {
+	r.users = m.UsersRepository(r.users)
+	r.tweets = m.TweetRepository(r.tweets)
+	r.notifications = m.NotificationsRepository(r.notifications)
+	if r.postgres != nil {
+		m.RegisterPool(r.postgres.Stat)
+	}
+	return r
+}
+
+// WithCache serves users and timelines from cache. Only the writes made through the
+// returned repositories invalidate it.
+func (r Repositories) WithCache(cache *caching.Cache) Repositories {
+	users := r.users
+	r.users = caching.NewUsersRepository(users, cache)
+	r.tweets = caching.NewTweetRepository(r.tweets, users, cache)
+	r.unitOfWork = caching.NewUnitOfWork(r.unitOfWork, cache)
+	return r
+}
+
+// NewInMemoryRepositories serves every repository from db.
+func NewInMemoryRepositories(db *in_memory_db.InMemoryDB) Repositories {
+	return Repositories{users: db, tweets: db, notifications: db, outbox: db, webhooks: db, unitOfWork: db, bulk: db, backend: memoryBackend{}}
+}
+
+// NewPostgresRepositories builds every repository on db.
+func NewPostgresRepositories(db *postgre_db.DB) Repositories {
+	return Repositories{
+		users:         postgre_db.NewUserRepository(db),
+		tweets:        postgre_db.NewTweetRepository(db),
+		notifications: postgre_db.NewNotificationRepository(db),
+		outbox:        postgre_db.NewOutboxRepository(db),
+		webhooks:      postgre_db.NewWebhookRepository(db),
+		unitOfWork:    postgre_db.NewUnitOfWork(db),
+		bulk:          postgre_db.NewBulkRepository(db),
+		postgres:      db,
+	}
+}
+
+// NewSQLiteRepositories builds every repository on db.
+func NewSQLiteRepositories(db *sqlite_db.DB) Repositories {
+	return Repositories{
+		users:         sqlite_db.NewUserRepository(db),
+		tweets:        sqlite_db.NewTweetRepository(db),
+		notifications: sqlite_db.NewNotificationRepository(db),
+		outbox:        sqlite_db.NewOutboxRepository(db),
+		webhooks:      sqlite_db.NewWebhookRepository(db),
+		unitOfWork:    sqlite_db.NewUnitOfWork(db),
+		bulk:          sqlite_db.NewBulkRepository(db),
+	}
+}
+
+// OpenRepositories connects to the database at databaseURL: a SQLite file when it
+// starts with sqlite:, PostgreSQL otherwise.
+func OpenRepositories(ctx context.Context, databaseURL string) (Repositories, error) {
+	repos, _, err := openDatabase(ctx, databaseURL)
+	return repos, err
+}
+
+// openDatabase connects to databaseURL and returns its repositories together with
+// the migrator for its schema.
+func openDatabase(ctx context.Context, databaseURL string) (Repositories, migrator, error) {
+	if path, ok := strings.CutPrefix(databaseURL, sqlitePrefix); ok {
+		embedded, err := migrations.LoadSQLite()
+		if err != nil {
+			return Repositories{}, nil, err
+		}
+
+		db, err := sqlite_db.NewDB(ctx, strings.TrimPrefix(path, "//"))
+		if err != nil {
+			return Repositories{}, nil, err
+		}
+
+		repos, migrator := NewSQLiteRepositories(db), sqlite_db.NewMigrator(db, embedded)
+		repos.backend = databaseBackend{name: "sqlite", db: db, migrator: migrator}
+		return repos, migrator, nil
+	}
+
+	embedded, err := migrations.Load()
+	if err != nil {
+		return Repositories{}, nil, err
+	}
+
+	db, err := postgre_db.NewDB(ctx, databaseURL)
+	if err != nil {
+		return Repositories{}, nil, err
+	}
+
+	repos, migrator := NewPostgresRepositories(db), postgre_db.NewMigrator(db, embedded)
+	repos.backend = databaseBackend{name: "postgres", db: db, migrator: migrator}
+	return repos, migrator, nil
+}
+
+// Services are the application services, shared by the API and the admin CLI.
+type Services struct {
+	Users         services.UserService
+	Tweets        services.TweetService
+	Notifications services.NotificationService
+	Webhooks      services.WebhookService
+}
+
+func NewServices(repos Repositories, tweetPublisher ports.TweetPublisher) Services {
+	return Services{
+		Users:         services.WithUserTracing(services.NewUserService(repos.users, repos.notifications, repos.unitOfWork)),
+		Tweets:        services.WithTweetTracing(services.NewTweetsService(repos.tweets, repos.users, repos.notifications, tweetPublisher)),
+		Notifications: services.NewNotificationsService(repos.notifications),
+		Webhooks:      services.NewWebhooksService(repos.webhooks),
+	}
+}
+
+func createHandlers(svcs Services, hub *streaming.Hub, config apiConfig) apiHandlers {
+	return apiHandlers{
+		admin:          handlers.RequireAdmin(config.adminToken),
+		user:           handlers.NewUserHandler(svcs.Users),
+		tweet:          handlers.NewTweetHandler(svcs.Tweets, svcs.Users),
+		notification:   handlers.NewNotificationHandler(svcs.Notifications),
+		timelineStream: handlers.NewTimelineStreamHandler(hub, svcs.Users, handlers.DefaultHeartbeatInterval),
+		websocket:      handlers.NewWebSocketHandler(hub, svcs.Users, config.webSocket),
+		webhook:        handlers.NewWebhookHandler(svcs.Webhooks),
+		graphql:        graphql.NewHandler(svcs.Users, svcs.Tweets, graphql.DefaultLimits),
+	}
+}
+
+func setupRoutes(router *gin.Engine, h apiHandlers) {
 	router.GET("/ping", handlers.PingHandler)
-	router.POST(basePath+"/users", userHandler.Create)
-	router.GET(basePath+"/users/:id", userHandler.Get)
-	router.POST(basePath+"/users/:id/tweet", tweetHandler.CreateTweet)
-	router.POST(basePath+"/users/:id/follow/:following_user_id", userHandler.FollowUser)
-	router.GET(basePath+"/users/:id/timeline", userHandler.GetUserTimeline)
+	router.POST(basePath+"/users", h.user.Create)
+	router.GET(basePath+"/users/:id", h.user.Get)
+	router.POST(basePath+"/users/:id/tweet", h.tweet.CreateTweet)
+	router.GET(basePath+"/users/:id/tweets", h.tweet.GetUserTweets)
+	router.GET(basePath+"/tweets/:tweet_id", h.tweet.GetTweet)
+	router.POST(basePath+"/users/:id/follow/:following_user_id", h.user.FollowUser)
+	router.GET(basePath+"/users/:id/timeline", h.user.GetUserTimeline)
+	router.GET(basePath+"/users/:id/timeline/stream", h.timelineStream.Stream)
+	router.GET(basePath+"/users/:id/followers", h.user.GetFollowers)
+	router.GET(basePath+"/users/:id/following", h.user.GetFollowing)
+	router.GET(basePath+"/users/:id/relationship/:target_user_id", h.user.GetRelationship)
+	router.POST(basePath+"/users/:id/block/:target_user_id", h.user.BlockUser)
+	router.DELETE(basePath+"/users/:id/block/:target_user_id", h.user.UnblockUser)
+	router.POST(basePath+"/users/:id/mute/:target_user_id", h.user.MuteUser)
+	router.DELETE(basePath+"/users/:id/mute/:target_user_id", h.user.UnmuteUser)
+	router.PATCH(basePath+"/users/:id/privacy", h.user.UpdatePrivacy)
+	router.GET(basePath+"/users/:id/follow-requests", h.user.GetFollowRequests)
+	router.POST(basePath+"/users/:id/follow-requests/:requester_id/approve", h.user.ApproveFollowRequest)
+	router.POST(basePath+"/users/:id/follow-requests/:requester_id/reject", h.user.RejectFollowRequest)
+	router.GET(basePath+"/users/:id/notifications", h.notification.GetNotifications)
+	router.POST(basePath+"/users/:id/notifications/read", h.notification.MarkAsRead)
+	router.GET(basePath+"/ws", h.websocket.Connect)
+	webhooks := router.Group(basePath+"/webhooks", h.admin)
+	webhooks.POST("", h.webhook.Create)
+	webhooks.GET("", h.webhook.List)
+	webhooks.GET("/:webhook_id", h.webhook.Get)
+	webhooks.DELETE("/:webhook_id", h.webhook.Delete)
+	webhooks.GET("/:webhook_id/deliveries", h.webhook.GetDeliveries)
+	webhooks.POST("/:webhook_id/deliveries/:delivery_id/retry", h.webhook.RetryDelivery)
+	router.POST("/graphql", h.graphql.Serve)
+}
+
+// startBackgroundWorkers relays the domain events stored in the outbox to their
+// subscribers and sends the resulting webhook deliveries until ctx is cancelled.
+// The returned channel is closed once both workers have stopped.
+func startBackgroundWorkers(ctx context.Context, repos Repositories) <-chan struct{} {
+	dispatcher := eventbus.NewDispatcher(repos.outbox, repos.unitOfWork, eventbus.DefaultRetryPolicy, eventbus.DefaultBatchSize, eventbus.DefaultPollInterval)
+	deliverer := webhooks.NewDeliverer(repos.webhooks, repos.users, webhooks.NewClient(webhooks.DefaultTimeout), webhooks.DefaultRetryPolicy, webhooks.DefaultConcurrency, webhooks.DefaultPollInterval)
+	for _, eventType := range domain.EventTypes {
+		dispatcher.Subscribe(eventType, deliverer.HandleEvent)
+	}
+
+	var workers sync.WaitGroup
+	for _, run := range []func(context.Context){dispatcher.Run, deliverer.Run} {
+		workers.Add(1)
+		go func(run func(context.Context)) {
+			defer workers.Done()
+			run(ctx)
+		}(run)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(done)
+	}()
+	return done
 }
 
+// SetupEngine returns the router of the API, for callers that serve it themselves.
 func SetupEngine() *gin.Engine {
+	return NewServer().Router
+}
+
+// NewServer sets up logging, tracing, the storage behind DATABASE_URL and the routes.
+func NewServer() *Server {
+	logger, err := newLogger()
+	if err != nil {
+		fatal("Failed to set up logging", err)
+	}
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), os.Getenv("OTEL_TRACES_EXPORTER"))
+	if err != nil {
+		fatal("Failed to set up tracing", err)
+	}
+
 	router := gin.New()
-	router.Use(gin.Logger())
+	// Handlers pass the gin context to the services; with the fallback its values,
+	// such as the request's span, come from the request context.
+	router.ContextWithFallback = true
+	router.Use(tracing.Middleware())
+	router.Use(logging.Middleware(logger))
 
+	apiMetrics := metrics.New()
+	router.Use(apiMetrics.Middleware())
+	router.GET("/metrics", gin.WrapH(apiMetrics.Handler()))
+
+	hub := streaming.NewHub(streaming.DefaultBufferSize, streaming.DefaultHistorySize)
+
+	var repos Repositories
+	closeStore := func() error { return nil }
 	databaseURL := os.Getenv("DATABASE_URL")
 	if databaseURL == "" {
-		log.Println("DATABASE_URL not set, using in-memory database")
-		repoIMDB := in_memory_db.NewInMemoryDB()
-		userHandler, tweetHandler := createHandlers(repoIMDB, repoIMDB)
+		db, err := openInMemoryDB()
+		if err != nil {
+			fatal("Failed to open the in-memory database", err)
+		}
+		repos = NewInMemoryRepositories(db)
+		closeStore = db.Close
+	} else {
+		ctx := context.Background()
+		var migrator migrator
+		repos, migrator, err = openDatabase(ctx, databaseURL)
+		if err != nil {
+			fatal("Failed to connect to database", err)
+		}
 
-		setupRoutes(router, userHandler, tweetHandler)
-		return router
+		if os.Getenv("MIGRATE_ON_START") == "true" {
+			migrateOnStart(ctx, migrator)
+		}
 	}
 
-	ctx := context.Background()
-	db, err := postgre_db.NewDB(ctx, databaseURL)
+	// The cache goes on top so the repository metrics time the reads it misses.
+	repos = repos.WithMetrics(apiMetrics)
+	cache, err := openCache()
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		fatal("Failed to configure the cache", err)
+	}
+	if cache != nil {
+		apiMetrics.RegisterCache(cache)
+		repos = repos.WithCache(cache)
+	}
+
+	health := handlers.NewHealthHandler(repos.backend, handlers.DefaultReadinessTimeout)
+	router.GET("/healthz", health.Live)
+	router.GET("/readyz", health.Ready)
+
+	doc, err := openapi.Load()
+	if err != nil {
+		fatal("Failed to load the OpenAPI document", err)
+	}
+	docHandler, err := openapi.Handler(doc)
+	if err != nil {
+		fatal("Failed to load the OpenAPI document", err)
+	}
+	router.GET("/openapi.json", docHandler)
+	router.GET("/docs", openapi.DocsHandler)
+
+	setupRoutes(router, createHandlers(NewServices(repos, hub), hub, loadAPIConfig()))
+	return &Server{
+		Router:          router,
+		health:          health,
+		shutdownTracing: shutdownTracing,
+		startWorkers: func(ctx context.Context) <-chan struct{} {
+			return startBackgroundWorkers(ctx, repos)
+		},
+		closeStore: closeStore,
+	}
+}
+
+// newLogger returns the JSON logger of the API, logging from LOG_LEVEL (debug,
+// info, warn or error; info by default) up.
+func newLogger() (*slog.Logger, error) {
+	level := slog.LevelInfo
+	if value := os.Getenv("LOG_LEVEL"); value != "" {
+		var err error
+		if level, err = logging.ParseLevel(value); err != nil {
+			return nil, err
+		}
 	}
 
-	userRepo := postgre_db.NewUserRepository(db)
-	tweetRepo := postgre_db.NewTweetRepository(db)
-	userHandler, tweetHandler := createHandlers(userRepo, tweetRepo)
+	return logging.New(os.Stderr, level), nil
+}
+
+// loadAPIConfig reads ADMIN_TOKEN, the bearer token of the admin routes, and
+// WS_ALLOWED_ORIGINS, a comma separated list of the origins besides the API's own
+// whose pages may open WebSockets.
+func loadAPIConfig() apiConfig {
+	config := apiConfig{webSocket: handlers.DefaultWebSocketConfig, adminToken: os.Getenv("ADMIN_TOKEN")}
+	for _, origin := range strings.Split(os.Getenv("WS_ALLOWED_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			config.webSocket.AllowedOrigins = append(config.webSocket.AllowedOrigins, origin)
+		}
+	}
+	if config.adminToken == "" {
+		slog.Warn("ADMIN_TOKEN not set, the admin routes are disabled")
+	}
+	return config
+}
+
+// fatal logs err and exits, for the errors the API cannot start with.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// openCache returns the cache set up by CACHE_SIZE, the number of entries kept in
+// process, and CACHE_PROFILE_TTL and CACHE_TIMELINE_TTL, or nil when CACHE_SIZE is
+// not set.
+func openCache() (*caching.Cache, error) {
+	value := os.Getenv("CACHE_SIZE")
+	if value == "" {
+		return nil, nil
+	}
+
+	size, err := strconv.Atoi(value)
+	if err != nil || size < 1 {
+		return nil, fmt.Errorf("CACHE_SIZE must be a positive number, got %q", value)
+	}
+
+	options := caching.DefaultOptions
+	for name, target := range map[string]*time.Duration{
+		"CACHE_PROFILE_TTL":  &options.ProfileTTL,
+		"CACHE_TIMELINE_TTL": &options.TimelineTTL,
+	} {
+		if value := os.Getenv(name); value != "" {
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			*target = duration
+		}
+	}
+
+	slog.Info("Caching profiles and timelines", "size", size)
+	return caching.New(caching.NewLRU(size), options), nil
+}
+
+// openInMemoryDB returns a store that only lives in memory or, with DATA_DIR set,
+// one saved to that directory. WAL_SYNC (always, interval or never),
+// WAL_SYNC_INTERVAL and SNAPSHOT_INTERVAL tune how it is saved.
+func openInMemoryDB() (*in_memory_db.InMemoryDB, error) {
+	dataDir := os.Getenv("DATA_DIR")
+	if dataDir == "" {
+		slog.Info("DATABASE_URL not set, using in-memory database")
+		return in_memory_db.NewInMemoryDB(), nil
+	}
+
+	options := in_memory_db.DefaultPersistenceOptions
+	options.Dir = dataDir
+	if value := os.Getenv("WAL_SYNC"); value != "" {
+		policy, err := in_memory_db.ParseSyncPolicy(value)
+		if err != nil {
+			return nil, err
+		}
+		options.Sync = policy
+	}
+	for name, target := range map[string]*time.Duration{
+		"WAL_SYNC_INTERVAL": &options.SyncInterval,
+		"SNAPSHOT_INTERVAL": &options.SnapshotInterval,
+	} {
+		if value := os.Getenv(name); value != "" {
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			*target = duration
+		}
+	}
+
+	slog.Info("DATABASE_URL not set, using in-memory database saved to disk", "dir", dataDir)
+	return in_memory_db.OpenInMemoryDB(options)
+}
+
+// migrateOnStart applies the pending migrations before serving. Replicas starting
+// together wait for each other on the migration lock.
+func migrateOnStart(ctx context.Context, migrator migrator) {
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		fatal("Failed to apply migrations", err)
+	}
 
-	setupRoutes(router, userHandler, tweetHandler)
-	return router
+	slog.Info("Applied migrations", "count", len(applied))
 }
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juanignaciorc/microbloggin-pltf/internal/bulk"
+	ports "github.com/juanignaciorc/microbloggin-pltf/internal/ports/repositories"
+)
+
+func (a *app) export(ctx context.Context, args []string) error {
+	flags := a.newFlagSet("export")
+	kind := flags.String("kind", "", "what to export: users, follows or tweets")
+	format := flags.String("format", "", "jsonl or csv; by default taken from the -o extension, else jsonl")
+	out := flags.String("o", "", "file to write to instead of stdout")
+	dir := flags.String("dir", "", "export every kind to <dir>/<kind>.<format>")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("%w: unexpected argument %q", errUsage, flags.Arg(0))
+	}
+
+	repos, err := a.repositories(ctx)
+	if err != nil {
+		return err
+	}
+
+	if *dir != "" {
+		if *kind != "" || *out != "" {
+			return fmt.Errorf("%w: -dir cannot be combined with -kind or -o", errUsage)
+		}
+		f, err := parseFormat(*format, "")
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(*dir, 0o755); err != nil {
+			return err
+		}
+
+		for _, k := range bulk.Kinds {
+			if err := a.exportFile(ctx, repos.Bulk(), k, f, filepath.Join(*dir, string(k)+"."+string(f))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	k, err := bulk.ParseKind(*kind)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	f, err := parseFormat(*format, *out)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err := bulk.Export(ctx, repos.Bulk(), k, f, a.stdout)
+		return err
+	}
+	return a.exportFile(ctx, repos.Bulk(), k, f, *out)
+}
+
+func (a *app) exportFile(ctx context.Context, repository ports.BulkRepository, kind bulk.Kind, format bulk.Format, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	count, err := bulk.Export(ctx, repository, kind, format, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("exporting %s: %w", kind, err)
+	}
+
+	fmt.Fprintf(a.stderr, "%s: exported %d to %s\n", kind, count, path)
+	return nil
+}
+
+func (a *app) importData(ctx context.Context, args []string) error {
+	flags := a.newFlagSet("import")
+	kind := flags.String("kind", "", "what to import: users, follows or tweets; by default taken from the file name")
+	format := flags.String("format", "", "jsonl or csv; by default taken from the file extension")
+	dir := flags.String("dir", "", "import <dir>/users, follows and tweets, in that order, as written by export -dir")
+	batchSize := flags.Int("batch", bulk.DefaultBatchSize, "records written per batch")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var files []string
+	switch {
+	case *dir != "" && flags.NArg() == 0 && *kind == "":
+		for _, k := range bulk.Kinds {
+			matches, err := filepath.Glob(filepath.Join(*dir, string(k)+".*"))
+			if err != nil {
+				return err
+			}
+			files = append(files, matches...)
+		}
+		if len(files) == 0 {
+			return fmt.Errorf("no users, follows or tweets files in %s", *dir)
+		}
+	case *dir == "" && flags.NArg() == 1:
+		files = []string{flags.Arg(0)}
+	default:
+		return fmt.Errorf("%w: expected <file> or -dir <dir>", errUsage)
+	}
+
+	repos, err := a.repositories(ctx)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, path := range files {
+		k := bulk.Kind(*kind)
+		if k == "" {
+			k = bulk.Kind(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+		}
+		if _, err := bulk.ParseKind(string(k)); err != nil {
+			return fmt.Errorf("%w: %v; pass -kind", errUsage, err)
+		}
+		f, err := parseFormat(*format, path)
+		if err != nil {
+			return err
+		}
+
+		report, err := a.importFile(ctx, repos.Bulk(), k, f, path, *batchSize)
+		a.printReport(report)
+		if err != nil {
+			return fmt.Errorf("importing %s: %w", path, err)
+		}
+		failed += report.Failed
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d records could not be imported", failed)
+	}
+	return nil
+}
+
+func (a *app) importFile(ctx context.Context, repository ports.BulkRepository, kind bulk.Kind, format bulk.Format, path string, batchSize int) (bulk.Report, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return bulk.Report{Kind: kind}, err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	return bulk.Import(ctx, repository, kind, format, r, bulk.Options{
+		BatchSize: batchSize,
+		Progress: func(report bulk.Report) {
+			fmt.Fprintf(a.stderr, "%s: read %d, imported %d, skipped %d, failed %d\n",
+				report.Kind, report.Read, report.Imported, report.Skipped, report.Failed)
+		},
+	})
+}
+
+// printReport writes the final counts of an import and the errors it kept.
+func (a *app) printReport(report bulk.Report) {
+	fmt.Fprintf(a.stdout, "%s: read %d, imported %d, skipped %d, failed %d\n",
+		report.Kind, report.Read, report.Imported, report.Skipped, report.Failed)
+	for _, lineErr := range report.Errors {
+		fmt.Fprintf(a.stdout, "  %v\n", lineErr)
+	}
+	if more := report.Failed - len(report.Errors); more > 0 {
+		fmt.Fprintf(a.stdout, "  ... and %d more\n", more)
+	}
+}
+
+// parseFormat returns the format flag, or the one matching path when it is empty.
+func parseFormat(format, path string) (bulk.Format, error) {
+	if format == "" {
+		return bulk.FormatFromPath(path), nil
+	}
+
+	f, err := bulk.ParseFormat(format)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errUsage, err)
+	}
+	return f, nil
+}
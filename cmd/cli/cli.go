@@ -0,0 +1,291 @@
+// Package cli implements the microblog command line: the API server and the admin
+// commands operators use to inspect and fix data through the services layer.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/cmd/api"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/handlers"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/streaming"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+const usage = `usage: microblog <command> [arguments]
+
+commands:
+  serve                                   start the API server (default)
+  migrate up | down [steps] | status | force <version>
+  user create -name <name> -email <email> create a user
+  user show <user-id>                     print a user
+  user protect [-off] <user-id>           make an account protected, or public with -off
+  follow <user-id> <target-user-id>       make a user follow another one
+  tweet <user-id> <message>               publish a tweet as a user
+  seed [flags]                            generate users, follows and tweets (see seed -h)
+  loadgen [flags]                         send mixed traffic to the API and report latencies (see loadgen -h)
+  replay [-url <url>] <scenario.jsonl>    replay a scenario of HTTP requests and check the responses
+  export [flags]                          write users, follows or tweets as JSON lines or CSV (see export -h)
+  import [flags] <file> | -dir <dir>      load users, follows or tweets written by export (see import -h)
+
+The data commands need DATABASE_URL.
+`
+
+// errUsage makes Run print the usage and exit with status 2.
+var errUsage = errors.New("invalid usage")
+
+type app struct {
+	stdout io.Writer
+	stderr io.Writer
+	// repositories opens the storage the data commands run on.
+	repositories func(ctx context.Context) (api.Repositories, error)
+}
+
+// Run executes the command in args (without the program name) and returns the
+// process exit code.
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	a := &app{stdout: stdout, stderr: stderr, repositories: databaseRepositories}
+	return a.run(ctx, args)
+}
+
+func (a *app) run(ctx context.Context, args []string) int {
+	err := a.dispatch(ctx, args)
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		fmt.Fprint(a.stdout, usage)
+		return 0
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(a.stderr, "%v\n\n%s", err, usage)
+		return 2
+	default:
+		fmt.Fprintf(a.stderr, "error: %v\n", err)
+		return 1
+	}
+}
+
+func (a *app) dispatch(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return a.serve()
+	}
+
+	switch args[0] {
+	case "serve":
+		return a.serve()
+	case "migrate":
+		return api.RunMigrate(ctx, args[1:], a.stdout)
+	case "user":
+		if len(args) < 2 {
+			return fmt.Errorf("%w: missing user subcommand", errUsage)
+		}
+		switch args[1] {
+		case "create":
+			return a.createUser(ctx, args[2:])
+		case "show":
+			return a.showUser(ctx, args[2:])
+		case "protect":
+			return a.protectUser(ctx, args[2:])
+		}
+		return fmt.Errorf("%w: unknown user subcommand %q", errUsage, args[1])
+	case "follow":
+		return a.follow(ctx, args[1:])
+	case "tweet":
+		return a.tweet(ctx, args[1:])
+	case "seed":
+		return a.seed(ctx, args[1:])
+	case "loadgen":
+		return a.loadgen(ctx, args[1:])
+	case "replay":
+		return a.replay(ctx, args[1:])
+	case "export":
+		return a.export(ctx, args[1:])
+	case "import":
+		return a.importData(ctx, args[1:])
+	case "help", "-h", "-help", "--help":
+		return flag.ErrHelp
+	}
+
+	return fmt.Errorf("%w: unknown command %q", errUsage, args[0])
+}
+
+func (a *app) serve() error {
+	api.StartServer(api.NewServer())
+	return nil
+}
+
+func (a *app) createUser(ctx context.Context, args []string) error {
+	flags := a.newFlagSet("user create")
+	name := flags.String("name", "", "name of the user")
+	email := flags.String("email", "", "email of the user")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" || *email == "" {
+		return fmt.Errorf("%w: -name and -email are required", errUsage)
+	}
+
+	svcs, err := a.services(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := svcs.Users.CreateUser(ctx, *name, *email)
+	if err != nil {
+		return err
+	}
+
+	return a.print(handlers.ToUserDetailResponse(user))
+}
+
+func (a *app) showUser(ctx context.Context, args []string) error {
+	ids, err := parseIDs(args, "user-id")
+	if err != nil {
+		return err
+	}
+
+	svcs, err := a.services(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := svcs.Users.GetUser(ctx, ids[0])
+	if err != nil {
+		return err
+	}
+
+	return a.print(handlers.ToUserDetailResponse(user))
+}
+
+func (a *app) protectUser(ctx context.Context, args []string) error {
+	flags := a.newFlagSet("user protect")
+	off := flags.Bool("off", false, "make the account public again")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	ids, err := parseIDs(flags.Args(), "user-id")
+	if err != nil {
+		return err
+	}
+
+	svcs, err := a.services(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := svcs.Users.SetProtected(ctx, ids[0], !*off); err != nil {
+		return err
+	}
+
+	return a.print(struct {
+		ID        uuid.UUID `json:"id"`
+		Protected bool      `json:"protected"`
+	}{ID: ids[0], Protected: !*off})
+}
+
+func (a *app) follow(ctx context.Context, args []string) error {
+	ids, err := parseIDs(args, "user-id", "target-user-id")
+	if err != nil {
+		return err
+	}
+
+	svcs, err := a.services(ctx)
+	if err != nil {
+		return err
+	}
+
+	status, err := svcs.Users.FollowUser(ctx, ids[0], ids[1])
+	if err != nil {
+		return err
+	}
+
+	return a.print(struct {
+		Status domain.FollowStatus `json:"status"`
+	}{Status: status})
+}
+
+func (a *app) tweet(ctx context.Context, args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("%w: expected <user-id> <message>", errUsage)
+	}
+
+	ids, err := parseIDs(args[:1], "user-id")
+	if err != nil {
+		return err
+	}
+
+	svcs, err := a.services(ctx)
+	if err != nil {
+		return err
+	}
+
+	tweet, err := svcs.Tweets.CreateTweet(ctx, ids[0], strings.Join(args[1:], " "))
+	if err != nil {
+		return err
+	}
+
+	return a.print(tweet)
+}
+
+func (a *app) newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(a.stderr)
+	return flags
+}
+
+// print writes v as indented JSON, so the output can be read or piped to jq.
+func (a *app) print(v any) error {
+	encoder := json.NewEncoder(a.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// parseIDs parses one UUID argument per name.
+func parseIDs(args []string, names ...string) ([]uuid.UUID, error) {
+	if len(args) != len(names) {
+		return nil, fmt.Errorf("%w: expected <%s>", errUsage, strings.Join(names, "> <"))
+	}
+
+	ids := make([]uuid.UUID, len(args))
+	for i, arg := range args {
+		id, err := uuid.Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid %s %q", errUsage, names[i], arg)
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+func (a *app) services(ctx context.Context) (api.Services, error) {
+	repos, err := a.repositories(ctx)
+	if err != nil {
+		return api.Services{}, err
+	}
+
+	// Nothing subscribes to this hub: the SSE and WebSocket subscribers live in the
+	// server's own hub, so tweets written by the CLI never reach them. Their events
+	// still go to the outbox, which the server relays to the webhooks.
+	hub := streaming.NewHub(streaming.DefaultBufferSize, streaming.DefaultHistorySize)
+	return api.NewServices(repos, hub), nil
+}
+
+// databaseRepositories runs the data commands on DATABASE_URL. The in-memory store
+// is not an option: whatever a command wrote would be gone when it exits.
+func databaseRepositories(ctx context.Context) (api.Repositories, error) {
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		return api.Repositories{}, errors.New("DATABASE_URL must be set")
+	}
+
+	return api.OpenRepositories(ctx, databaseURL)
+}
@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/juanignaciorc/microbloggin-pltf/cmd/api"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/repositories/in_memory_db"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestApp runs the data commands on one in-memory store shared across calls.
+func newTestApp() (*app, *bytes.Buffer, *bytes.Buffer) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	repos := api.NewInMemoryRepositories(in_memory_db.NewInMemoryDB())
+
+	return &app{
+		stdout:       stdout,
+		stderr:       stderr,
+		repositories: func(ctx context.Context) (api.Repositories, error) { return repos, nil },
+	}, stdout, stderr
+}
+
+func TestRun_DataCommands(t *testing.T) {
+	ctx := context.Background()
+	a, stdout, _ := newTestApp()
+
+	createUser := func(name string) string {
+		stdout.Reset()
+		assert.Equal(t, 0, a.run(ctx, []string{"user", "create", "-name", name, "-email", name + "@example.com"}))
+
+		var user struct {
+			ID string `json:"id"`
+		}
+		assert.NoError(t, json.Unmarshal(stdout.Bytes(), &user))
+		return user.ID
+	}
+	alice, bob := createUser("alice"), createUser("bob")
+
+	stdout.Reset()
+	assert.Equal(t, 0, a.run(ctx, []string{"user", "protect", bob}))
+	assert.JSONEq(t, `{"id":"`+bob+`","protected":true}`, stdout.String())
+
+	stdout.Reset()
+	assert.Equal(t, 0, a.run(ctx, []string{"follow", alice, bob}))
+	assert.JSONEq(t, `{"status":"pending"}`, stdout.String())
+
+	stdout.Reset()
+	assert.Equal(t, 0, a.run(ctx, []string{"tweet", alice, "hello", "world"}))
+	var tweet struct {
+		UserID  string `json:"user_id"`
+		Message string `json:"message"`
+	}
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &tweet))
+	assert.Equal(t, alice, tweet.UserID)
+	assert.Equal(t, "hello world", tweet.Message)
+
+	stdout.Reset()
+	assert.Equal(t, 0, a.run(ctx, []string{"user", "show", alice}))
+	var shown struct {
+		Name        string `json:"name"`
+		TweetsCount int    `json:"tweets_count"`
+	}
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &shown))
+	assert.Equal(t, "alice", shown.Name)
+	assert.Equal(t, 1, shown.TweetsCount)
+}
+
+func TestRun_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedCode   int
+		expectedStderr string
+	}{
+		{
+			name:           "Unknown command",
+			args:           []string{"unfollow"},
+			expectedCode:   2,
+			expectedStderr: "invalid usage: unknown command \"unfollow\"",
+		},
+		{
+			name:           "Missing flags",
+			args:           []string{"user", "create", "-name", "alice"},
+			expectedCode:   2,
+			expectedStderr: "invalid usage: -name and -email are required",
+		},
+		{
+			name:           "Invalid user ID",
+			args:           []string{"follow", "not-a-uuid", "9b2a6f4e-5a76-4c39-9d3c-6a1f9f5c1a2b"},
+			expectedCode:   2,
+			expectedStderr: "invalid usage: invalid user-id \"not-a-uuid\"",
+		},
+		{
+			name:           "Service error",
+			args:           []string{"user", "show", "9b2a6f4e-5a76-4c39-9d3c-6a1f9f5c1a2b"},
+			expectedCode:   1,
+			expectedStderr: "error: user with id 9b2a6f4e-5a76-4c39-9d3c-6a1f9f5c1a2b not found",
+		},
+		{
+			name:           "Unknown export kind",
+			args:           []string{"export", "-kind", "likes"},
+			expectedCode:   2,
+			expectedStderr: "invalid usage: unknown kind \"likes\", want users, follows or tweets",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _, stderr := newTestApp()
+
+			assert.Equal(t, tt.expectedCode, a.run(ctx, tt.args))
+			assert.Contains(t, stderr.String(), tt.expectedStderr)
+		})
+	}
+}
+
+func TestRun_Seed(t *testing.T) {
+	ctx := context.Background()
+	a, stdout, _ := newTestApp()
+	idsOut := filepath.Join(t.TempDir(), "ids.txt")
+
+	assert.Equal(t, 0, a.run(ctx, []string{"seed", "-users", "20", "-tweets", "50", "-following", "3", "-ids-out", idsOut}))
+	assert.Contains(t, stdout.String(), "users: 20,")
+	assert.Contains(t, stdout.String(), "tweets: 50\n")
+
+	ids, err := readIDs(idsOut)
+	assert.NoError(t, err)
+	assert.Len(t, ids, 20)
+}
+
+func TestRun_ExportImport(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	source, stdout, _ := newTestApp()
+	assert.Equal(t, 0, source.run(ctx, []string{"seed", "-users", "10", "-tweets", "30", "-following", "3"}))
+	assert.Equal(t, 0, source.run(ctx, []string{"export", "-dir", dir, "-format", "csv"}))
+
+	stdout.Reset()
+	assert.Equal(t, 0, source.run(ctx, []string{"export", "-kind", "users"}))
+	assert.Equal(t, 10, strings.Count(stdout.String(), "\n"))
+
+	target, stdout, stderr := newTestApp()
+	assert.Equal(t, 0, target.run(ctx, []string{"import", "-dir", dir}), stderr.String())
+	assert.Contains(t, stdout.String(), "users: read 10, imported 10, skipped 0, failed 0\n")
+	assert.Contains(t, stdout.String(), "tweets: read 30, imported 30, skipped 0, failed 0\n")
+
+	// Re-running the import is a no-op.
+	stdout.Reset()
+	assert.Equal(t, 0, target.run(ctx, []string{"import", filepath.Join(dir, "tweets.csv")}))
+	assert.Equal(t, "tweets: read 30, imported 0, skipped 30, failed 0\n", stdout.String())
+
+	// Bad records are listed and make the command fail.
+	bad := filepath.Join(dir, "follows.jsonl")
+	assert.NoError(t, os.WriteFile(bad, []byte(`{"follower_id":"nope"}`+"\n"), 0o644))
+	stdout.Reset()
+	assert.Equal(t, 1, target.run(ctx, []string{"import", bad}))
+	assert.Contains(t, stdout.String(), "follows: read 1, imported 0, skipped 0, failed 1\n  line 1: ")
+}
+
+func TestRun_ReplayScenarios(t *testing.T) {
+	// In-process replays run on the in-memory store.
+	t.Setenv("DATABASE_URL", "")
+
+	scenarios, err := filepath.Glob("../../scenarios/*.jsonl")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, scenarios)
+
+	for _, scenario := range scenarios {
+		t.Run(filepath.Base(scenario), func(t *testing.T) {
+			a, stdout, stderr := newTestApp()
+
+			assert.Equal(t, 0, a.run(context.Background(), []string{"replay", scenario}), stderr.String())
+			assert.Contains(t, stdout.String(), "steps passed")
+		})
+	}
+}
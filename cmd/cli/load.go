@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/loadgen"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/seed"
+)
+
+func (a *app) seed(ctx context.Context, args []string) error {
+	config := seed.DefaultConfig
+	flags := a.newFlagSet("seed")
+	flags.IntVar(&config.Users, "users", config.Users, "number of users")
+	flags.IntVar(&config.Tweets, "tweets", config.Tweets, "number of tweets")
+	flags.IntVar(&config.MeanFollowing, "following", config.MeanFollowing, "average number of accounts each user follows")
+	flags.Float64Var(&config.Skew, "skew", config.Skew, "power-law exponent of popularity and activity, greater than 1")
+	flags.DurationVar(&config.Period, "period", config.Period, "how far back the tweets are spread")
+	flags.Int64Var(&config.Seed, "seed", config.Seed, "random seed; the same seed generates the same data")
+	idsOut := flags.String("ids-out", "", "file to write the generated user IDs to, one per line, for loadgen -ids")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	repos, err := a.repositories(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := seed.NewSeeder(repos.Users(), repos.Tweets(), repos.Bulk()).Seed(ctx, config)
+	fmt.Fprintf(a.stdout, "users: %d, follows: %d, tweets: %d\n", len(result.UserIDs), result.Follows, result.Tweets)
+	if err != nil {
+		return err
+	}
+
+	if *idsOut != "" {
+		return writeIDs(*idsOut, result.UserIDs)
+	}
+	return nil
+}
+
+func (a *app) loadgen(ctx context.Context, args []string) error {
+	config := loadgen.Config{Mix: loadgen.DefaultMix}
+	flags := a.newFlagSet("loadgen")
+	flags.StringVar(&config.BaseURL, "url", "http://localhost:8080", "base URL of the API")
+	flags.DurationVar(&config.Duration, "duration", 30*time.Second, "how long to send traffic")
+	flags.IntVar(&config.Concurrency, "concurrency", 8, "number of concurrent clients")
+	flags.IntVar(&config.Users, "users", 50, "accounts to create through the API when -ids is not given")
+	flags.Int64Var(&config.Seed, "seed", 1, "random seed")
+	idsFile := flags.String("ids", "", "file with the user IDs to act as, e.g. from seed -ids-out")
+	mix := flags.String("mix", "", "operation weights, e.g. read_timeline=60,read_user=20,post_tweet=15,follow=5")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *mix != "" {
+		parsed, err := loadgen.ParseMix(*mix)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errUsage, err)
+		}
+		config.Mix = parsed
+	}
+
+	if *idsFile != "" {
+		ids, err := readIDs(*idsFile)
+		if err != nil {
+			return err
+		}
+		config.UserIDs = ids
+	}
+
+	report, err := loadgen.Run(ctx, http.DefaultClient, config)
+	if err != nil {
+		return err
+	}
+
+	return report.Write(a.stdout)
+}
+
+func writeIDs(path string, ids []uuid.UUID) error {
+	lines := make([]string, len(ids))
+	for i, id := range ids {
+		lines[i] = id.String()
+	}
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644)
+}
+
+func readIDs(path string) ([]uuid.UUID, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var ids []uuid.UUID
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		id, err := uuid.Parse(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid user ID %q", path, line)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, scanner.Err()
+}
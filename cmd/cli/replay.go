@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/juanignaciorc/microbloggin-pltf/cmd/api"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/replay"
+)
+
+func (a *app) replay(ctx context.Context, args []string) error {
+	flags := a.newFlagSet("replay")
+	url := flags.String("url", "", "base URL of a running API; without it the scenario runs in-process on a fresh engine")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%w: expected one scenario file", errUsage)
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	records, err := replay.Read(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", flags.Arg(0), err)
+	}
+
+	var replayer *replay.Replayer
+	if *url != "" {
+		replayer = replay.NewRemote(http.DefaultClient, *url)
+	} else {
+		// Keep the engine's route listing and request log out of the report.
+		gin.SetMode(gin.ReleaseMode)
+		gin.DefaultWriter = io.Discard
+		replayer = replay.NewInProcess(api.SetupEngine())
+	}
+
+	results, err := replayer.Replay(ctx, records)
+	for _, result := range results {
+		outcome := "ok  "
+		if result.Err != nil {
+			outcome = "FAIL"
+		}
+		fmt.Fprintf(a.stdout, "%s %3d %s %s -> %d (%v) %s\n", outcome, result.Step, result.Record.Method, result.Record.Path, result.Status, result.Duration, result.Record.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "%d steps passed\n", len(results))
+	return nil
+}
@@ -1,10 +1,12 @@
 package main
 
 import (
-	"github.com/juanignaciorc/microbloggin-pltf/cmd/api"
+	"context"
+	"os"
+
+	"github.com/juanignaciorc/microbloggin-pltf/cmd/cli"
 )
 
 func main() {
-	router := api.SetupEngine()
-	api.StartServer(router)
+	os.Exit(cli.Run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
 }
@@ -0,0 +1,151 @@
+package caching
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/logging"
+	ports "github.com/juanignaciorc/microbloggin-pltf/internal/ports/repositories"
+	"golang.org/x/sync/singleflight"
+)
+
+const DefaultSize = 10000
+
+// Options sets how long each kind of read stays cached. Writes made through the
+// decorators invalidate what they change right away; the TTLs bound how stale a
+// read can be after writes made elsewhere, such as another replica sharing the
+// database or a bulk import.
+type Options struct {
+	ProfileTTL  time.Duration
+	TimelineTTL time.Duration
+}
+
+var DefaultOptions = Options{ProfileTTL: time.Minute, TimelineTTL: 10 * time.Second}
+
+// Stats counts the reads served from the cache (hits) and from the repository (misses).
+type Stats struct {
+	ProfileHits    uint64
+	ProfileMisses  uint64
+	TimelineHits   uint64
+	TimelineMisses uint64
+}
+
+type counters struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// Cache is what the repository decorators share: the store the reads are kept in,
+// encoded as JSON, and the bookkeeping that keeps them consistent with the writes.
+type Cache struct {
+	store   ports.Cache
+	options Options
+
+	// Concurrent misses on a key wait for a single load from the repository.
+	group     singleflight.Group
+	profiles  counters
+	timelines counters
+
+	// generation counts the invalidations. A load stores what it read only when no
+	// invalidation happened since it started, because what it read may predate the write.
+	mu         sync.RWMutex
+	generation uint64
+}
+
+func New(store ports.Cache, options Options) *Cache {
+	return &Cache{store: store, options: options}
+}
+
+func (c *Cache) Stats() Stats {
+	return Stats{
+		ProfileHits:    c.profiles.hits.Load(),
+		ProfileMisses:  c.profiles.misses.Load(),
+		TimelineHits:   c.timelines.hits.Load(),
+		TimelineMisses: c.timelines.misses.Load(),
+	}
+}
+
+func profileKey(id uuid.UUID) string {
+	return "user:" + id.String()
+}
+
+func timelineKey(id uuid.UUID) string {
+	return "timeline:" + id.String()
+}
+
+// readThrough returns the value cached under key or, on a miss, the one load reads
+// and caches for ttl. Inside a unit of work it always calls load: the transaction
+// must see its own writes, and what it reads is not committed yet.
+func readThrough[T any](ctx context.Context, c *Cache, stats *counters, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	var value T
+	if inUnitOfWork(ctx) {
+		return load()
+	}
+
+	data, ok, err := c.store.Get(ctx, key)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to read from the cache", "key", key, "error", err)
+	}
+	if ok && json.Unmarshal(data, &value) == nil {
+		stats.hits.Add(1)
+		return value, nil
+	}
+
+	stats.misses.Add(1)
+	shared, err, _ := c.group.Do(key, func() (any, error) {
+		c.mu.RLock()
+		generation := c.generation
+		c.mu.RUnlock()
+
+		loaded, err := load()
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(loaded)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		if c.generation == generation {
+			if err := c.store.Set(ctx, key, data, ttl); err != nil {
+				logging.FromContext(ctx).Warn("Failed to write to the cache", "key", key, "error", err)
+			}
+		}
+
+		return data, nil
+	})
+	if err != nil {
+		return value, err
+	}
+
+	// Each caller decodes its own copy, so callers sharing a load cannot see each other's changes.
+	err = json.Unmarshal(shared.([]byte), &value)
+	return value, err
+}
+
+// invalidate drops the cached keys after a write. Inside a unit of work they are
+// dropped again once it ends, since a read outside it may cache the old values
+// until the write commits.
+func (c *Cache) invalidate(ctx context.Context, keys ...string) {
+	if tx, ok := ctx.Value(txKey{}).(*transaction); ok {
+		tx.keys = append(tx.keys, keys...)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for _, key := range keys {
+		c.group.Forget(key)
+	}
+	if err := c.store.Delete(ctx, keys...); err != nil {
+		logging.FromContext(ctx).Warn("Failed to invalidate cache entries", "keys", keys, "error", err)
+	}
+}
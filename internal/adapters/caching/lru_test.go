@@ -0,0 +1,58 @@
+package caching
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRU(2)
+
+	assert.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.NoError(t, cache.Set(ctx, "b", []byte("2"), time.Minute))
+	_, ok, _ := cache.Get(ctx, "a")
+	assert.True(t, ok)
+
+	// "b" is now the least recently used.
+	assert.NoError(t, cache.Set(ctx, "c", []byte("3"), time.Minute))
+	_, ok, _ = cache.Get(ctx, "b")
+	assert.False(t, ok)
+	value, ok, err := cache.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+	assert.Equal(t, 2, cache.Len())
+
+	assert.NoError(t, cache.Delete(ctx, "a", "missing"))
+	_, ok, _ = cache.Get(ctx, "a")
+	assert.False(t, ok)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestLRU_Expires(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewLRU(10)
+	cache.now = func() time.Time { return now }
+
+	assert.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+	now = now.Add(59 * time.Second)
+	_, ok, _ := cache.Get(ctx, "a")
+	assert.True(t, ok)
+
+	// Setting a key again resets its TTL.
+	assert.NoError(t, cache.Set(ctx, "a", []byte("2"), time.Minute))
+	now = now.Add(59 * time.Second)
+	value, ok, _ := cache.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("2"), value)
+
+	now = now.Add(time.Second)
+	_, ok, _ = cache.Get(ctx, "a")
+	assert.False(t, ok)
+	assert.Zero(t, cache.Len())
+}
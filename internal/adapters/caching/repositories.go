@@ -0,0 +1,111 @@
+package caching
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/logging"
+	ports "github.com/juanignaciorc/microbloggin-pltf/internal/ports/repositories"
+)
+
+// UsersRepository decorates a ports.UsersRepository, caching profiles and timelines.
+// The methods it does not override go straight to the decorated repository.
+type UsersRepository struct {
+	ports.UsersRepository
+	cache *Cache
+}
+
+func NewUsersRepository(next ports.UsersRepository, cache *Cache) *UsersRepository {
+	return &UsersRepository{UsersRepository: next, cache: cache}
+}
+
+func (r *UsersRepository) GetUser(ctx context.Context, id uuid.UUID) (domain.User, error) {
+	return readThrough(ctx, r.cache, &r.cache.profiles, profileKey(id), r.cache.options.ProfileTTL, func() (domain.User, error) {
+		return r.UsersRepository.GetUser(ctx, id)
+	})
+}
+
+func (r *UsersRepository) GetUserTimeline(ctx context.Context, userID uuid.UUID) ([]domain.Tweet, error) {
+	return readThrough(ctx, r.cache, &r.cache.timelines, timelineKey(userID), r.cache.options.TimelineTTL, func() ([]domain.Tweet, error) {
+		return r.UsersRepository.GetUserTimeline(ctx, userID)
+	})
+}
+
+func (r *UsersRepository) FollowUser(ctx context.Context, userID uuid.UUID, followedID uuid.UUID, events ...domain.Event) error {
+	if err := r.UsersRepository.FollowUser(ctx, userID, followedID, events...); err != nil {
+		return err
+	}
+
+	r.cache.invalidate(ctx, profileKey(userID), profileKey(followedID), timelineKey(userID))
+	return nil
+}
+
+// BlockUser also drops the follows between both users, so both profiles and timelines change.
+func (r *UsersRepository) BlockUser(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error {
+	if err := r.UsersRepository.BlockUser(ctx, userID, blockedID); err != nil {
+		return err
+	}
+
+	r.cache.invalidate(ctx, profileKey(userID), profileKey(blockedID), timelineKey(userID), timelineKey(blockedID))
+	return nil
+}
+
+func (r *UsersRepository) SetProtected(ctx context.Context, userID uuid.UUID, protected bool) error {
+	if err := r.UsersRepository.SetProtected(ctx, userID, protected); err != nil {
+		return err
+	}
+
+	r.cache.invalidate(ctx, profileKey(userID))
+	return nil
+}
+
+func (r *UsersRepository) ApproveFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID, events ...domain.Event) error {
+	if err := r.UsersRepository.ApproveFollowRequest(ctx, followerID, userID, events...); err != nil {
+		return err
+	}
+
+	r.cache.invalidate(ctx, profileKey(followerID), profileKey(userID), timelineKey(followerID))
+	return nil
+}
+
+// TweetRepository decorates a ports.TweetRepository so new tweets invalidate the
+// cached profile of their author and the cached timelines of its followers.
+type TweetRepository struct {
+	ports.TweetRepository
+	users ports.UsersRepository
+	cache *Cache
+}
+
+// NewTweetRepository decorates next. The followers of an author are listed from
+// users, which should be the repository the UsersRepository decorator wraps.
+func NewTweetRepository(next ports.TweetRepository, users ports.UsersRepository, cache *Cache) *TweetRepository {
+	return &TweetRepository{TweetRepository: next, users: users, cache: cache}
+}
+
+func (r *TweetRepository) CreateTweet(ctx context.Context, tweet domain.Tweet, events ...domain.Event) (domain.Tweet, error) {
+	created, err := r.TweetRepository.CreateTweet(ctx, tweet, events...)
+	if err != nil {
+		return domain.Tweet{}, err
+	}
+
+	keys := []string{profileKey(created.UserID)}
+	for page := domain.NewPagination(domain.MaxPageLimit, 0); ; page.Offset += page.Limit {
+		followers, err := r.users.GetFollowers(ctx, created.UserID, page)
+		if err != nil {
+			// The timelines left behind expire after the timeline TTL.
+			logging.FromContext(ctx).Warn("Failed to list followers to invalidate their timelines", "user_id", created.UserID, "error", err)
+			break
+		}
+
+		for _, follower := range followers {
+			keys = append(keys, timelineKey(follower.ID))
+		}
+		if len(followers) < page.Limit {
+			break
+		}
+	}
+
+	r.cache.invalidate(ctx, keys...)
+	return created, nil
+}
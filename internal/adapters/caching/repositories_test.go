@@ -0,0 +1,173 @@
+package caching
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/repositories/in_memory_db"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	mock_ports "github.com/juanignaciorc/microbloggin-pltf/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsersRepository_CachesProfilesUntilWritten(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	next := mock_ports.NewMockUsersRepository(ctrl)
+	cache := New(NewLRU(DefaultSize), DefaultOptions)
+	users := NewUsersRepository(next, cache)
+
+	alice := domain.User{ID: uuid.New(), Name: "alice"}
+	bob := domain.User{ID: uuid.New(), Name: "bob"}
+	next.EXPECT().GetUser(gomock.Any(), bob.ID).Return(bob, nil).Times(1)
+
+	for i := 0; i < 3; i++ {
+		got, err := users.GetUser(ctx, bob.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, bob, got)
+	}
+	assert.Equal(t, Stats{ProfileHits: 2, ProfileMisses: 1}, cache.Stats())
+
+	// Following bob changes his follower count, so his profile is read again.
+	next.EXPECT().FollowUser(gomock.Any(), alice.ID, bob.ID).Return(nil)
+	assert.NoError(t, users.FollowUser(ctx, alice.ID, bob.ID))
+
+	followed := bob
+	followed.FollowersCount = 1
+	next.EXPECT().GetUser(gomock.Any(), bob.ID).Return(followed, nil).Times(1)
+	got, err := users.GetUser(ctx, bob.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, followed, got)
+
+	// Errors are not cached.
+	missing := uuid.New()
+	errNotFound := errors.New("user not found")
+	next.EXPECT().GetUser(gomock.Any(), missing).Return(domain.User{}, errNotFound).Times(2)
+	for i := 0; i < 2; i++ {
+		_, err = users.GetUser(ctx, missing)
+		assert.ErrorIs(t, err, errNotFound)
+	}
+}
+
+func TestUsersRepository_ConcurrentMissesLoadOnce(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	next := mock_ports.NewMockUsersRepository(ctrl)
+	cache := New(NewLRU(DefaultSize), DefaultOptions)
+	users := NewUsersRepository(next, cache)
+
+	const readers = 10
+	user := domain.User{ID: uuid.New(), Name: "alice"}
+	release := make(chan struct{})
+	next.EXPECT().GetUser(gomock.Any(), user.ID).DoAndReturn(func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+		<-release
+		return user, nil
+	}).Times(1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := users.GetUser(ctx, user.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, user, got)
+		}()
+	}
+
+	assert.Eventually(t, func() bool { return cache.Stats().ProfileMisses == readers }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+}
+
+func TestTweetRepository_CreateTweetInvalidatesFollowerTimelines(t *testing.T) {
+	ctx := context.Background()
+	db := in_memory_db.NewInMemoryDB()
+	cache := New(NewLRU(DefaultSize), DefaultOptions)
+	users := NewUsersRepository(db, cache)
+	tweets := NewTweetRepository(db, db, cache)
+
+	alice, _ := users.CreateUser(ctx, domain.User{Name: "alice"})
+	bob, _ := users.CreateUser(ctx, domain.User{Name: "bob"})
+	assert.NoError(t, users.FollowUser(ctx, alice.ID, bob.ID))
+
+	timeline, err := users.GetUserTimeline(ctx, alice.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, timeline)
+	profile, _ := users.GetUser(ctx, bob.ID)
+	assert.Zero(t, profile.TweetsCount)
+
+	tweet, err := tweets.CreateTweet(ctx, domain.Tweet{UserID: bob.ID, Message: "hello"})
+	assert.NoError(t, err)
+
+	timeline, err = users.GetUserTimeline(ctx, alice.ID)
+	assert.NoError(t, err)
+	assert.Len(t, timeline, 1)
+	assert.Equal(t, tweet.ID, timeline[0].ID)
+	profile, _ = users.GetUser(ctx, bob.ID)
+	assert.Equal(t, 1, profile.TweetsCount)
+	assert.Equal(t, Stats{ProfileMisses: 2, TimelineMisses: 2}, cache.Stats())
+}
+
+func TestUnitOfWork_InvalidatesOnceDone(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	next := mock_ports.NewMockUsersRepository(ctrl)
+	cache := New(NewLRU(DefaultSize), DefaultOptions)
+	users := NewUsersRepository(next, cache)
+	unitOfWork := NewUnitOfWork(passThroughUnitOfWork(ctrl), cache)
+
+	alice := domain.User{ID: uuid.New(), Name: "alice"}
+	bob := domain.User{ID: uuid.New(), Name: "bob"}
+	followed := bob
+	followed.FollowersCount = 1
+
+	gomock.InOrder(
+		// Reads in the unit of work skip the cache.
+		next.EXPECT().GetUser(gomock.Any(), bob.ID).Return(bob, nil).Times(2),
+		next.EXPECT().FollowUser(gomock.Any(), alice.ID, bob.ID).Return(nil),
+		// A read outside it, before it commits, caches the old profile...
+		next.EXPECT().GetUser(gomock.Any(), bob.ID).Return(bob, nil),
+		// ...which is dropped once it is done.
+		next.EXPECT().GetUser(gomock.Any(), bob.ID).Return(followed, nil),
+	)
+
+	err := unitOfWork.Do(ctx, func(txCtx context.Context) error {
+		for i := 0; i < 2; i++ {
+			if _, err := users.GetUser(txCtx, bob.ID); err != nil {
+				return err
+			}
+		}
+		if err := users.FollowUser(txCtx, alice.ID, bob.ID); err != nil {
+			return err
+		}
+
+		stale, err := users.GetUser(ctx, bob.ID)
+		assert.Equal(t, bob, stale)
+		return err
+	})
+	assert.NoError(t, err)
+
+	got, err := users.GetUser(ctx, bob.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, followed, got)
+}
+
+func passThroughUnitOfWork(ctrl *gomock.Controller) *mock_ports.MockUnitOfWork {
+	unitOfWork := mock_ports.NewMockUnitOfWork(ctrl)
+	unitOfWork.
+		EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	return unitOfWork
+}
@@ -0,0 +1,44 @@
+package caching
+
+import (
+	"context"
+
+	ports "github.com/juanignaciorc/microbloggin-pltf/internal/ports/repositories"
+)
+
+type txKey struct{}
+
+// transaction collects the keys invalidated by the writes of a unit of work.
+type transaction struct {
+	keys []string
+}
+
+// UnitOfWork decorates a ports.UnitOfWork so the reads made in it bypass the cache
+// and the keys its writes invalidate are dropped again once it commits or rolls back.
+type UnitOfWork struct {
+	next  ports.UnitOfWork
+	cache *Cache
+}
+
+func NewUnitOfWork(next ports.UnitOfWork, cache *Cache) *UnitOfWork {
+	return &UnitOfWork{next: next, cache: cache}
+}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inUnitOfWork(ctx) {
+		return u.next.Do(ctx, fn)
+	}
+
+	tx := &transaction{}
+	err := u.next.Do(context.WithValue(ctx, txKey{}, tx), fn)
+	if len(tx.keys) > 0 {
+		u.cache.invalidate(ctx, tx.keys...)
+	}
+
+	return err
+}
+
+func inUnitOfWork(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*transaction)
+	return ok
+}
@@ -0,0 +1,180 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/logging"
+	ports "github.com/juanignaciorc/microbloggin-pltf/internal/ports/repositories"
+)
+
+const (
+	DefaultBatchSize    = 100
+	DefaultPollInterval = time.Second
+)
+
+// RetryPolicy controls the exponential backoff between dispatches of an event whose
+// handlers fail. After MaxAttempts failed dispatches the event is dead-lettered:
+// it stays in the outbox, marked dead, and is not dispatched again.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 10,
+	BaseDelay:   time.Second,
+	MaxDelay:    10 * time.Minute,
+}
+
+// Backoff returns how long to wait after the given failed attempt (1-based):
+// BaseDelay, then doubling each time, capped at MaxDelay.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	return delay
+}
+
+// Handler processes one event. Delivery is at-least-once: an event is retried,
+// on every handler subscribed to its type, until all of them succeed in the same
+// pass, so handlers must be idempotent (Event.ID is a natural deduplication key).
+type Handler func(ctx context.Context, event domain.Event) error
+
+// Dispatcher relays the events stored in the outbox to the handlers subscribed
+// to their type, and marks them dispatched once every handler has succeeded.
+// Each batch is handled in a unit of work that keeps its events locked, so several
+// dispatchers can share an outbox.
+type Dispatcher struct {
+	outbox       ports.OutboxRepository
+	unitOfWork   ports.UnitOfWork
+	policy       RetryPolicy
+	batchSize    int
+	pollInterval time.Duration
+	now          func() time.Time
+
+	mu       sync.RWMutex
+	handlers map[domain.EventType][]Handler
+}
+
+func NewDispatcher(outbox ports.OutboxRepository, unitOfWork ports.UnitOfWork, policy RetryPolicy, batchSize int, pollInterval time.Duration) *Dispatcher {
+	return &Dispatcher{
+		outbox:       outbox,
+		unitOfWork:   unitOfWork,
+		policy:       policy,
+		batchSize:    batchSize,
+		pollInterval: pollInterval,
+		now:          func() time.Time { return time.Now().UTC() },
+		handlers:     make(map[domain.EventType][]Handler),
+	}
+}
+
+// Subscribe registers handler for every event of the given type.
+func (d *Dispatcher) Subscribe(eventType domain.EventType, handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.handlers[eventType] = append(d.handlers[eventType], handler)
+}
+
+// Run polls the outbox every pollInterval until ctx is cancelled, draining it
+// in batches whenever there is a backlog.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			dispatched, err := d.DispatchPending(ctx)
+			if err != nil {
+				logging.FromContext(ctx).Error("Failed to dispatch outbox events", "error", err)
+				break
+			}
+			if dispatched < d.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending delivers one batch of due events and returns how many of them
+// were dispatched. Events whose handlers fail are retried after a backoff, until
+// the retry policy gives up on them.
+func (d *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	var dispatched []uuid.UUID
+	err := d.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		dispatched = nil
+
+		events, err := d.outbox.GetPendingEvents(ctx, d.now(), d.batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if err := d.deliver(ctx, event); err != nil {
+				if err := d.outbox.MarkEventFailed(ctx, d.failed(ctx, event, err)); err != nil {
+					return err
+				}
+				continue
+			}
+			dispatched = append(dispatched, event.ID)
+		}
+
+		if len(dispatched) == 0 {
+			return nil
+		}
+		return d.outbox.MarkEventsDispatched(ctx, dispatched)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(dispatched), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, event domain.Event) error {
+	d.mu.RLock()
+	handlers := d.handlers[event.Type]
+	d.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// failed returns the event with the failed attempt recorded: scheduled for a retry,
+// or dead once it has used up the retry policy.
+func (d *Dispatcher) failed(ctx context.Context, event domain.Event, err error) domain.Event {
+	now := d.now()
+	event.Attempts++
+
+	if event.Attempts >= d.policy.MaxAttempts {
+		event.DeadAt = &now
+		logging.FromContext(ctx).Error("Event handlers kept failing, giving up on the event", "event_id", event.ID, "event_type", event.Type, "attempts", event.Attempts, "error", err)
+		return event
+	}
+
+	event.NextAttemptAt = now.Add(d.policy.Backoff(event.Attempts))
+	logging.FromContext(ctx).Warn("Event handler failed, will retry", "event_id", event.ID, "event_type", event.Type, "attempts", event.Attempts, "next_attempt_at", event.NextAttemptAt, "error", err)
+	return event
+}
@@ -0,0 +1,174 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/repositories/in_memory_db"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder is a handler that remembers the events it received and fails the
+// first failures calls.
+type recorder struct {
+	mu       sync.Mutex
+	events   []domain.Event
+	failures int
+}
+
+func (r *recorder) handle(ctx context.Context, event domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event)
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("temporary failure")
+	}
+	return nil
+}
+
+func (r *recorder) received() []domain.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]domain.Event(nil), r.events...)
+}
+
+func TestDispatcher_DispatchPending(t *testing.T) {
+	ctx := context.Background()
+	db := in_memory_db.NewInMemoryDB()
+
+	follower := domain.User{ID: uuid.New(), Name: "follower"}
+	_, _ = db.CreateUser(ctx, follower, domain.NewUserCreatedEvent(follower))
+	followed, _ := db.CreateUser(ctx, domain.User{Name: "followed"})
+	followedEvent := domain.NewUserFollowedEvent(follower.ID, followed.ID)
+	_ = db.FollowUser(ctx, follower.ID, followed.ID, followedEvent)
+
+	follows := &recorder{}
+	dispatcher := NewDispatcher(db, db, DefaultRetryPolicy, DefaultBatchSize, DefaultPollInterval)
+	dispatcher.Subscribe(domain.EventTypeUserFollowed, follows.handle)
+
+	dispatched, err := dispatcher.DispatchPending(ctx)
+
+	assert.NoError(t, err)
+	// Events nobody subscribed to are dispatched too.
+	assert.Equal(t, 2, dispatched)
+	assert.Equal(t, []domain.Event{followedEvent}, follows.received())
+
+	var payload domain.UserFollowed
+	assert.NoError(t, follows.received()[0].DecodePayload(&payload))
+	assert.Equal(t, domain.UserFollowed{FollowerID: follower.ID, FollowedID: followed.ID}, payload)
+
+	pending, _ := db.GetPendingEvents(ctx, time.Now(), DefaultBatchSize)
+	assert.Empty(t, pending)
+}
+
+func TestDispatcher_RetriesFailedEvents(t *testing.T) {
+	ctx := context.Background()
+	db := in_memory_db.NewInMemoryDB()
+
+	user := domain.User{ID: uuid.New(), Name: "user"}
+	event := domain.NewUserCreatedEvent(user)
+	_, _ = db.CreateUser(ctx, user, event)
+
+	flaky := &recorder{failures: 1}
+	healthy := &recorder{}
+	dispatcher := NewDispatcher(db, db, DefaultRetryPolicy, DefaultBatchSize, DefaultPollInterval)
+	now := event.OccurredAt
+	dispatcher.now = func() time.Time { return now }
+	dispatcher.Subscribe(domain.EventTypeUserCreated, flaky.handle)
+	dispatcher.Subscribe(domain.EventTypeUserCreated, healthy.handle)
+
+	dispatched, err := dispatcher.DispatchPending(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dispatched)
+
+	// The retry waits for the backoff.
+	dispatched, err = dispatcher.DispatchPending(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dispatched)
+
+	now = now.Add(DefaultRetryPolicy.BaseDelay)
+	dispatched, err = dispatcher.DispatchPending(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dispatched)
+
+	// At-least-once: every handler sees the event again on the retry.
+	retried := event
+	retried.Attempts = 1
+	retried.NextAttemptAt = now
+	assert.Equal(t, []domain.Event{event, retried}, flaky.received())
+	assert.Equal(t, []domain.Event{event, retried}, healthy.received())
+}
+
+func TestDispatcher_DeadLettersFailingEvents(t *testing.T) {
+	ctx := context.Background()
+	db := in_memory_db.NewInMemoryDB()
+
+	broken := domain.User{ID: uuid.New(), Name: "broken"}
+	_, _ = db.CreateUser(ctx, broken, domain.NewUserCreatedEvent(broken))
+	fine := domain.User{ID: uuid.New(), Name: "fine"}
+	_, _ = db.CreateUser(ctx, fine, domain.NewUserCreatedEvent(fine))
+
+	handler := func(ctx context.Context, event domain.Event) error {
+		var payload domain.UserCreated
+		_ = event.DecodePayload(&payload)
+		if payload.UserID == broken.ID {
+			return errors.New("permanent failure")
+		}
+		return nil
+	}
+
+	policy := RetryPolicy{MaxAttempts: 2, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	dispatcher := NewDispatcher(db, db, policy, DefaultBatchSize, DefaultPollInterval)
+	now := time.Now().UTC()
+	dispatcher.now = func() time.Time { return now }
+	dispatcher.Subscribe(domain.EventTypeUserCreated, handler)
+
+	dispatched, err := dispatcher.DispatchPending(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dispatched)
+
+	now = now.Add(time.Minute)
+	dispatched, err = dispatcher.DispatchPending(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dispatched)
+
+	// Out of attempts: the event is never due again.
+	pending, err := db.GetPendingEvents(ctx, now.Add(24*time.Hour), DefaultBatchSize)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestDispatcher_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := in_memory_db.NewInMemoryDB()
+	created := &recorder{}
+	dispatcher := NewDispatcher(db, db, DefaultRetryPolicy, 2, 5*time.Millisecond)
+	dispatcher.Subscribe(domain.EventTypeUserCreated, created.handle)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		dispatcher.Run(ctx)
+	}()
+
+	// More events than a batch, written while the dispatcher is running.
+	for i := 0; i < 5; i++ {
+		user := domain.User{ID: uuid.New()}
+		_, _ = db.CreateUser(context.Background(), user, domain.NewUserCreatedEvent(user))
+	}
+
+	assert.Eventually(t, func() bool { return len(created.received()) == 5 }, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,111 @@
+package graphql
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/services"
+)
+
+// viewerHeader identifies the user performing the reads, as in the REST API.
+const viewerHeader = "X-User-ID"
+
+// Request is the body of a POST to the GraphQL endpoint.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Response carries the data an operation resolved and the errors it found.
+// Requests rejected before running have no data.
+type Response struct {
+	Data   interface{}                `json:"data,omitempty"`
+	Errors []gqlerrors.FormattedError `json:"errors,omitempty"`
+}
+
+type Handler struct {
+	schema    gql.Schema
+	resolvers resolvers
+	limits    Limits
+}
+
+// NewHandler serves the schema over the services. The schema is fixed, so it
+// panics if the schema is invalid.
+func NewHandler(users services.UserService, tweets services.TweetService, limits Limits) *Handler {
+	schema, err := NewSchema(users, tweets)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Handler{
+		schema:    schema,
+		resolvers: resolvers{users: users, tweets: tweets},
+		limits:    limits,
+	}
+}
+
+// Serve runs the operation in the body. Requests that cannot run, because the
+// body, the query or the viewer is invalid or the query exceeds the limits, are
+// answered 400; the rest 200, with the errors of the fields that failed.
+func (h *Handler) Serve(ctx *gin.Context) {
+	var body Request
+	if err := ctx.ShouldBindJSON(&body); err != nil || body.Query == "" {
+		reject(ctx, "the body must be a JSON object with a query", "INVALID_REQUEST_BODY")
+		return
+	}
+
+	viewerID := uuid.Nil
+	if raw := ctx.GetHeader(viewerHeader); raw != "" {
+		var err error
+		if viewerID, err = uuid.Parse(raw); err != nil {
+			reject(ctx, "Invalid viewer ID", "INVALID_VIEWER_ID")
+			return
+		}
+	}
+
+	document, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(body.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, Response{Errors: gqlerrors.FormatErrors(err)})
+		return
+	}
+
+	if validation := gql.ValidateDocument(&h.schema, document, nil); !validation.IsValid {
+		ctx.JSON(http.StatusBadRequest, Response{Errors: validation.Errors})
+		return
+	}
+
+	if err := checkLimits(&h.schema, document, body.OperationName, body.Variables, h.limits); err != nil {
+		ctx.JSON(http.StatusBadRequest, Response{Errors: []gqlerrors.FormattedError{formatError(err)}})
+		return
+	}
+
+	result := gql.Execute(gql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           document,
+		OperationName: body.OperationName,
+		Args:          body.Variables,
+		Context:       h.resolvers.newRequest(ctx.Request.Context(), viewerID),
+	})
+	ctx.JSON(http.StatusOK, Response{Data: result.Data, Errors: result.Errors})
+}
+
+func reject(ctx *gin.Context, message, code string) {
+	ctx.JSON(http.StatusBadRequest, Response{Errors: []gqlerrors.FormattedError{formatError(codedError{message: message, code: code})}})
+}
+
+// formatError reports err, which has no location in the query, in the GraphQL form.
+func formatError(err error) gqlerrors.FormattedError {
+	formatted := gqlerrors.FormatError(err)
+	if extended, ok := err.(gqlerrors.ExtendedError); ok {
+		formatted.Extensions = extended.Extensions()
+	}
+	return formatted
+}
@@ -0,0 +1,322 @@
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	mock_ports "github.com/juanignaciorc/microbloggin-pltf/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	alice = domain.User{ID: uuid.MustParse("4d4e5f6a-1b2c-4d3e-8f9a-0b1c2d3e4f5a"), Name: "alice", FollowersCount: 2, TweetsCount: 2}
+	bob   = domain.User{ID: uuid.MustParse("5e5f6a7b-2c3d-4e4f-9a0b-1c2d3e4f5a6b"), Name: "bob", Protected: true, TweetsCount: 7}
+	carol = domain.User{ID: uuid.MustParse("6f6a7b8c-3d4e-4f5a-8b1c-2d3e4f5a6b7c"), Name: "carol"}
+)
+
+func tweetBy(author domain.User, message string) domain.Tweet {
+	return domain.Tweet{ID: uuid.New(), UserID: author.ID, Message: message}
+}
+
+// serve posts query with variables to a handler over the mocked services.
+func serve(t *testing.T, users *mock_ports.MockUserService, tweets *mock_ports.MockTweetService, viewer, query string, variables map[string]interface{}) (int, map[string]interface{}) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/graphql", NewHandler(users, tweets, DefaultLimits).Serve)
+
+	body, err := json.Marshal(Request{Query: query, Variables: variables})
+	assert.NoError(t, err)
+	request := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	request.Header.Set("Content-Type", "application/json")
+	if viewer != "" {
+		request.Header.Set(viewerHeader, viewer)
+	}
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	var decoded map[string]interface{}
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &decoded), response.Body.String())
+	return response.Code, decoded
+}
+
+// errorCodes returns the codes in the extensions of the errors of response.
+func errorCodes(response map[string]interface{}) []string {
+	var codes []string
+	errs, _ := response["errors"].([]interface{})
+	for _, err := range errs {
+		extensions, _ := err.(map[string]interface{})["extensions"].(map[string]interface{})
+		code, _ := extensions["code"].(string)
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+func TestHandler_UserWithTweetsAndFollowers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	users := mock_ports.NewMockUserService(ctrl)
+	tweets := mock_ports.NewMockTweetService(ctrl)
+
+	users.EXPECT().GetUserAsViewer(gomock.Any(), carol.ID, alice.ID).Return(alice, nil)
+	tweets.EXPECT().GetUserTweets(gomock.Any(), carol.ID, alice.ID, domain.Pagination{Limit: 2, Offset: 0}).
+		Return([]domain.Tweet{tweetBy(alice, "first"), tweetBy(alice, "second")}, nil)
+	users.EXPECT().GetFollowers(gomock.Any(), alice.ID, domain.Pagination{Limit: domain.DefaultPageLimit}).Return([]domain.User{bob, carol}, nil)
+	// bob is protected and carol does not follow him.
+	users.EXPECT().CanViewTweets(gomock.Any(), carol.ID, bob.ID).Return(false, nil)
+	// Both tweets share their author, which is read once.
+	users.EXPECT().GetUsers(gomock.Any(), []uuid.UUID{alice.ID}).Return([]domain.User{alice}, nil)
+
+	status, response := serve(t, users, tweets, carol.ID.String(), `query($id: ID!) {
+		user(id: $id) {
+			name
+			tweets(limit: 2) { message author { name } }
+			followers { name tweetsCount }
+		}
+	}`, map[string]interface{}{"id": alice.ID.String()})
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Nil(t, response["errors"])
+	assert.Equal(t, map[string]interface{}{
+		"user": map[string]interface{}{
+			"name": "alice",
+			"tweets": []interface{}{
+				map[string]interface{}{"message": "first", "author": map[string]interface{}{"name": "alice"}},
+				map[string]interface{}{"message": "second", "author": map[string]interface{}{"name": "alice"}},
+			},
+			"followers": []interface{}{
+				map[string]interface{}{"name": "bob", "tweetsCount": float64(0)},
+				map[string]interface{}{"name": "carol", "tweetsCount": float64(0)},
+			},
+		},
+	}, response["data"])
+}
+
+func TestHandler_BatchesAuthors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	users := mock_ports.NewMockUserService(ctrl)
+	tweets := mock_ports.NewMockTweetService(ctrl)
+
+	unknown := domain.User{ID: uuid.New()}
+	users.EXPECT().GetUserTimeline(gomock.Any(), carol.ID).
+		Return([]domain.Tweet{tweetBy(alice, "a"), tweetBy(bob, "b"), tweetBy(alice, "c"), tweetBy(unknown, "d")}, nil)
+	users.EXPECT().GetUsers(gomock.Any(), gomock.InAnyOrder([]uuid.UUID{alice.ID, bob.ID, unknown.ID})).
+		Return([]domain.User{alice, bob}, nil).
+		Times(1)
+
+	status, response := serve(t, users, tweets, carol.ID.String(), `{ timeline(userId: "`+carol.ID.String()+`") { message author { name } } }`, nil)
+
+	assert.Equal(t, http.StatusOK, status)
+	// The tweet whose author is gone fails, and the null reaches the root through non-null fields.
+	assert.Nil(t, response["data"])
+	assert.Len(t, response["errors"], 1)
+	assert.Contains(t, response["errors"].([]interface{})[0].(map[string]interface{})["message"], "not found")
+}
+
+func TestHandler_ResolverErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		setupMock func(users *mock_ports.MockUserService, tweets *mock_ports.MockTweetService)
+		code      string
+	}{
+		{
+			name:  "Tweet not found",
+			query: `{ tweet(id: "` + alice.ID.String() + `") { message } }`,
+			setupMock: func(users *mock_ports.MockUserService, tweets *mock_ports.MockTweetService) {
+				tweets.EXPECT().GetTweet(gomock.Any(), uuid.Nil, alice.ID).Return(domain.Tweet{}, domain.ErrTweetNotFound)
+			},
+			code: "TWEET_NOT_FOUND",
+		},
+		{
+			name:  "Protected tweets",
+			query: `{ user(id: "` + bob.ID.String() + `") { name tweets { message } } }`,
+			setupMock: func(users *mock_ports.MockUserService, tweets *mock_ports.MockTweetService) {
+				users.EXPECT().GetUserAsViewer(gomock.Any(), uuid.Nil, bob.ID).Return(bob, nil)
+				tweets.EXPECT().GetUserTweets(gomock.Any(), uuid.Nil, bob.ID, gomock.Any()).Return(nil, domain.ErrTweetsProtected)
+			},
+			code: "TWEETS_PROTECTED",
+		},
+		{
+			name:  "Someone else's timeline",
+			query: `{ timeline(userId: "` + carol.ID.String() + `") { message } }`,
+			code:  "TIMELINE_FORBIDDEN",
+		},
+		{
+			name:  "Invalid user ID",
+			query: `{ user(id: "not-a-uuid") { name } }`,
+			code:  "INVALID_USER_ID",
+		},
+		{
+			name:  "Negative pagination",
+			query: `{ user(id: "` + alice.ID.String() + `") { followers(offset: -1) { name } } }`,
+			setupMock: func(users *mock_ports.MockUserService, tweets *mock_ports.MockTweetService) {
+				users.EXPECT().GetUserAsViewer(gomock.Any(), uuid.Nil, alice.ID).Return(alice, nil)
+			},
+			code: "INVALID_PAGINATION",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			users := mock_ports.NewMockUserService(ctrl)
+			tweets := mock_ports.NewMockTweetService(ctrl)
+			if tc.setupMock != nil {
+				tc.setupMock(users, tweets)
+			}
+
+			status, response := serve(t, users, tweets, "", tc.query, nil)
+
+			assert.Equal(t, http.StatusOK, status)
+			assert.Equal(t, []string{tc.code}, errorCodes(response))
+		})
+	}
+}
+
+func TestHandler_Mutations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	users := mock_ports.NewMockUserService(ctrl)
+	tweets := mock_ports.NewMockTweetService(ctrl)
+
+	tweet := tweetBy(alice, "hello")
+	tweets.EXPECT().CreateTweet(gomock.Any(), alice.ID, "hello").Return(tweet, nil)
+	users.EXPECT().GetUsers(gomock.Any(), []uuid.UUID{alice.ID}).Return([]domain.User{alice}, nil)
+	users.EXPECT().FollowUser(gomock.Any(), alice.ID, bob.ID).Return(domain.FollowStatusPending, nil)
+	users.EXPECT().FollowUser(gomock.Any(), alice.ID, carol.ID).Return(domain.FollowStatus(""), domain.ErrUserBlocked)
+
+	status, response := serve(t, users, tweets, alice.ID.String(), `mutation($alice: ID!) {
+		createTweet(userId: $alice, message: "hello") { id message author { name } }
+		bob: followUser(userId: $alice, targetUserId: "`+bob.ID.String()+`")
+	}`, map[string]interface{}{"alice": alice.ID.String()})
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Nil(t, response["errors"])
+	assert.Equal(t, map[string]interface{}{
+		"createTweet": map[string]interface{}{"id": tweet.ID.String(), "message": "hello", "author": map[string]interface{}{"name": "alice"}},
+		"bob":         "PENDING",
+	}, response["data"])
+
+	_, response = serve(t, users, tweets, alice.ID.String(), `mutation { followUser(userId: "`+alice.ID.String()+`", targetUserId: "`+carol.ID.String()+`") }`, nil)
+	assert.Equal(t, []string{"USER_BLOCKED"}, errorCodes(response))
+
+	_, response = serve(t, users, tweets, alice.ID.String(), `mutation { createTweet(userId: "`+alice.ID.String()+`", message: "`+strings.Repeat("a", maxTweetLength+1)+`") { id } }`, nil)
+	assert.Equal(t, []string{"EXCEEDED_MAX_TWEET_CHARACTERS"}, errorCodes(response))
+}
+
+func TestHandler_MutationsActAsTheViewer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	users := mock_ports.NewMockUserService(ctrl)
+	tweets := mock_ports.NewMockTweetService(ctrl)
+
+	tests := []struct {
+		name   string
+		viewer string
+		query  string
+	}{
+		{
+			name:   "createTweet as another user",
+			viewer: bob.ID.String(),
+			query:  `mutation { createTweet(userId: "` + alice.ID.String() + `", message: "hello") { id } }`,
+		},
+		{
+			name:   "followUser as another user",
+			viewer: bob.ID.String(),
+			query:  `mutation { followUser(userId: "` + alice.ID.String() + `", targetUserId: "` + carol.ID.String() + `") }`,
+		},
+		{
+			name:  "createTweet without a viewer",
+			query: `mutation { createTweet(userId: "` + uuid.Nil.String() + `", message: "hello") { id } }`,
+		},
+		{
+			name:  "followUser without a viewer",
+			query: `mutation { followUser(userId: "` + alice.ID.String() + `", targetUserId: "` + carol.ID.String() + `") }`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			status, response := serve(t, users, tweets, tc.viewer, tc.query, nil)
+
+			assert.Equal(t, http.StatusOK, status)
+			assert.Equal(t, []string{"UNAUTHORIZED"}, errorCodes(response))
+		})
+	}
+}
+
+func TestHandler_RejectsRequestsThatCannotRun(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		viewer string
+		code   string
+	}{
+		{
+			name: "Malformed body",
+			body: `{"query":`,
+			code: "INVALID_REQUEST_BODY",
+		},
+		{
+			name: "Missing query",
+			body: `{}`,
+			code: "INVALID_REQUEST_BODY",
+		},
+		{
+			name:   "Invalid viewer",
+			body:   `{"query":"{ timeline(userId: \"` + alice.ID.String() + `\") { message } }"}`,
+			viewer: "not-a-uuid",
+			code:   "INVALID_VIEWER_ID",
+		},
+		{
+			name: "Syntax error",
+			body: `{"query":"{ user(id: "}`,
+		},
+		{
+			name: "Unknown field",
+			body: `{"query":"{ user(id: \"` + alice.ID.String() + `\") { email } }"}`,
+		},
+		{
+			name: "Too deep",
+			body: `{"query":"{ tweet(id: \"` + alice.ID.String() + `\") { author { followers(limit: 1) { followers(limit: 1) { followers(limit: 1) { followers(limit: 1) { followers(limit: 1) { followers(limit: 1) { followers(limit: 1) { name } } } } } } } } } }"}`,
+			code: "QUERY_TOO_DEEP",
+		},
+		{
+			name: "Too complex",
+			body: `{"query":"{ user(id: \"` + alice.ID.String() + `\") { followers(limit: 100) { followers(limit: 100) { name } } } }"}`,
+			code: "QUERY_TOO_COMPLEX",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			// No service is called.
+			router.POST("/graphql", NewHandler(mock_ports.NewMockUserService(ctrl), mock_ports.NewMockTweetService(ctrl), DefaultLimits).Serve)
+
+			request := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tc.body))
+			request.Header.Set("Content-Type", "application/json")
+			if tc.viewer != "" {
+				request.Header.Set(viewerHeader, tc.viewer)
+			}
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, request)
+
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+			var decoded map[string]interface{}
+			assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &decoded))
+			assert.NotContains(t, decoded, "data")
+			assert.Len(t, decoded["errors"], 1)
+			if tc.code != "" {
+				assert.Equal(t, []string{tc.code}, errorCodes(decoded))
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+// Limits bound the cost of an operation; the ones that exceed them are rejected
+// before anything is read.
+type Limits struct {
+	// MaxDepth is how deeply fields may be nested.
+	MaxDepth int
+	// MaxComplexity bounds the number of values an operation may resolve. A field
+	// costs 1 plus the cost of its selections times the number of items it may
+	// return: the limit argument of paginated lists and MaxPageLimit for the rest.
+	MaxComplexity int
+}
+
+// DefaultLimits allow, for instance, a user with a page of tweets and their
+// authors, and a page of followers with a page of their tweets.
+var DefaultLimits = Limits{MaxDepth: 8, MaxComplexity: 5000}
+
+// checkLimits measures the operation of document that will run. Introspection
+// fields are not counted: they are answered from the schema, without reads.
+func checkLimits(schema *gql.Schema, document *ast.Document, operationName string, variables map[string]interface{}, limits Limits) error {
+	m := measurer{
+		schema:    schema,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		ceiling:   limits.MaxComplexity + 1,
+	}
+
+	var operation *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			m.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operation = definition
+			}
+		}
+	}
+
+	var root *gql.Object
+	if operation != nil {
+		switch operation.Operation {
+		case ast.OperationTypeQuery:
+			root = schema.QueryType()
+		case ast.OperationTypeMutation:
+			root = schema.MutationType()
+		}
+	}
+	// The executor rejects the operations that cannot run.
+	if root == nil {
+		return nil
+	}
+
+	// Variables left out take the default of their definition.
+	m.variables = make(map[string]interface{}, len(variables))
+	for _, definition := range operation.VariableDefinitions {
+		if value, ok := definition.DefaultValue.(*ast.IntValue); ok {
+			m.variables[definition.Variable.Name.Value], _ = strconv.ParseFloat(value.Value, 64)
+		}
+	}
+	for name, value := range variables {
+		m.variables[name] = value
+	}
+
+	depth, complexity := m.measure(operation.SelectionSet, root)
+	if depth > limits.MaxDepth {
+		return codedError{message: fmt.Sprintf("query depth %d exceeds the limit of %d", depth, limits.MaxDepth), code: "QUERY_TOO_DEEP"}
+	}
+	if complexity > limits.MaxComplexity {
+		return codedError{message: fmt.Sprintf("query complexity exceeds the limit of %d", limits.MaxComplexity), code: "QUERY_TOO_COMPLEX"}
+	}
+
+	return nil
+}
+
+type measurer struct {
+	schema    *gql.Schema
+	variables map[string]interface{}
+	fragments map[string]*ast.FragmentDefinition
+	// ceiling caps complexities once they exceed the limit, so they cannot overflow.
+	ceiling int
+}
+
+// measure returns the depth and the complexity of the selections made on parent.
+func (m measurer) measure(selections *ast.SelectionSet, parent gql.Type) (depth, complexity int) {
+	if selections == nil {
+		return 0, 0
+	}
+
+	for _, selection := range selections.Selections {
+		var selectionDepth, selectionComplexity int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+
+			field := fieldOf(parent, selection.Name.Value)
+			if field == nil {
+				continue
+			}
+
+			itemType, items := m.items(field, selection)
+			childDepth, childComplexity := m.measure(selection.SelectionSet, itemType)
+			selectionDepth = 1 + childDepth
+			selectionComplexity = 1 + items*childComplexity
+		case *ast.InlineFragment:
+			fragmentType := parent
+			if selection.TypeCondition != nil {
+				fragmentType = m.schema.Type(selection.TypeCondition.Name.Value)
+			}
+			selectionDepth, selectionComplexity = m.measure(selection.SelectionSet, fragmentType)
+		case *ast.FragmentSpread:
+			// Validation has rejected fragment cycles and unknown fragments.
+			if fragment, ok := m.fragments[selection.Name.Value]; ok {
+				selectionDepth, selectionComplexity = m.measure(fragment.SelectionSet, m.schema.Type(fragment.TypeCondition.Name.Value))
+			}
+		}
+
+		depth = max(depth, selectionDepth)
+		complexity = min(complexity+selectionComplexity, m.ceiling)
+	}
+
+	return depth, complexity
+}
+
+// items returns the type of the values field returns and how many it may return.
+func (m measurer) items(field *gql.FieldDefinition, selection *ast.Field) (gql.Type, int) {
+	itemType := gql.GetNamed(field.Type).(gql.Type)
+	fieldType := gql.Type(field.Type)
+	if nonNull, ok := fieldType.(*gql.NonNull); ok {
+		fieldType = nonNull.OfType
+	}
+	if _, ok := fieldType.(*gql.List); !ok {
+		return itemType, 1
+	}
+
+	for _, arg := range field.Args {
+		if arg.Name() == "limit" {
+			return itemType, domain.NewPagination(m.limit(selection), 0).Limit
+		}
+	}
+	return itemType, domain.MaxPageLimit
+}
+
+// limit returns the limit argument of selection, or 0 when it is not given.
+func (m measurer) limit(selection *ast.Field) int {
+	for _, arg := range selection.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			limit, _ := strconv.Atoi(value.Value)
+			return limit
+		case *ast.Variable:
+			// Variables are decoded from JSON, so numbers are float64.
+			if limit, ok := m.variables[value.Name.Value].(float64); ok {
+				return int(limit)
+			}
+		}
+	}
+
+	return 0
+}
+
+func fieldOf(parent gql.Type, name string) *gql.FieldDefinition {
+	if fields, ok := parent.(interface{ Fields() gql.FieldDefinitionMap }); ok {
+		return fields.Fields()[name]
+	}
+
+	return nil
+}
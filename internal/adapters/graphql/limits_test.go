@@ -0,0 +1,88 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckLimits(t *testing.T) {
+	schema, err := NewSchema(nil, nil)
+	assert.NoError(t, err)
+	limits := Limits{MaxDepth: 4, MaxComplexity: 100}
+
+	tests := []struct {
+		name      string
+		query     string
+		operation string
+		variables map[string]interface{}
+		code      string
+	}{
+		{
+			// user 1 + (name 1 + followers 1 + 10 * name 1) = 13
+			name:  "Within the limits",
+			query: `{ user(id: "1") { name followers(limit: 10) { name } } }`,
+		},
+		{
+			name:  "Too deep",
+			query: `{ user(id: "1") { following(limit: 1) { following(limit: 1) { following(limit: 1) { name } } } } }`,
+			code:  "QUERY_TOO_DEEP",
+		},
+		{
+			// followers 1 + 20 * (tweets 1 + 20 * message 1) > 100
+			name:  "Default page size",
+			query: `{ user(id: "1") { followers { tweets { message } } } }`,
+			code:  "QUERY_TOO_COMPLEX",
+		},
+		{
+			name:  "Lists without a limit count as a full page",
+			query: `{ timeline(userId: "1") { message } }`,
+			code:  "QUERY_TOO_COMPLEX",
+		},
+		{
+			name:      "Limit from a variable",
+			query:     `query($limit: Int) { user(id: "1") { followers(limit: $limit) { name } } }`,
+			variables: map[string]interface{}{"limit": float64(99)},
+			code:      "QUERY_TOO_COMPLEX",
+		},
+		{
+			name:  "Limit from the default of a variable",
+			query: `query($limit: Int = 99) { user(id: "1") { followers(limit: $limit) { name } } }`,
+			code:  "QUERY_TOO_COMPLEX",
+		},
+		{
+			name: "Fragments",
+			query: `query { user(id: "1") { ...Follows } }
+				fragment Follows on User { followers(limit: 1) { ... on User { following(limit: 1) { followers(limit: 1) { name } } } } }`,
+			code: "QUERY_TOO_DEEP",
+		},
+		{
+			name: "Only the selected operation",
+			query: `query Small { user(id: "1") { name } }
+				query Large { timeline(userId: "1") { message } }`,
+			operation: "Small",
+		},
+		{
+			name:  "Introspection is not counted",
+			query: `{ __schema { types { name fields { name type { name ofType { name ofType { name } } } } } } }`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			document, err := parser.Parse(parser.ParseParams{Source: tc.query})
+			assert.NoError(t, err)
+
+			err = checkLimits(&schema, document, tc.operation, tc.variables, limits)
+			if tc.code == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var coded codedError
+			assert.ErrorAs(t, err, &coded)
+			assert.Equal(t, tc.code, coded.code)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package graphql
+
+import "context"
+
+// loaded is the outcome of looking up one key.
+type loaded[V any] struct {
+	value V
+	err   error
+}
+
+// loader batches the lookups a query makes while it is resolved. load only queues
+// the key and returns a thunk; the executor calls the thunks of a level of the
+// query once all its fields are resolved, so the first thunk fetches every queued
+// key in a single call. Outcomes are kept, so a loader must not outlive its
+// request. It is not safe for concurrent use: the executor resolves one field at a time.
+type loader[K comparable, V any] struct {
+	fetch    func(ctx context.Context, keys []K) (map[K]V, error)
+	notFound func(key K) error
+
+	queue   []K
+	queued  map[K]bool
+	results map[K]loaded[V]
+}
+
+// newLoader returns a loader that reads the values of a batch of keys with fetch,
+// which leaves out the keys that do not exist, reported with notFound.
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error), notFound func(key K) error) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:    fetch,
+		notFound: notFound,
+		queued:   make(map[K]bool),
+		results:  make(map[K]loaded[V]),
+	}
+}
+
+// load queues key and returns a thunk in the form the executor resolves lazily.
+func (l *loader[K, V]) load(ctx context.Context, key K) func() (interface{}, error) {
+	if _, ok := l.results[key]; !ok && !l.queued[key] {
+		l.queue = append(l.queue, key)
+		l.queued[key] = true
+	}
+
+	return func() (interface{}, error) {
+		if _, ok := l.results[key]; !ok {
+			l.flush(ctx)
+		}
+
+		result := l.results[key]
+		return result.value, result.err
+	}
+}
+
+// flush fetches the queued keys. A failed fetch fails every key of the batch.
+func (l *loader[K, V]) flush(ctx context.Context) {
+	keys := l.queue
+	l.queue = nil
+	clear(l.queued)
+
+	values, err := l.fetch(ctx, keys)
+	for _, key := range keys {
+		value, ok := values[key]
+		switch {
+		case err != nil:
+			l.results[key] = loaded[V]{err: err}
+		case !ok:
+			l.results[key] = loaded[V]{err: l.notFound(key)}
+		default:
+			l.results[key] = loaded[V]{value: value}
+		}
+	}
+}
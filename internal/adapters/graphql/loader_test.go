@@ -0,0 +1,60 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoader(t *testing.T) {
+	var batches [][]int
+	fail := false
+	l := newLoader(func(_ context.Context, keys []int) (map[int]string, error) {
+		batches = append(batches, keys)
+		if fail {
+			return nil, errors.New("unavailable")
+		}
+
+		values := make(map[int]string)
+		for _, key := range keys {
+			if key > 0 {
+				values[key] = strconv.Itoa(key)
+			}
+		}
+		return values, nil
+	}, func(key int) error {
+		return fmt.Errorf("%d not found", key)
+	})
+
+	ctx := context.Background()
+	one, two, oneAgain, missing := l.load(ctx, 1), l.load(ctx, 2), l.load(ctx, 1), l.load(ctx, -1)
+
+	value, err := two()
+	assert.NoError(t, err)
+	assert.Equal(t, "2", value)
+	value, err = one()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", value)
+	value, err = oneAgain()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", value)
+	_, err = missing()
+	assert.EqualError(t, err, "-1 not found")
+
+	// Loaded keys are not fetched again; a failed fetch fails its whole batch.
+	fail = true
+	cached, three, four := l.load(ctx, 2), l.load(ctx, 3), l.load(ctx, 4)
+	value, err = cached()
+	assert.NoError(t, err)
+	assert.Equal(t, "2", value)
+	_, err = three()
+	assert.EqualError(t, err, "unavailable")
+	_, err = four()
+	assert.EqualError(t, err, "unavailable")
+
+	assert.Equal(t, [][]int{{1, 2, -1}, {3, 4}}, batches)
+}
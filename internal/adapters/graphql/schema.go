@@ -0,0 +1,424 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	gql "github.com/graphql-go/graphql"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/services"
+)
+
+// maxTweetLength matches the validation of the REST endpoint that creates tweets.
+const maxTweetLength = 280
+
+// codedError is a resolver error with the machine-readable code the REST API uses
+// for the same failure, reported in the extensions of the GraphQL error.
+type codedError struct {
+	message string
+	code    string
+}
+
+func (e codedError) Error() string {
+	return e.message
+}
+
+func (e codedError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+var errInvalidPagination = codedError{message: "limit and offset must be non-negative integers", code: "INVALID_PAGINATION"}
+
+type requestKey struct{}
+
+// request is the state shared by the resolvers of a single GraphQL request.
+type request struct {
+	viewerID uuid.UUID
+	users    *loader[uuid.UUID, domain.User]
+}
+
+func requestFrom(ctx context.Context) *request {
+	return ctx.Value(requestKey{}).(*request)
+}
+
+type resolvers struct {
+	users  services.UserService
+	tweets services.TweetService
+}
+
+// newRequest returns ctx carrying the state of a request made by viewerID.
+func (r resolvers) newRequest(ctx context.Context, viewerID uuid.UUID) context.Context {
+	users := newLoader(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.User, error) {
+		found, err := r.users.GetUsers(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		byID := make(map[uuid.UUID]domain.User, len(found))
+		for _, user := range found {
+			byID[user.ID] = user
+		}
+		return byID, nil
+	}, func(id uuid.UUID) error {
+		return fmt.Errorf("user %s not found", id)
+	})
+
+	return context.WithValue(ctx, requestKey{}, &request{viewerID: viewerID, users: users})
+}
+
+// NewSchema describes users, their tweets and follows. Lists of users and tweets
+// are paginated like their REST counterparts, and tweet authors are looked up in
+// batches, so listing tweets costs one read of their authors per level of the query.
+func NewSchema(users services.UserService, tweets services.TweetService) (gql.Schema, error) {
+	r := resolvers{users: users, tweets: tweets}
+
+	pageArgs := gql.FieldConfigArgument{
+		"limit": &gql.ArgumentConfig{
+			Type:         gql.Int,
+			DefaultValue: domain.DefaultPageLimit,
+			Description:  fmt.Sprintf("Page size, at most %d.", domain.MaxPageLimit),
+		},
+		"offset": &gql.ArgumentConfig{
+			Type:         gql.Int,
+			DefaultValue: 0,
+		},
+	}
+
+	var userType *gql.Object
+	tweetType := gql.NewObject(gql.ObjectConfig{
+		Name: "Tweet",
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return gql.Fields{
+				"id": &gql.Field{
+					Type: gql.NewNonNull(gql.ID),
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						return p.Source.(domain.Tweet).ID.String(), nil
+					},
+				},
+				"message": &gql.Field{
+					Type: gql.NewNonNull(gql.String),
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						return p.Source.(domain.Tweet).Message, nil
+					},
+				},
+				"createdAt": &gql.Field{
+					Type: gql.NewNonNull(gql.DateTime),
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						return p.Source.(domain.Tweet).CreatedAt, nil
+					},
+				},
+				"author": &gql.Field{
+					Type:    gql.NewNonNull(userType),
+					Resolve: r.tweetAuthor,
+				},
+			}
+		}),
+	})
+
+	// The email of a user is left out, as in the lists of the REST API.
+	userType = gql.NewObject(gql.ObjectConfig{
+		Name: "User",
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return gql.Fields{
+				"id": &gql.Field{
+					Type: gql.NewNonNull(gql.ID),
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						return p.Source.(domain.User).ID.String(), nil
+					},
+				},
+				"name": &gql.Field{
+					Type: gql.NewNonNull(gql.String),
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						return p.Source.(domain.User).Name, nil
+					},
+				},
+				"protected": &gql.Field{
+					Type: gql.NewNonNull(gql.Boolean),
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						return p.Source.(domain.User).Protected, nil
+					},
+				},
+				"followersCount": &gql.Field{
+					Type: gql.NewNonNull(gql.Int),
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						return p.Source.(domain.User).FollowersCount, nil
+					},
+				},
+				"followingCount": &gql.Field{
+					Type: gql.NewNonNull(gql.Int),
+					Resolve: func(p gql.ResolveParams) (interface{}, error) {
+						return p.Source.(domain.User).FollowingCount, nil
+					},
+				},
+				"tweetsCount": &gql.Field{
+					Type:        gql.NewNonNull(gql.Int),
+					Description: "0 for protected accounts whose tweets the viewer may not read.",
+					Resolve:     r.userTweetsCount,
+				},
+				"tweets": &gql.Field{
+					Type:        gql.NewNonNull(gql.NewList(gql.NewNonNull(tweetType))),
+					Description: "The tweets of the user, newest first, as seen by the viewer.",
+					Args:        pageArgs,
+					Resolve:     r.userTweets,
+				},
+				"followers": &gql.Field{
+					Type:    gql.NewNonNull(gql.NewList(gql.NewNonNull(userType))),
+					Args:    pageArgs,
+					Resolve: r.userFollowers,
+				},
+				"following": &gql.Field{
+					Type:    gql.NewNonNull(gql.NewList(gql.NewNonNull(userType))),
+					Args:    pageArgs,
+					Resolve: r.userFollowing,
+				},
+			}
+		}),
+	})
+
+	followStatusType := gql.NewEnum(gql.EnumConfig{
+		Name: "FollowStatus",
+		Values: gql.EnumValueConfigMap{
+			"FOLLOWING": &gql.EnumValueConfig{
+				Value:       domain.FollowStatusFollowing,
+				Description: "The follow took effect.",
+			},
+			"PENDING": &gql.EnumValueConfig{
+				Value:       domain.FollowStatusPending,
+				Description: "The account is protected and has to approve the follow request.",
+			},
+		},
+	})
+
+	query := gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: gql.Fields{
+			"user": &gql.Field{
+				Type: userType,
+				Args: gql.FieldConfigArgument{
+					"id": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+				},
+				Resolve: r.user,
+			},
+			"tweet": &gql.Field{
+				Type: tweetType,
+				Args: gql.FieldConfigArgument{
+					"id": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+				},
+				Resolve: r.tweet,
+			},
+			"timeline": &gql.Field{
+				Type:        gql.NewNonNull(gql.NewList(gql.NewNonNull(tweetType))),
+				Description: "The tweets of the users that userId follows.",
+				Args: gql.FieldConfigArgument{
+					"userId": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+				},
+				Resolve: r.timeline,
+			},
+		},
+	})
+
+	mutation := gql.NewObject(gql.ObjectConfig{
+		Name: "Mutation",
+		Fields: gql.Fields{
+			"createTweet": &gql.Field{
+				Type:        gql.NewNonNull(tweetType),
+				Description: "Publishes a tweet of userId, who must be the viewer.",
+				Args: gql.FieldConfigArgument{
+					"userId":  &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+					"message": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.String)},
+				},
+				Resolve: r.createTweet,
+			},
+			"followUser": &gql.Field{
+				Type:        gql.NewNonNull(followStatusType),
+				Description: "Makes userId, who must be the viewer, follow targetUserId, or asks to when the account is protected.",
+				Args: gql.FieldConfigArgument{
+					"userId":       &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+					"targetUserId": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+				},
+				Resolve: r.followUser,
+			},
+		},
+	})
+
+	return gql.NewSchema(gql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func (r resolvers) user(p gql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id", "INVALID_USER_ID")
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.users.GetUserAsViewer(p.Context, requestFrom(p.Context).viewerID, id)
+	if err != nil {
+		return nil, codedError{message: "user not found", code: "USER_NOT_FOUND"}
+	}
+
+	return user, nil
+}
+
+func (r resolvers) tweet(p gql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id", "INVALID_TWEET_ID")
+	if err != nil {
+		return nil, err
+	}
+
+	tweet, err := r.tweets.GetTweet(p.Context, requestFrom(p.Context).viewerID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrTweetNotFound) {
+			return nil, codedError{message: err.Error(), code: "TWEET_NOT_FOUND"}
+		}
+		return nil, err
+	}
+
+	return tweet, nil
+}
+
+func (r resolvers) timeline(p gql.ResolveParams) (interface{}, error) {
+	userID, err := idArg(p, "userId", "INVALID_USER_ID")
+	if err != nil {
+		return nil, err
+	}
+
+	// As in the REST API, the timeline includes protected accounts the user follows.
+	if userID != requestFrom(p.Context).viewerID {
+		return nil, codedError{message: "the timeline is only visible to its owner", code: "TIMELINE_FORBIDDEN"}
+	}
+
+	return r.users.GetUserTimeline(p.Context, userID)
+}
+
+// tweetAuthor queues the author in the request's loader, so the authors of all
+// the tweets at the same level of the query are read together.
+func (r resolvers) tweetAuthor(p gql.ResolveParams) (interface{}, error) {
+	return requestFrom(p.Context).users.load(p.Context, p.Source.(domain.Tweet).UserID), nil
+}
+
+func (r resolvers) userTweetsCount(p gql.ResolveParams) (interface{}, error) {
+	user := p.Source.(domain.User)
+	viewerID := requestFrom(p.Context).viewerID
+	if !user.Protected || user.ID == viewerID {
+		return user.TweetsCount, nil
+	}
+
+	visible, err := r.users.CanViewTweets(p.Context, viewerID, user.ID)
+	if err != nil || !visible {
+		return 0, err
+	}
+	return user.TweetsCount, nil
+}
+
+func (r resolvers) userTweets(p gql.ResolveParams) (interface{}, error) {
+	page, err := pageArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	tweets, err := r.tweets.GetUserTweets(p.Context, requestFrom(p.Context).viewerID, p.Source.(domain.User).ID, page)
+	if err != nil {
+		if errors.Is(err, domain.ErrTweetsProtected) {
+			return nil, codedError{message: err.Error(), code: "TWEETS_PROTECTED"}
+		}
+		return nil, err
+	}
+
+	return tweets, nil
+}
+
+func (r resolvers) userFollowers(p gql.ResolveParams) (interface{}, error) {
+	page, err := pageArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.users.GetFollowers(p.Context, p.Source.(domain.User).ID, page)
+}
+
+func (r resolvers) userFollowing(p gql.ResolveParams) (interface{}, error) {
+	page, err := pageArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.users.GetFollowing(p.Context, p.Source.(domain.User).ID, page)
+}
+
+func (r resolvers) createTweet(p gql.ResolveParams) (interface{}, error) {
+	userID, err := viewerArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	message := p.Args["message"].(string)
+	if message == "" || utf8.RuneCountInString(message) > maxTweetLength {
+		return nil, codedError{
+			message: fmt.Sprintf("message must have between 1 and %d characters", maxTweetLength),
+			code:    "EXCEEDED_MAX_TWEET_CHARACTERS",
+		}
+	}
+
+	return r.tweets.CreateTweet(p.Context, userID, message)
+}
+
+func (r resolvers) followUser(p gql.ResolveParams) (interface{}, error) {
+	userID, err := viewerArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	targetUserID, err := idArg(p, "targetUserId", "INVALID_FOLLOWED_USER_ID")
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := r.users.FollowUser(p.Context, userID, targetUserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserBlocked) {
+			return nil, codedError{message: err.Error(), code: "USER_BLOCKED"}
+		}
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// idArg parses the ID argument name, reporting code when it is not a UUID.
+func idArg(p gql.ResolveParams, name, code string) (uuid.UUID, error) {
+	id, err := uuid.Parse(p.Args[name].(string))
+	if err != nil {
+		return uuid.Nil, codedError{message: fmt.Sprintf("invalid %s", name), code: code}
+	}
+
+	return id, nil
+}
+
+// viewerArg parses the userId argument of a mutation, which only the viewer may act
+// as.
+func viewerArg(p gql.ResolveParams) (uuid.UUID, error) {
+	userID, err := idArg(p, "userId", "INVALID_USER_ID")
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	viewerID := requestFrom(p.Context).viewerID
+	if viewerID == uuid.Nil || userID != viewerID {
+		return uuid.Nil, codedError{message: "mutations can only act as the viewer", code: "UNAUTHORIZED"}
+	}
+
+	return userID, nil
+}
+
+func pageArgs(p gql.ResolveParams) (domain.Pagination, error) {
+	// An explicit null leaves the argument out, which selects the default page.
+	limit, _ := p.Args["limit"].(int)
+	offset, _ := p.Args["offset"].(int)
+	if limit < 0 || offset < 0 {
+		return domain.Pagination{}, errInvalidPagination
+	}
+
+	return domain.NewPagination(limit, offset), nil
+}
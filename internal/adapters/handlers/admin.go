@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireAdmin guards the admin routes, such as the webhooks: it only lets through
+// requests with token as the bearer token of their Authorization header. With an
+// empty token every request is rejected, so the admin routes are off until one is set.
+func RequireAdmin(token string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		provided, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if token == "" || !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			writeError(ctx, http.StatusUnauthorized, NewErrorResponseWithCode("A valid admin token is required", "UNAUTHORIZED"))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestRequireAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		token              string
+		authorization      string
+		expectedStatusCode int
+	}{
+		{
+			name:               "Success - Valid token",
+			token:              "t0ken",
+			authorization:      "Bearer t0ken",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Failure - Missing token",
+			token:              "t0ken",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Failure - Wrong token",
+			token:              "t0ken",
+			authorization:      "Bearer other",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Failure - Not a bearer token",
+			token:              "t0ken",
+			authorization:      "t0ken",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Failure - No admin token configured",
+			authorization:      "Bearer ",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/admin", RequireAdmin(tt.token), func(ctx *gin.Context) {
+				ctx.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "/admin", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.expectedStatusCode == http.StatusUnauthorized {
+				assert.Equal(t, `{"error":"A valid admin token is required","code":"UNAUTHORIZED"}`, w.Body.String())
+			}
+		})
+	}
+}
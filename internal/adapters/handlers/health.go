@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const DefaultReadinessTimeout = 2 * time.Second
+
+// Backend is the storage the readiness check probes.
+type Backend interface {
+	// Name is the kind of storage, such as postgres, sqlite or memory.
+	Name() string
+	Ping(ctx context.Context) error
+	// MigrationVersion returns the newest migration applied to the schema and the
+	// newest one the binary knows. Backends without migrations return 0 for both.
+	MigrationVersion(ctx context.Context) (current int64, latest int64, err error)
+}
+
+type HealthResponse struct {
+	Status                 string `json:"status"`
+	Backend                string `json:"backend,omitempty"`
+	MigrationVersion       int64  `json:"migration_version"`
+	LatestMigrationVersion int64  `json:"latest_migration_version"`
+	Error                  string `json:"error,omitempty"`
+}
+
+var errShuttingDown = errors.New("shutting down")
+
+// HealthHandler answers the liveness and readiness probes of orchestrators and
+// docker-compose.
+type HealthHandler struct {
+	backend      Backend
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+func NewHealthHandler(backend Backend, timeout time.Duration) *HealthHandler {
+	return &HealthHandler{
+		backend: backend,
+		timeout: timeout,
+	}
+}
+
+// SetShuttingDown makes the readiness check fail from now on, so traffic stops
+// being routed to the process while it finishes the requests in flight.
+func (h *HealthHandler) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// Live answers as long as the process can serve HTTP, whatever the state of its
+// dependencies: restarting it would not fix them.
+func (h *HealthHandler) Live(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Ready answers 200 when the process can take traffic: it is not shutting down,
+// the database answers a ping within the timeout and its schema has every
+// migration the binary knows. Otherwise it answers 503 with the reason.
+func (h *HealthHandler) Ready(ctx *gin.Context) {
+	response := HealthResponse{Status: "ready", Backend: h.backend.Name()}
+	if err := h.check(ctx, &response); err != nil {
+		response.Status = "not_ready"
+		response.Error = err.Error()
+		ctx.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *HealthHandler) check(ctx context.Context, response *HealthResponse) error {
+	if h.shuttingDown.Load() {
+		return errShuttingDown
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	if err := h.backend.Ping(ctx); err != nil {
+		return fmt.Errorf("database ping: %w", err)
+	}
+
+	current, latest, err := h.backend.MigrationVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("migration version: %w", err)
+	}
+	response.MigrationVersion = current
+	response.LatestMigrationVersion = latest
+	if current < latest {
+		return fmt.Errorf("pending migrations: schema at version %d, expected %d", current, latest)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type fakeBackend struct {
+	ping    func(ctx context.Context) error
+	current int64
+	latest  int64
+}
+
+func (b fakeBackend) Name() string {
+	return "postgres"
+}
+
+func (b fakeBackend) Ping(ctx context.Context) error {
+	if b.ping == nil {
+		return nil
+	}
+	return b.ping(ctx)
+}
+
+func (b fakeBackend) MigrationVersion(context.Context) (int64, int64, error) {
+	return b.current, b.latest, nil
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		backend            fakeBackend
+		shuttingDown       bool
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:               "Ready",
+			backend:            fakeBackend{current: 5, latest: 5},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"status":"ready","backend":"postgres","migration_version":5,"latest_migration_version":5}`,
+		},
+		{
+			name:               "Ready - Schema ahead of the binary",
+			backend:            fakeBackend{current: 6, latest: 5},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"status":"ready","backend":"postgres","migration_version":6,"latest_migration_version":5}`,
+		},
+		{
+			name:               "Not ready - Pending migrations",
+			backend:            fakeBackend{current: 3, latest: 5},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedResponse:   `{"status":"not_ready","backend":"postgres","migration_version":3,"latest_migration_version":5,"error":"pending migrations: schema at version 3, expected 5"}`,
+		},
+		{
+			name:               "Not ready - Ping fails",
+			backend:            fakeBackend{ping: func(context.Context) error { return errors.New("connection refused") }},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedResponse:   `{"status":"not_ready","backend":"postgres","migration_version":0,"latest_migration_version":0,"error":"database ping: connection refused"}`,
+		},
+		{
+			name: "Not ready - Ping times out",
+			backend: fakeBackend{ping: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedResponse:   `{"status":"not_ready","backend":"postgres","migration_version":0,"latest_migration_version":0,"error":"database ping: context deadline exceeded"}`,
+		},
+		{
+			name:               "Not ready - Shutting down",
+			backend:            fakeBackend{current: 5, latest: 5},
+			shuttingDown:       true,
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedResponse:   `{"status":"not_ready","backend":"postgres","migration_version":0,"latest_migration_version":0,"error":"shutting down"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHealthHandler(tc.backend, 10*time.Millisecond)
+			if tc.shuttingDown {
+				handler.SetShuttingDown()
+			}
+
+			router := gin.New()
+			router.GET("/readyz", handler.Ready)
+			router.GET("/healthz", handler.Live)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponse, w.Body.String())
+
+			// Liveness does not depend on the database or the shutdown.
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, `{"status":"ok"}`, w.Body.String())
+		})
+	}
+}
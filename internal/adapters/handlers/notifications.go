@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/services"
+	"net/http"
+)
+
+type NotificationHandler struct {
+	service services.NotificationService
+}
+
+func NewNotificationHandler(service services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		service: service,
+	}
+}
+
+type MarkNotificationsReadBody struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+// GetNotifications lists the notifications of the :id user, who must be the viewer.
+func (h *NotificationHandler) GetNotifications(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		return
+	}
+
+	if !requireViewer(ctx, userID, "The notifications are only visible to their owner", "NOTIFICATIONS_FORBIDDEN") {
+		return
+	}
+
+	page, err := parsePagination(ctx)
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode(err.Error(), "INVALID_PAGINATION"))
+		return
+	}
+
+	groups, err := h.service.GetNotifications(ctx, userID, page)
+	if err != nil {
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	unread, err := h.service.CountUnread(ctx, userID)
+	if err != nil {
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := NewSuccessResponse("Notifications retrieved successfully", ToNotificationsResponse(groups, unread, page))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// MarkAsRead marks the notifications listed in the body as read, or every notification when no ids are sent.
+func (h *NotificationHandler) MarkAsRead(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		return
+	}
+
+	if !requireViewer(ctx, userID, "Only the user can mark their notifications as read", "NOTIFICATIONS_FORBIDDEN") {
+		return
+	}
+
+	var body MarkNotificationsReadBody
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&body); err != nil {
+			writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode(err.Error(), "INVALID_REQUEST_BODY"))
+			return
+		}
+	}
+
+	if err := h.service.MarkAsRead(ctx, userID, body.IDs); err != nil {
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, NewSuccessResponse("Notifications marked as read", nil))
+}
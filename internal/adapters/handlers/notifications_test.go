@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	mock_ports "github.com/juanignaciorc/microbloggin-pltf/mocks"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNotificationHandler_GetNotifications(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_ports.NewMockNotificationService(ctrl)
+
+	// Create the handler with the mock service
+	handler := NewNotificationHandler(mockService)
+
+	latestAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	notificationID := uuid.MustParse(uuidMock)
+
+	tests := []struct {
+		name               string
+		userID             string
+		viewerID           string
+		setupMock          func()
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:     "Success - Grouped notifications",
+			userID:   userUuidMock,
+			viewerID: userUuidMock,
+			setupMock: func() {
+				groups := []domain.NotificationGroup{
+					{
+						Type:            domain.NotificationTypeFollow,
+						ActorIDs:        []uuid.UUID{uuid.MustParse(followedUserUuidMock), uuid.MustParse(userUuidMock)},
+						NotificationIDs: []uuid.UUID{notificationID, notificationID},
+						Unread:          true,
+						LatestAt:        latestAt,
+					},
+				}
+				mockService.EXPECT().
+					GetNotifications(gomock.Any(), uuid.MustParse(userUuidMock), domain.Pagination{Limit: domain.DefaultPageLimit}).
+					Return(groups, nil)
+				mockService.EXPECT().
+					CountUnread(gomock.Any(), uuid.MustParse(userUuidMock)).
+					Return(2, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: fmt.Sprintf(`{"message":"Notifications retrieved successfully","data":{"unread_count":2,"groups":[{"type":"follow","summary":"2 people followed you","count":2,"actor_ids":["%s","%s"],"notification_ids":["%s","%s"],"unread":true,"latest_at":"2024-01-02T03:04:05Z"}],"limit":20,"offset":0}}`,
+				followedUserUuidMock, userUuidMock, uuidMock, uuidMock),
+		},
+		{
+			name:     "Failure - Service error",
+			userID:   userUuidMock,
+			viewerID: userUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					GetNotifications(gomock.Any(), uuid.MustParse(userUuidMock), gomock.Any()).
+					Return(nil, errors.New("notifications error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error":"notifications error"}`,
+		},
+		{
+			name:               "Failure - Invalid UUID",
+			userID:             "invalid-uuid",
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Invalid user ID","code":"INVALID_USER_ID"}`,
+		},
+		{
+			name:               "Failure - Someone else's notifications",
+			userID:             userUuidMock,
+			viewerID:           followedUserUuidMock,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"The notifications are only visible to their owner","code":"NOTIFICATIONS_FORBIDDEN"}`,
+		},
+		{
+			name:               "Failure - Anonymous viewer",
+			userID:             userUuidMock,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"The notifications are only visible to their owner","code":"NOTIFICATIONS_FORBIDDEN"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up mock expectations
+			tt.setupMock()
+
+			// Create a new HTTP request
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/users/%s/notifications", tt.userID), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.viewerID != "" {
+				req.Header.Set("X-User-ID", tt.viewerID)
+			}
+
+			// Create a response recorder to capture the response
+			rr := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: "id", Value: tt.userID},
+			}
+
+			handler.GetNotifications(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestNotificationHandler_MarkAsRead(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_ports.NewMockNotificationService(ctrl)
+
+	// Create the handler with the mock service
+	handler := NewNotificationHandler(mockService)
+
+	tests := []struct {
+		name               string
+		viewerID           string
+		requestBody        string
+		setupMock          func()
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:        "Success - Selected notifications",
+			viewerID:    userUuidMock,
+			requestBody: fmt.Sprintf(`{"ids":["%s"]}`, uuidMock),
+			setupMock: func() {
+				mockService.EXPECT().
+					MarkAsRead(gomock.Any(), uuid.MustParse(userUuidMock), []uuid.UUID{uuid.MustParse(uuidMock)}).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"message":"Notifications marked as read"}`,
+		},
+		{
+			name:     "Success - All notifications without body",
+			viewerID: userUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					MarkAsRead(gomock.Any(), uuid.MustParse(userUuidMock), gomock.Nil()).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"message":"Notifications marked as read"}`,
+		},
+		{
+			name:               "Failure - Invalid body",
+			viewerID:           userUuidMock,
+			requestBody:        `{"ids":["not-a-uuid"]}`,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"invalid UUID length: 10","code":"INVALID_REQUEST_BODY"}`,
+		},
+		{
+			name:               "Failure - Someone else's notifications",
+			viewerID:           followedUserUuidMock,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"Only the user can mark their notifications as read","code":"NOTIFICATIONS_FORBIDDEN"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up mock expectations
+			tt.setupMock()
+
+			// Create a new HTTP request
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/users/%s/notifications/read", userUuidMock), bytes.NewBufferString(tt.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.Header.Set("Content-Type", "application/json")
+			if tt.viewerID != "" {
+				req.Header.Set("X-User-ID", tt.viewerID)
+			}
+
+			// Create a response recorder to capture the response
+			rr := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: "id", Value: userUuidMock},
+			}
+
+			handler.MarkAsRead(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+var errInvalidPagination = errors.New("limit and offset must be non-negative integers")
+
+// parsePagination reads the optional limit and offset query parameters.
+func parsePagination(ctx *gin.Context) (domain.Pagination, error) {
+	limit, err := queryInt(ctx, "limit", domain.DefaultPageLimit)
+	if err != nil {
+		return domain.Pagination{}, err
+	}
+
+	offset, err := queryInt(ctx, "offset", 0)
+	if err != nil {
+		return domain.Pagination{}, err
+	}
+
+	return domain.NewPagination(limit, offset), nil
+}
+
+func queryInt(ctx *gin.Context, key string, fallback int) (int, error) {
+	raw, ok := ctx.GetQuery(key)
+	if !ok || raw == "" {
+		return fallback, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		return 0, errInvalidPagination
+	}
+
+	return value, nil
+}
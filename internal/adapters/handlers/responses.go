@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"fmt"
+	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"go.opentelemetry.io/otel/trace"
+	"time"
 )
 
 // Response DTOs to avoid exposing domain objects
@@ -17,6 +21,7 @@ type UserDetailResponse struct {
 	ID             uuid.UUID `json:"id"`
 	Name           string    `json:"name"`
 	Email          string    `json:"email"` // Only included in detailed responses when appropriate
+	Protected      bool      `json:"protected"`
 	FollowersCount int       `json:"followers_count"`
 	FollowingCount int       `json:"following_count"`
 	TweetsCount    int       `json:"tweets_count"`
@@ -28,12 +33,79 @@ type TweetResponse struct {
 	User    UserResponse `json:"user"` // Nested user info without sensitive data
 }
 
+type UserListResponse struct {
+	Users  []UserResponse `json:"users"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+type TweetListResponse struct {
+	Tweets []TweetResponse `json:"tweets"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
+
+type RelationshipResponse struct {
+	UserID       uuid.UUID `json:"user_id"`
+	TargetUserID uuid.UUID `json:"target_user_id"`
+	Following    bool      `json:"following"`
+	FollowedBy   bool      `json:"followed_by"`
+}
+
+type NotificationGroupResponse struct {
+	Type            domain.NotificationType `json:"type"`
+	Summary         string                  `json:"summary"`
+	Count           int                     `json:"count"`
+	ActorIDs        []uuid.UUID             `json:"actor_ids"`
+	NotificationIDs []uuid.UUID             `json:"notification_ids"`
+	TweetID         *uuid.UUID              `json:"tweet_id,omitempty"`
+	Unread          bool                    `json:"unread"`
+	LatestAt        time.Time               `json:"latest_at"`
+}
+
+type NotificationsResponse struct {
+	UnreadCount int                         `json:"unread_count"`
+	Groups      []NotificationGroupResponse `json:"groups"`
+	Limit       int                         `json:"limit"`
+	Offset      int                         `json:"offset"`
+}
+
+type WebhookResponse struct {
+	ID               uuid.UUID          `json:"id"`
+	URL              string             `json:"url"`
+	Events           []domain.EventType `json:"events"`
+	IncludeProtected bool               `json:"include_protected"`
+	Secret           string             `json:"secret,omitempty"` // Only returned when the webhook is created
+	CreatedAt        time.Time          `json:"created_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID                    `json:"id"`
+	WebhookID      uuid.UUID                    `json:"webhook_id"`
+	EventID        uuid.UUID                    `json:"event_id"`
+	EventType      domain.EventType             `json:"event_type"`
+	Status         domain.WebhookDeliveryStatus `json:"status"`
+	Attempts       int                          `json:"attempts"`
+	LastStatusCode int                          `json:"last_status_code,omitempty"`
+	LastError      string                       `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time                   `json:"next_attempt_at,omitempty"` // Only set while the delivery is pending
+	CreatedAt      time.Time                    `json:"created_at"`
+	UpdatedAt      time.Time                    `json:"updated_at"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+	Limit      int                       `json:"limit"`
+	Offset     int                       `json:"offset"`
+}
+
 // Error response structures for better error formatting
 
 type ErrorResponse struct {
 	Error   string            `json:"error"`
 	Code    string            `json:"code,omitempty"`
 	Details map[string]string `json:"details,omitempty"`
+	TraceID string            `json:"trace_id,omitempty"`
 }
 
 type SuccessResponse struct {
@@ -48,9 +120,135 @@ func ToUserDetailResponse(user domain.User) UserDetailResponse {
 		ID:             user.ID,
 		Name:           user.Name,
 		Email:          user.Email,
-		FollowersCount: len(user.Followers),
-		FollowingCount: len(user.Follwing), // Note: keeping the typo from domain for now
-		TweetsCount:    len(user.Tweets),
+		Protected:      user.Protected,
+		FollowersCount: user.FollowersCount,
+		FollowingCount: user.FollowingCount,
+		TweetsCount:    user.TweetsCount,
+	}
+}
+
+func ToUserResponse(user domain.User) UserResponse {
+	return UserResponse{
+		ID:   user.ID,
+		Name: user.Name,
+	}
+}
+
+func ToUserListResponse(users []domain.User, page domain.Pagination) UserListResponse {
+	userResponses := make([]UserResponse, len(users))
+	for i, user := range users {
+		userResponses[i] = ToUserResponse(user)
+	}
+
+	return UserListResponse{
+		Users:  userResponses,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}
+}
+
+// ToTweetListResponse renders tweets that all belong to user.
+func ToTweetListResponse(tweets []domain.Tweet, user domain.User, page domain.Pagination) TweetListResponse {
+	tweetResponses := make([]TweetResponse, len(tweets))
+	for i, tweet := range tweets {
+		tweetResponses[i] = ToTweetResponseWithUser(tweet, user)
+	}
+
+	return TweetListResponse{
+		Tweets: tweetResponses,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}
+}
+
+func ToNotificationsResponse(groups []domain.NotificationGroup, unreadCount int, page domain.Pagination) NotificationsResponse {
+	groupResponses := make([]NotificationGroupResponse, len(groups))
+	for i, group := range groups {
+		groupResponses[i] = NotificationGroupResponse{
+			Type:            group.Type,
+			Summary:         notificationSummary(group.Type, len(group.ActorIDs)),
+			Count:           len(group.ActorIDs),
+			ActorIDs:        group.ActorIDs,
+			NotificationIDs: group.NotificationIDs,
+			TweetID:         group.TweetID,
+			Unread:          group.Unread,
+			LatestAt:        group.LatestAt,
+		}
+	}
+
+	return NotificationsResponse{
+		UnreadCount: unreadCount,
+		Groups:      groupResponses,
+		Limit:       page.Limit,
+		Offset:      page.Offset,
+	}
+}
+
+// notificationSummary renders a group as a sentence such as "3 people followed you".
+func notificationSummary(notificationType domain.NotificationType, actors int) string {
+	who := "Someone"
+	if actors > 1 {
+		who = fmt.Sprintf("%d people", actors)
+	}
+
+	switch notificationType {
+	case domain.NotificationTypeFollow:
+		return who + " followed you"
+	case domain.NotificationTypeFollowRequest:
+		return who + " requested to follow you"
+	case domain.NotificationTypeMention:
+		return who + " mentioned you"
+	case domain.NotificationTypeReply:
+		return who + " replied to your tweet"
+	case domain.NotificationTypeLike:
+		return who + " liked your tweet"
+	default:
+		return who + " interacted with you"
+	}
+}
+
+func ToWebhookResponse(webhook domain.Webhook) WebhookResponse {
+	return WebhookResponse{
+		ID:               webhook.ID,
+		URL:              webhook.URL,
+		Events:           webhook.Events,
+		IncludeProtected: webhook.IncludeProtected,
+		CreatedAt:        webhook.CreatedAt,
+	}
+}
+
+func ToWebhookDeliveryResponse(delivery domain.WebhookDelivery) WebhookDeliveryResponse {
+	response := WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+
+	if delivery.Status == domain.WebhookDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+
+	return response
+}
+
+func ToWebhookDeliveryListResponse(deliveries []domain.WebhookDelivery, page domain.Pagination) WebhookDeliveryListResponse {
+	deliveryResponses := make([]WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		deliveryResponses[i] = ToWebhookDeliveryResponse(delivery)
+	}
+
+	return WebhookDeliveryListResponse{
+		Deliveries: deliveryResponses,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
 	}
 }
 
@@ -66,10 +264,7 @@ func ToTweetResponseWithUser(tweet domain.Tweet, user domain.User) TweetResponse
 	return TweetResponse{
 		ID:      tweet.ID,
 		Message: tweet.Message,
-		User: UserResponse{
-			ID:   user.ID,
-			Name: user.Name,
-		},
+		User:    ToUserResponse(user),
 	}
 }
 
@@ -88,6 +283,16 @@ func NewErrorResponseWithCode(message, code string) ErrorResponse {
 	}
 }
 
+// writeError writes response with the ID of the request's trace, when it is traced,
+// so a failed request can be looked up in the tracing backend.
+func writeError(ctx *gin.Context, status int, response ErrorResponse) {
+	if spanContext := trace.SpanContextFromContext(ctx.Request.Context()); spanContext.HasTraceID() {
+		response.TraceID = spanContext.TraceID().String()
+	}
+
+	ctx.JSON(status, response)
+}
+
 func NewSuccessResponse(message string, data interface{}) SuccessResponse {
 	return SuccessResponse{
 		Message: message,
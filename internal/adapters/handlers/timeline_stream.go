@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/streaming"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/services"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const DefaultHeartbeatInterval = 15 * time.Second
+
+// TimelineStreamHandler pushes new tweets from followed users as Server-Sent Events.
+type TimelineStreamHandler struct {
+	hub               *streaming.Hub
+	userService       services.UserService
+	heartbeatInterval time.Duration
+}
+
+func NewTimelineStreamHandler(hub *streaming.Hub, userService services.UserService, heartbeatInterval time.Duration) *TimelineStreamHandler {
+	return &TimelineStreamHandler{
+		hub:               hub,
+		userService:       userService,
+		heartbeatInterval: heartbeatInterval,
+	}
+}
+
+// Stream keeps the connection open and writes one "tweet" event per new timeline tweet.
+// Like the timeline itself, only the :id user may stream it.
+// A heartbeat comment is sent every heartbeatInterval, which is also when the set of
+// followed users is refreshed. Clients resume with the standard Last-Event-ID header.
+func (h *TimelineStreamHandler) Stream(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		return
+	}
+
+	if !requireViewer(ctx, userID, "The timeline is only visible to its owner", "TIMELINE_FORBIDDEN") {
+		return
+	}
+
+	authors := &authorSet{}
+	if err := h.refreshAuthors(ctx, userID, authors); err != nil {
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	lastEventID, _ := strconv.ParseUint(ctx.GetHeader("Last-Event-ID"), 10, 64)
+	subscription := h.hub.Subscribe(authors.contains, lastEventID)
+	defer subscription.Close()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case event, ok := <-subscription.Events():
+			if !ok {
+				// Dropped for falling behind; the client reconnects with Last-Event-ID.
+				return
+			}
+
+			if err := writeTweetEvent(ctx, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(ctx.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			ctx.Writer.Flush()
+
+			// Keep streaming with the previous set if the refresh fails.
+			_ = h.refreshAuthors(ctx, userID, authors)
+		}
+	}
+}
+
+func (h *TimelineStreamHandler) refreshAuthors(ctx *gin.Context, userID uuid.UUID, authors *authorSet) error {
+	authorIDs, err := h.userService.GetTimelineAuthorIDs(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	authors.replace(authorIDs)
+	return nil
+}
+
+func writeTweetEvent(ctx *gin.Context, event streaming.Event) error {
+	data, err := json.Marshal(ToTweetResponseSimple(event.Tweet))
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(ctx.Writer, "id: %d\nevent: tweet\ndata: %s\n\n", event.ID, data); err != nil {
+		return err
+	}
+
+	ctx.Writer.Flush()
+	return nil
+}
+
+// authorSet is the set of users whose tweets a stream delivers. It is read by the
+// hub while publishing and replaced by the stream on every refresh.
+type authorSet struct {
+	mu  sync.RWMutex
+	ids map[uuid.UUID]struct{}
+}
+
+func (s *authorSet) contains(tweet domain.Tweet) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.ids[tweet.UserID]
+	return ok
+}
+
+func (s *authorSet) replace(authorIDs []uuid.UUID) {
+	ids := make(map[uuid.UUID]struct{}, len(authorIDs))
+	for _, id := range authorIDs {
+		ids[id] = struct{}{}
+	}
+
+	s.mu.Lock()
+	s.ids = ids
+	s.mu.Unlock()
+}
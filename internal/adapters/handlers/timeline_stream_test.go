@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/streaming"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	mock_ports "github.com/juanignaciorc/microbloggin-pltf/mocks"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimelineStreamHandler_Stream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_ports.NewMockUserService(ctrl)
+	followedID := uuid.MustParse(followedUserUuidMock)
+	tweetID := uuid.MustParse(uuidMock)
+
+	tests := []struct {
+		name               string
+		userID             string
+		viewerID           string
+		lastEventID        string
+		setupMock          func()
+		publish            func(*streaming.Hub)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:     "Success - Streams tweets from followed users",
+			userID:   userUuidMock,
+			viewerID: userUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					GetTimelineAuthorIDs(gomock.Any(), uuid.MustParse(userUuidMock)).
+					Return([]uuid.UUID{followedID}, nil)
+			},
+			publish: func(hub *streaming.Hub) {
+				hub.PublishTweet(context.Background(), domain.Tweet{ID: tweetID, UserID: uuid.New(), Message: "Not followed"})
+				hub.PublishTweet(context.Background(), domain.Tweet{ID: tweetID, UserID: followedID, Message: "Hello"})
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   fmt.Sprintf("id: 4\nevent: tweet\ndata: {\"id\":\"%s\",\"message\":\"Hello\",\"user\":{\"id\":\"00000000-0000-0000-0000-000000000000\",\"name\":\"\"}}\n\n", uuidMock),
+		},
+		{
+			name:        "Success - Resumes after Last-Event-ID",
+			userID:      userUuidMock,
+			viewerID:    userUuidMock,
+			lastEventID: "2",
+			setupMock: func() {
+				mockService.EXPECT().
+					GetTimelineAuthorIDs(gomock.Any(), uuid.MustParse(userUuidMock)).
+					Return([]uuid.UUID{followedID}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   fmt.Sprintf("id: 3\nevent: tweet\ndata: {\"id\":\"%s\",\"message\":\"Missed\",\"user\":{\"id\":\"00000000-0000-0000-0000-000000000000\",\"name\":\"\"}}\n\n", uuidMock),
+		},
+		{
+			name:     "Failure - Service error",
+			userID:   userUuidMock,
+			viewerID: userUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					GetTimelineAuthorIDs(gomock.Any(), uuid.MustParse(userUuidMock)).
+					Return(nil, errors.New("timeline error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error":"timeline error"}`,
+		},
+		{
+			name:               "Failure - Someone else's timeline",
+			userID:             userUuidMock,
+			viewerID:           followedUserUuidMock,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"The timeline is only visible to its owner","code":"TIMELINE_FORBIDDEN"}`,
+		},
+		{
+			name:               "Failure - Anonymous viewer",
+			userID:             userUuidMock,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"The timeline is only visible to its owner","code":"TIMELINE_FORBIDDEN"}`,
+		},
+		{
+			name:               "Failure - Invalid UUID",
+			userID:             "invalid-uuid",
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Invalid user ID","code":"INVALID_USER_ID"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up mock expectations
+			tt.setupMock()
+
+			hub := streaming.NewHub(streaming.DefaultBufferSize, streaming.DefaultHistorySize)
+			hub.PublishTweet(context.Background(), domain.Tweet{ID: tweetID, UserID: followedID, Message: "Old"})
+			hub.PublishTweet(context.Background(), domain.Tweet{ID: tweetID, UserID: followedID, Message: "Seen"})
+			if tt.lastEventID != "" {
+				hub.PublishTweet(context.Background(), domain.Tweet{ID: tweetID, UserID: followedID, Message: "Missed"})
+			}
+
+			// Long heartbeat so the only writes are the events under test
+			handler := NewTimelineStreamHandler(hub, mockService, time.Hour)
+
+			// Create a new HTTP request that is cancelled to simulate the client disconnecting
+			requestCtx, disconnect := context.WithCancel(context.Background())
+			req, err := http.NewRequestWithContext(requestCtx, http.MethodGet, fmt.Sprintf("/users/%s/timeline/stream", tt.userID), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.viewerID != "" {
+				req.Header.Set("X-User-ID", tt.viewerID)
+			}
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+
+			// Create a response recorder to capture the response
+			rr := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: "id", Value: tt.userID},
+			}
+
+			done := make(chan struct{})
+			go func() {
+				handler.Stream(ctx)
+				close(done)
+			}()
+
+			if tt.expectedStatusCode == http.StatusOK {
+				waitForSubscribers(t, hub, 1)
+				if tt.publish != nil {
+					tt.publish(hub)
+				}
+			}
+
+			// Give the stream a moment to write what was published, then disconnect
+			time.Sleep(50 * time.Millisecond)
+			disconnect()
+			<-done
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+			assert.Equal(t, 0, hub.SubscriberCount())
+		})
+	}
+}
+
+func waitForSubscribers(t *testing.T, hub *streaming.Hub, count int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for hub.SubscriberCount() != count {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d subscribers", count)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
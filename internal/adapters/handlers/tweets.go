@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
 	"github.com/juanignaciorc/microbloggin-pltf/internal/services"
 	"net/http"
 )
@@ -24,29 +26,108 @@ func (h *TweetHandler) CreateTweet(ctx *gin.Context) {
 
 	var body CreateTweetBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
-		ctx.JSON(http.StatusBadRequest, NewErrorResponseWithCode(err.Error(), "EXCEEDED_MAX_TWEET_CHARACTERS"))
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode(err.Error(), "EXCEEDED_MAX_TWEET_CHARACTERS"))
 		return
 	}
 
 	parsedUserID, err := uuid.Parse(userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		return
+	}
+
+	if !requireViewer(ctx, parsedUserID, "Users can only tweet as themselves", "TWEET_FORBIDDEN") {
 		return
 	}
 
 	tweet, err := h.service.CreateTweet(ctx, parsedUserID, body.Message)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
 		return
 	}
 
 	// Fetch user information to include in the response
 	user, err := h.userService.GetUser(ctx, parsedUserID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
 		return
 	}
 
 	response := NewSuccessResponse("Tweet created successfully", ToTweetResponseWithUser(tweet, user))
 	ctx.JSON(http.StatusCreated, response)
 }
+
+func (h *TweetHandler) GetTweet(ctx *gin.Context) {
+	tweetID, err := uuid.Parse(ctx.Param("tweet_id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid tweet ID", "INVALID_TWEET_ID"))
+		return
+	}
+
+	viewerID, err := parseViewerID(ctx)
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid viewer ID", "INVALID_VIEWER_ID"))
+		return
+	}
+
+	tweet, err := h.service.GetTweet(ctx, viewerID, tweetID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTweetNotFound) {
+			writeError(ctx, http.StatusNotFound, NewErrorResponseWithCode(err.Error(), "TWEET_NOT_FOUND"))
+			return
+		}
+
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	user, err := h.userService.GetUser(ctx, tweet.UserID)
+	if err != nil {
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := NewSuccessResponse("Tweet retrieved successfully", ToTweetResponseWithUser(tweet, user))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetUserTweets lists a user's tweets, newest first, as seen by the viewer.
+func (h *TweetHandler) GetUserTweets(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		return
+	}
+
+	viewerID, err := parseViewerID(ctx)
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid viewer ID", "INVALID_VIEWER_ID"))
+		return
+	}
+
+	page, err := parsePagination(ctx)
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode(err.Error(), "INVALID_PAGINATION"))
+		return
+	}
+
+	tweets, err := h.service.GetUserTweets(ctx, viewerID, userID, page)
+	if err != nil {
+		if errors.Is(err, domain.ErrTweetsProtected) {
+			writeError(ctx, http.StatusForbidden, NewErrorResponseWithCode(err.Error(), "TWEETS_PROTECTED"))
+			return
+		}
+
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	user, err := h.userService.GetUser(ctx, userID)
+	if err != nil {
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := NewSuccessResponse("Tweets retrieved successfully", ToTweetListResponse(tweets, user, page))
+	ctx.JSON(http.StatusOK, response)
+}
@@ -32,6 +32,7 @@ func TestTweetHandler_CreateTweet(t *testing.T) {
 	tests := []struct {
 		name               string
 		userID             uuid.UUID
+		viewerID           string
 		requestBody        interface{}
 		setupMock          func()
 		expectedStatusCode int
@@ -40,6 +41,7 @@ func TestTweetHandler_CreateTweet(t *testing.T) {
 		{
 			name:        "Success - Tweet created",
 			userID:      uuid.MustParse(uuidMock),
+			viewerID:    uuidMock,
 			requestBody: map[string]string{"message": "Tweet created successfully"},
 			setupMock: func() {
 				// Set up the expected behavior for the mocks
@@ -57,6 +59,7 @@ func TestTweetHandler_CreateTweet(t *testing.T) {
 		{
 			name:        "Failure - Service error",
 			userID:      uuid.MustParse(uuidMock),
+			viewerID:    uuidMock,
 			requestBody: map[string]string{"message": "Hello, Error!"},
 			setupMock: func() {
 				mockTweetService.EXPECT().
@@ -66,6 +69,23 @@ func TestTweetHandler_CreateTweet(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedResponse:   `{"error":"service error"}`,
 		},
+		{
+			name:               "Failure - Tweeting as someone else",
+			userID:             uuid.MustParse(uuidMock),
+			viewerID:           followedUserUuidMock,
+			requestBody:        map[string]string{"message": "Not mine"},
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"Users can only tweet as themselves","code":"TWEET_FORBIDDEN"}`,
+		},
+		{
+			name:               "Failure - Anonymous viewer",
+			userID:             uuid.MustParse(uuidMock),
+			requestBody:        map[string]string{"message": "Nobody's"},
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"Users can only tweet as themselves","code":"TWEET_FORBIDDEN"}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -88,6 +108,9 @@ func TestTweetHandler_CreateTweet(t *testing.T) {
 			}
 
 			req.Header.Set("Content-Type", "application/json")
+			if tt.viewerID != "" {
+				req.Header.Set("X-User-ID", tt.viewerID)
+			}
 
 			// Create a response recorder to capture the response
 			rr := httptest.NewRecorder()
@@ -107,3 +130,189 @@ func TestTweetHandler_CreateTweet(t *testing.T) {
 	}
 
 }
+
+func TestTweetHandler_GetTweet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTweetService := mock_ports.NewMockTweetService(ctrl)
+	mockUserService := mock_ports.NewMockUserService(ctrl)
+
+	// Create the handler with the mock services
+	handler := NewTweetHandler(mockTweetService, mockUserService)
+
+	viewerUuidMock := "99dae0ef-658c-44c6-803f-f849854a7055"
+
+	tests := []struct {
+		name               string
+		tweetID            string
+		viewerID           string
+		setupMock          func()
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:     "Success - Tweet retrieved",
+			tweetID:  uuidMock,
+			viewerID: viewerUuidMock,
+			setupMock: func() {
+				mockTweetService.EXPECT().
+					GetTweet(gomock.Any(), uuid.MustParse(viewerUuidMock), uuid.MustParse(uuidMock)).
+					Return(domain.Tweet{ID: uuid.MustParse(uuidMock), UserID: uuid.MustParse(uuidMock), Message: "Hello"}, nil)
+
+				mockUserService.EXPECT().
+					GetUser(gomock.Any(), uuid.MustParse(uuidMock)).
+					Return(domain.User{ID: uuid.MustParse(uuidMock), Name: "Test User"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   fmt.Sprintf(`{"message":"Tweet retrieved successfully","data":{"id":"%s","message":"Hello","user":{"id":"%s","name":"Test User"}}}`, uuidMock, uuidMock),
+		},
+		{
+			name:    "Failure - Hidden or missing tweet",
+			tweetID: uuidMock,
+			setupMock: func() {
+				mockTweetService.EXPECT().
+					GetTweet(gomock.Any(), uuid.Nil, uuid.MustParse(uuidMock)).
+					Return(domain.Tweet{}, domain.ErrTweetNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"error":"tweet not found","code":"TWEET_NOT_FOUND"}`,
+		},
+		{
+			name:               "Failure - Invalid viewer ID",
+			tweetID:            uuidMock,
+			viewerID:           "invalid-uuid",
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Invalid viewer ID","code":"INVALID_VIEWER_ID"}`,
+		},
+		{
+			name:               "Failure - Invalid tweet ID",
+			tweetID:            "invalid-uuid",
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Invalid tweet ID","code":"INVALID_TWEET_ID"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up mock expectations
+			tt.setupMock()
+
+			// Create a new HTTP request
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/tweets/%s", tt.tweetID), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.viewerID != "" {
+				req.Header.Set(viewerHeader, tt.viewerID)
+			}
+
+			// Create a response recorder to capture the response
+			rr := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: "tweet_id", Value: tt.tweetID},
+			}
+
+			handler.GetTweet(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestTweetHandler_GetUserTweets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTweetService := mock_ports.NewMockTweetService(ctrl)
+	mockUserService := mock_ports.NewMockUserService(ctrl)
+
+	handler := NewTweetHandler(mockTweetService, mockUserService)
+
+	tweetUuidMock := "88dae0ef-658c-44c6-803f-f849854a7044"
+
+	tests := []struct {
+		name               string
+		userID             string
+		query              string
+		setupMock          func()
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:   "Success - Tweets retrieved",
+			userID: uuidMock,
+			query:  "?limit=1&offset=2",
+			setupMock: func() {
+				mockTweetService.EXPECT().
+					GetUserTweets(gomock.Any(), uuid.Nil, uuid.MustParse(uuidMock), domain.NewPagination(1, 2)).
+					Return([]domain.Tweet{{ID: uuid.MustParse(tweetUuidMock), UserID: uuid.MustParse(uuidMock), Message: "Hello"}}, nil)
+
+				mockUserService.EXPECT().
+					GetUser(gomock.Any(), uuid.MustParse(uuidMock)).
+					Return(domain.User{ID: uuid.MustParse(uuidMock), Name: "Test User"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   fmt.Sprintf(`{"message":"Tweets retrieved successfully","data":{"tweets":[{"id":"%s","message":"Hello","user":{"id":"%s","name":"Test User"}}],"limit":1,"offset":2}}`, tweetUuidMock, uuidMock),
+		},
+		{
+			name:   "Failure - Protected tweets",
+			userID: uuidMock,
+			setupMock: func() {
+				mockTweetService.EXPECT().
+					GetUserTweets(gomock.Any(), uuid.Nil, uuid.MustParse(uuidMock), domain.NewPagination(domain.DefaultPageLimit, 0)).
+					Return(nil, domain.ErrTweetsProtected)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"tweets are protected","code":"TWEETS_PROTECTED"}`,
+		},
+		{
+			name:               "Failure - Invalid pagination",
+			userID:             uuidMock,
+			query:              "?limit=-1",
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"limit and offset must be non-negative integers","code":"INVALID_PAGINATION"}`,
+		},
+		{
+			name:               "Failure - Invalid user ID",
+			userID:             "invalid-uuid",
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Invalid user ID","code":"INVALID_USER_ID"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/users/%s/tweets%s", tt.userID, tt.query), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: "id", Value: tt.userID},
+			}
+
+			handler.GetUserTweets(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
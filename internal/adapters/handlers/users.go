@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
 	"github.com/juanignaciorc/microbloggin-pltf/internal/services"
 	"net/http"
 )
@@ -22,6 +25,10 @@ type CreateUserBody struct {
 	Email string `json:"email"`
 }
 
+type UpdatePrivacyBody struct {
+	Protected *bool `json:"protected" binding:"required"`
+}
+
 type CreateTweetBody struct {
 	Message string `json:"message" binding:"required,max=280"`
 }
@@ -29,13 +36,13 @@ type CreateTweetBody struct {
 func (h UserHandler) Create(ctx *gin.Context) {
 	var body CreateUserBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
-		ctx.JSON(http.StatusBadRequest, NewErrorResponseWithCode(err.Error(), "INVALID_REQUEST_BODY"))
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode(err.Error(), "INVALID_REQUEST_BODY"))
 		return
 	}
 
 	user, err := h.service.CreateUser(ctx, body.Name, body.Email)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
 		return
 	}
 
@@ -48,13 +55,19 @@ func (h UserHandler) Get(ctx *gin.Context) {
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		return
+	}
+
+	viewerID, err := parseViewerID(ctx)
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid viewer ID", "INVALID_VIEWER_ID"))
 		return
 	}
 
-	user, err := h.service.GetUser(ctx, userID)
+	user, err := h.service.GetUserAsViewer(ctx, viewerID, userID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, NewErrorResponse("USER NOT FOUND"))
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse("USER NOT FOUND"))
 		return
 	}
 
@@ -62,12 +75,17 @@ func (h UserHandler) Get(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response)
 }
 
+// FollowUser makes the :id user, who must be the viewer, follow :following_user_id.
 func (h UserHandler) FollowUser(ctx *gin.Context) {
 	userIDStr := ctx.Param("id")
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		return
+	}
+
+	if !requireViewer(ctx, userID, "Users can only follow as themselves", "FOLLOW_FORBIDDEN") {
 		return
 	}
 
@@ -75,12 +93,23 @@ func (h UserHandler) FollowUser(ctx *gin.Context) {
 
 	followedUserID, err := uuid.Parse(followedUserIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, NewErrorResponseWithCode("Invalid followed user ID", "INVALID_FOLLOWED_USER_ID"))
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid followed user ID", "INVALID_FOLLOWED_USER_ID"))
 		return
 	}
 
-	if err := h.service.FollowUser(ctx, userID, followedUserID); err != nil {
-		ctx.JSON(http.StatusInternalServerError, NewErrorResponse(err.Error()))
+	status, err := h.service.FollowUser(ctx, userID, followedUserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserBlocked) {
+			writeError(ctx, http.StatusForbidden, NewErrorResponseWithCode(err.Error(), "USER_BLOCKED"))
+			return
+		}
+
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	if status == domain.FollowStatusPending {
+		ctx.JSON(http.StatusAccepted, NewSuccessResponse("Follow request sent", nil))
 		return
 	}
 
@@ -88,18 +117,24 @@ func (h UserHandler) FollowUser(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, response)
 }
 
+// GetUserTimeline answers only the user whose timeline it is: it lists the tweets of
+// the accounts they follow, protected ones included.
 func (h UserHandler) GetUserTimeline(ctx *gin.Context) {
 	userIDStr := ctx.Param("id")
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		return
+	}
+
+	if !requireViewer(ctx, userID, "The timeline is only visible to its owner", "TIMELINE_FORBIDDEN") {
 		return
 	}
 
 	tweets, err := h.service.GetUserTimeline(ctx, userID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
 		return
 	}
 
@@ -112,3 +147,223 @@ func (h UserHandler) GetUserTimeline(ctx *gin.Context) {
 	response := NewSuccessResponse("Timeline retrieved successfully", tweetResponses)
 	ctx.JSON(http.StatusOK, response)
 }
+
+func (h UserHandler) GetFollowers(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		return
+	}
+
+	page, err := parsePagination(ctx)
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode(err.Error(), "INVALID_PAGINATION"))
+		return
+	}
+
+	followers, err := h.service.GetFollowers(ctx, userID, page)
+	if err != nil {
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := NewSuccessResponse("Followers retrieved successfully", ToUserListResponse(followers, page))
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h UserHandler) GetFollowing(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		return
+	}
+
+	page, err := parsePagination(ctx)
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode(err.Error(), "INVALID_PAGINATION"))
+		return
+	}
+
+	following, err := h.service.GetFollowing(ctx, userID, page)
+	if err != nil {
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := NewSuccessResponse("Following retrieved successfully", ToUserListResponse(following, page))
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h UserHandler) GetRelationship(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		return
+	}
+
+	targetUserID, err := uuid.Parse(ctx.Param("target_user_id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid target user ID", "INVALID_TARGET_USER_ID"))
+		return
+	}
+
+	following, err := h.service.IsFollowing(ctx, userID, targetUserID)
+	if err != nil {
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	followedBy, err := h.service.IsFollowing(ctx, targetUserID, userID)
+	if err != nil {
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := NewSuccessResponse("Relationship retrieved successfully", RelationshipResponse{
+		UserID:       userID,
+		TargetUserID: targetUserID,
+		Following:    following,
+		FollowedBy:   followedBy,
+	})
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h UserHandler) BlockUser(ctx *gin.Context) {
+	h.updateRelationship(ctx, h.service.BlockUser, "User blocked successfully")
+}
+
+func (h UserHandler) UnblockUser(ctx *gin.Context) {
+	h.updateRelationship(ctx, h.service.UnblockUser, "User unblocked successfully")
+}
+
+func (h UserHandler) MuteUser(ctx *gin.Context) {
+	h.updateRelationship(ctx, h.service.MuteUser, "User muted successfully")
+}
+
+func (h UserHandler) UnmuteUser(ctx *gin.Context) {
+	h.updateRelationship(ctx, h.service.UnmuteUser, "User unmuted successfully")
+}
+
+// updateRelationship parses the :id and :target_user_id params and applies update to
+// them. Only the :id user may change their blocks and mutes.
+func (h UserHandler) updateRelationship(ctx *gin.Context, update func(context.Context, uuid.UUID, uuid.UUID) error, message string) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		return
+	}
+
+	if !requireViewer(ctx, userID, "Only the user can change their blocks and mutes", "RELATIONSHIP_FORBIDDEN") {
+		return
+	}
+
+	targetUserID, err := uuid.Parse(ctx.Param("target_user_id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid target user ID", "INVALID_TARGET_USER_ID"))
+		return
+	}
+
+	if err := update(ctx, userID, targetUserID); err != nil {
+		if errors.Is(err, domain.ErrSelfRelationship) {
+			writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode(err.Error(), "INVALID_RELATIONSHIP"))
+			return
+		}
+
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, NewSuccessResponse(message, nil))
+}
+
+func (h UserHandler) UpdatePrivacy(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		return
+	}
+
+	if !requireViewer(ctx, userID, "Only the user can change their privacy", "PRIVACY_FORBIDDEN") {
+		return
+	}
+
+	var body UpdatePrivacyBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode(err.Error(), "INVALID_REQUEST_BODY"))
+		return
+	}
+
+	if err := h.service.SetProtected(ctx, userID, *body.Protected); err != nil {
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, NewSuccessResponse("Privacy updated successfully", nil))
+}
+
+func (h UserHandler) GetFollowRequests(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		return
+	}
+
+	if !requireViewer(ctx, userID, "The follow requests are only visible to their owner", "FOLLOW_REQUESTS_FORBIDDEN") {
+		return
+	}
+
+	page, err := parsePagination(ctx)
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode(err.Error(), "INVALID_PAGINATION"))
+		return
+	}
+
+	requesters, err := h.service.GetFollowRequests(ctx, userID, page)
+	if err != nil {
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	response := NewSuccessResponse("Follow requests retrieved successfully", ToUserListResponse(requesters, page))
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h UserHandler) ApproveFollowRequest(ctx *gin.Context) {
+	h.resolveFollowRequest(ctx, h.service.ApproveFollowRequest, "Follow request approved")
+}
+
+func (h UserHandler) RejectFollowRequest(ctx *gin.Context) {
+	h.resolveFollowRequest(ctx, h.service.RejectFollowRequest, "Follow request rejected")
+}
+
+// resolveFollowRequest parses the :id and :requester_id params and applies resolve to
+// them. Only the :id user may resolve their requests.
+func (h UserHandler) resolveFollowRequest(ctx *gin.Context, resolve func(context.Context, uuid.UUID, uuid.UUID) error, message string) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid user ID", "INVALID_USER_ID"))
+		return
+	}
+
+	if !requireViewer(ctx, userID, "Only the user can resolve their follow requests", "FOLLOW_REQUESTS_FORBIDDEN") {
+		return
+	}
+
+	requesterID, err := uuid.Parse(ctx.Param("requester_id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid requester ID", "INVALID_REQUESTER_ID"))
+		return
+	}
+
+	if err := resolve(ctx, userID, requesterID); err != nil {
+		if errors.Is(err, domain.ErrFollowRequestNotFound) {
+			writeError(ctx, http.StatusNotFound, NewErrorResponseWithCode(err.Error(), "FOLLOW_REQUEST_NOT_FOUND"))
+			return
+		}
+
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, NewSuccessResponse(message, nil))
+}
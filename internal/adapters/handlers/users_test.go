@@ -45,7 +45,7 @@ func TestUserHandler_Create(t *testing.T) {
 					Return(domain.User{ID: uuid.MustParse(userUuidMock), Name: "John Doe", Email: "john@example.com"}, nil)
 			},
 			expectedStatusCode: http.StatusOK,
-			expectedResponse:   fmt.Sprintf(`{"message":"User created successfully","data":{"id":"%s","name":"John Doe","email":"john@example.com","followers_count":0,"following_count":0,"tweets_count":0}}`, userUuidMock),
+			expectedResponse:   fmt.Sprintf(`{"message":"User created successfully","data":{"id":"%s","name":"John Doe","email":"john@example.com","protected":false,"followers_count":0,"following_count":0,"tweets_count":0}}`, userUuidMock),
 		},
 		{
 			name:        "Failure - Service error",
@@ -125,18 +125,18 @@ func TestUserHandler_Get(t *testing.T) {
 			userID: userUuidMock,
 			setupMock: func() {
 				mockService.EXPECT().
-					GetUser(gomock.Any(), uuid.MustParse(userUuidMock)).
+					GetUserAsViewer(gomock.Any(), uuid.Nil, uuid.MustParse(userUuidMock)).
 					Return(domain.User{ID: uuid.MustParse(userUuidMock), Name: "John Doe", Email: "john@example.com"}, nil)
 			},
 			expectedStatusCode: http.StatusOK,
-			expectedResponse:   fmt.Sprintf(`{"message":"User retrieved successfully","data":{"id":"%s","name":"John Doe","email":"john@example.com","followers_count":0,"following_count":0,"tweets_count":0}}`, userUuidMock),
+			expectedResponse:   fmt.Sprintf(`{"message":"User retrieved successfully","data":{"id":"%s","name":"John Doe","email":"john@example.com","protected":false,"followers_count":0,"following_count":0,"tweets_count":0}}`, userUuidMock),
 		},
 		{
 			name:   "Failure - Service error",
 			userID: userUuidMock,
 			setupMock: func() {
 				mockService.EXPECT().
-					GetUser(gomock.Any(), uuid.MustParse(userUuidMock)).
+					GetUserAsViewer(gomock.Any(), uuid.Nil, uuid.MustParse(userUuidMock)).
 					Return(domain.User{}, errors.New("USER NOT FOUND"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
@@ -192,6 +192,7 @@ func TestUserHandler_FollowUser(t *testing.T) {
 	tests := []struct {
 		name               string
 		userID             string
+		viewerID           string
 		followedUserID     string
 		setupMock          func()
 		expectedStatusCode int
@@ -200,27 +201,55 @@ func TestUserHandler_FollowUser(t *testing.T) {
 		{
 			name:           "Success - User followed",
 			userID:         userUuidMock,
+			viewerID:       userUuidMock,
 			followedUserID: followedUserUuidMock,
 			setupMock: func() {
 				mockService.EXPECT().
 					FollowUser(gomock.Any(), uuid.MustParse(userUuidMock), uuid.MustParse(followedUserUuidMock)).
-					Return(nil)
+					Return(domain.FollowStatusFollowing, nil)
 			},
 			expectedStatusCode: http.StatusCreated,
 			expectedResponse:   `{"message":"User followed successfully"}`,
 		},
+		{
+			name:           "Success - Follow request sent to protected account",
+			userID:         userUuidMock,
+			viewerID:       userUuidMock,
+			followedUserID: followedUserUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					FollowUser(gomock.Any(), uuid.MustParse(userUuidMock), uuid.MustParse(followedUserUuidMock)).
+					Return(domain.FollowStatusPending, nil)
+			},
+			expectedStatusCode: http.StatusAccepted,
+			expectedResponse:   `{"message":"Follow request sent"}`,
+		},
 		{
 			name:           "Failure - Service error",
 			userID:         userUuidMock,
+			viewerID:       userUuidMock,
 			followedUserID: followedUserUuidMock,
 			setupMock: func() {
 				mockService.EXPECT().
 					FollowUser(gomock.Any(), uuid.MustParse(userUuidMock), uuid.MustParse(followedUserUuidMock)).
-					Return(errors.New("follow error"))
+					Return(domain.FollowStatus(""), errors.New("follow error"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedResponse:   `{"error":"follow error"}`,
 		},
+		{
+			name:           "Failure - User blocked",
+			userID:         userUuidMock,
+			viewerID:       userUuidMock,
+			followedUserID: followedUserUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					FollowUser(gomock.Any(), uuid.MustParse(userUuidMock), uuid.MustParse(followedUserUuidMock)).
+					Return(domain.FollowStatus(""), domain.ErrUserBlocked)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"user is blocked","code":"USER_BLOCKED"}`,
+		},
 		{
 			name:               "Failure - Invalid user ID",
 			userID:             "invalid-uuid",
@@ -232,11 +261,29 @@ func TestUserHandler_FollowUser(t *testing.T) {
 		{
 			name:               "Failure - Invalid followed user ID",
 			userID:             userUuidMock,
+			viewerID:           userUuidMock,
 			followedUserID:     "invalid-uuid",
 			setupMock:          func() {},
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse:   `{"error":"Invalid followed user ID","code":"INVALID_FOLLOWED_USER_ID"}`,
 		},
+		{
+			name:               "Failure - Following as someone else",
+			userID:             userUuidMock,
+			viewerID:           followedUserUuidMock,
+			followedUserID:     followedUserUuidMock,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"Users can only follow as themselves","code":"FOLLOW_FORBIDDEN"}`,
+		},
+		{
+			name:               "Failure - Anonymous viewer",
+			userID:             userUuidMock,
+			followedUserID:     followedUserUuidMock,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"Users can only follow as themselves","code":"FOLLOW_FORBIDDEN"}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -249,6 +296,9 @@ func TestUserHandler_FollowUser(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+			if tt.viewerID != "" {
+				req.Header.Set("X-User-ID", tt.viewerID)
+			}
 
 			// Create a response recorder to capture the response
 			rr := httptest.NewRecorder()
@@ -281,13 +331,15 @@ func TestUserHandler_GetUserTimeline(t *testing.T) {
 	tests := []struct {
 		name               string
 		userID             string
+		viewerID           string
 		setupMock          func()
 		expectedStatusCode int
 		expectedResponse   string
 	}{
 		{
-			name:   "Success - Timeline retrieved",
-			userID: userUuidMock,
+			name:     "Success - Timeline retrieved",
+			userID:   userUuidMock,
+			viewerID: userUuidMock,
 			setupMock: func() {
 				tweets := []domain.Tweet{
 					{ID: uuid.MustParse(userUuidMock), Message: "Hello World"},
@@ -300,8 +352,9 @@ func TestUserHandler_GetUserTimeline(t *testing.T) {
 			expectedResponse:   fmt.Sprintf(`{"message":"Timeline retrieved successfully","data":[{"id":"%s","message":"Hello World","user":{"id":"00000000-0000-0000-0000-000000000000","name":""}}]}`, userUuidMock),
 		},
 		{
-			name:   "Success - Empty timeline",
-			userID: userUuidMock,
+			name:     "Success - Empty timeline",
+			userID:   userUuidMock,
+			viewerID: userUuidMock,
 			setupMock: func() {
 				mockService.EXPECT().
 					GetUserTimeline(gomock.Any(), uuid.MustParse(userUuidMock)).
@@ -311,8 +364,9 @@ func TestUserHandler_GetUserTimeline(t *testing.T) {
 			expectedResponse:   `{"message":"Timeline retrieved successfully","data":[]}`,
 		},
 		{
-			name:   "Failure - Service error",
-			userID: userUuidMock,
+			name:     "Failure - Service error",
+			userID:   userUuidMock,
+			viewerID: userUuidMock,
 			setupMock: func() {
 				mockService.EXPECT().
 					GetUserTimeline(gomock.Any(), uuid.MustParse(userUuidMock)).
@@ -321,6 +375,21 @@ func TestUserHandler_GetUserTimeline(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedResponse:   `{"error":"timeline error"}`,
 		},
+		{
+			name:               "Failure - Someone else's timeline",
+			userID:             userUuidMock,
+			viewerID:           uuid.NewString(),
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"The timeline is only visible to its owner","code":"TIMELINE_FORBIDDEN"}`,
+		},
+		{
+			name:               "Failure - Anonymous viewer",
+			userID:             userUuidMock,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"The timeline is only visible to its owner","code":"TIMELINE_FORBIDDEN"}`,
+		},
 		{
 			name:               "Failure - Invalid UUID",
 			userID:             "invalid-uuid",
@@ -340,6 +409,9 @@ func TestUserHandler_GetUserTimeline(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+			if tt.viewerID != "" {
+				req.Header.Set("X-User-ID", tt.viewerID)
+			}
 
 			// Create a response recorder to capture the response
 			rr := httptest.NewRecorder()
@@ -357,3 +429,560 @@ func TestUserHandler_GetUserTimeline(t *testing.T) {
 		})
 	}
 }
+
+func TestUserHandler_GetFollowers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_ports.NewMockUserService(ctrl)
+
+	// Create the handler with the mock service
+	handler := NewUserHandler(mockService)
+
+	tests := []struct {
+		name               string
+		userID             string
+		query              string
+		setupMock          func()
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:   "Success - Followers retrieved",
+			userID: userUuidMock,
+			query:  "?limit=1&offset=2",
+			setupMock: func() {
+				followers := []domain.User{
+					{ID: uuid.MustParse(followedUserUuidMock), Name: "Jane Doe", Email: "jane@example.com"},
+				}
+				mockService.EXPECT().
+					GetFollowers(gomock.Any(), uuid.MustParse(userUuidMock), domain.Pagination{Limit: 1, Offset: 2}).
+					Return(followers, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   fmt.Sprintf(`{"message":"Followers retrieved successfully","data":{"users":[{"id":"%s","name":"Jane Doe"}],"limit":1,"offset":2}}`, followedUserUuidMock),
+		},
+		{
+			name:   "Success - Default pagination",
+			userID: userUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					GetFollowers(gomock.Any(), uuid.MustParse(userUuidMock), domain.Pagination{Limit: domain.DefaultPageLimit}).
+					Return([]domain.User{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"message":"Followers retrieved successfully","data":{"users":[],"limit":20,"offset":0}}`,
+		},
+		{
+			name:   "Failure - Service error",
+			userID: userUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					GetFollowers(gomock.Any(), uuid.MustParse(userUuidMock), gomock.Any()).
+					Return(nil, errors.New("followers error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error":"followers error"}`,
+		},
+		{
+			name:               "Failure - Invalid pagination",
+			userID:             userUuidMock,
+			query:              "?limit=abc",
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"limit and offset must be non-negative integers","code":"INVALID_PAGINATION"}`,
+		},
+		{
+			name:               "Failure - Invalid UUID",
+			userID:             "invalid-uuid",
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Invalid user ID","code":"INVALID_USER_ID"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up mock expectations
+			tt.setupMock()
+
+			// Create a new HTTP request
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/users/%s/followers%s", tt.userID, tt.query), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Create a response recorder to capture the response
+			rr := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: "id", Value: tt.userID},
+			}
+
+			handler.GetFollowers(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestUserHandler_GetRelationship(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_ports.NewMockUserService(ctrl)
+
+	// Create the handler with the mock service
+	handler := NewUserHandler(mockService)
+
+	tests := []struct {
+		name               string
+		userID             string
+		targetUserID       string
+		setupMock          func()
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:         "Success - One-way follow",
+			userID:       userUuidMock,
+			targetUserID: followedUserUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					IsFollowing(gomock.Any(), uuid.MustParse(userUuidMock), uuid.MustParse(followedUserUuidMock)).
+					Return(true, nil)
+				mockService.EXPECT().
+					IsFollowing(gomock.Any(), uuid.MustParse(followedUserUuidMock), uuid.MustParse(userUuidMock)).
+					Return(false, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   fmt.Sprintf(`{"message":"Relationship retrieved successfully","data":{"user_id":"%s","target_user_id":"%s","following":true,"followed_by":false}}`, userUuidMock, followedUserUuidMock),
+		},
+		{
+			name:         "Failure - Service error",
+			userID:       userUuidMock,
+			targetUserID: followedUserUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					IsFollowing(gomock.Any(), uuid.MustParse(userUuidMock), uuid.MustParse(followedUserUuidMock)).
+					Return(false, errors.New("relationship error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error":"relationship error"}`,
+		},
+		{
+			name:               "Failure - Invalid target user ID",
+			userID:             userUuidMock,
+			targetUserID:       "invalid-uuid",
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Invalid target user ID","code":"INVALID_TARGET_USER_ID"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up mock expectations
+			tt.setupMock()
+
+			// Create a new HTTP request
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/users/%s/relationship/%s", tt.userID, tt.targetUserID), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Create a response recorder to capture the response
+			rr := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: "id", Value: tt.userID},
+				{Key: "target_user_id", Value: tt.targetUserID},
+			}
+
+			handler.GetRelationship(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestUserHandler_BlockUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_ports.NewMockUserService(ctrl)
+
+	// Create the handler with the mock service
+	handler := NewUserHandler(mockService)
+
+	tests := []struct {
+		name               string
+		userID             string
+		viewerID           string
+		targetUserID       string
+		setupMock          func()
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:         "Success - User blocked",
+			userID:       userUuidMock,
+			viewerID:     userUuidMock,
+			targetUserID: followedUserUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					BlockUser(gomock.Any(), uuid.MustParse(userUuidMock), uuid.MustParse(followedUserUuidMock)).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"message":"User blocked successfully"}`,
+		},
+		{
+			name:         "Failure - Blocking yourself",
+			userID:       userUuidMock,
+			viewerID:     userUuidMock,
+			targetUserID: userUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					BlockUser(gomock.Any(), uuid.MustParse(userUuidMock), uuid.MustParse(userUuidMock)).
+					Return(domain.ErrSelfRelationship)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"users cannot block or mute themselves","code":"INVALID_RELATIONSHIP"}`,
+		},
+		{
+			name:         "Failure - Service error",
+			userID:       userUuidMock,
+			viewerID:     userUuidMock,
+			targetUserID: followedUserUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					BlockUser(gomock.Any(), uuid.MustParse(userUuidMock), uuid.MustParse(followedUserUuidMock)).
+					Return(errors.New("block error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error":"block error"}`,
+		},
+		{
+			name:               "Failure - Invalid target user ID",
+			userID:             userUuidMock,
+			viewerID:           userUuidMock,
+			targetUserID:       "invalid-uuid",
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Invalid target user ID","code":"INVALID_TARGET_USER_ID"}`,
+		},
+		{
+			name:               "Failure - Someone else's blocks",
+			userID:             userUuidMock,
+			viewerID:           followedUserUuidMock,
+			targetUserID:       followedUserUuidMock,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"Only the user can change their blocks and mutes","code":"RELATIONSHIP_FORBIDDEN"}`,
+		},
+		{
+			name:               "Failure - Anonymous viewer",
+			userID:             userUuidMock,
+			targetUserID:       followedUserUuidMock,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"Only the user can change their blocks and mutes","code":"RELATIONSHIP_FORBIDDEN"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up mock expectations
+			tt.setupMock()
+
+			// Create a new HTTP request
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/users/%s/block/%s", tt.userID, tt.targetUserID), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.viewerID != "" {
+				req.Header.Set("X-User-ID", tt.viewerID)
+			}
+
+			// Create a response recorder to capture the response
+			rr := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: "id", Value: tt.userID},
+				{Key: "target_user_id", Value: tt.targetUserID},
+			}
+
+			handler.BlockUser(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestUserHandler_ApproveFollowRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_ports.NewMockUserService(ctrl)
+
+	// Create the handler with the mock service
+	handler := NewUserHandler(mockService)
+
+	tests := []struct {
+		name               string
+		userID             string
+		viewerID           string
+		requesterID        string
+		setupMock          func()
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:        "Success - Request approved",
+			userID:      userUuidMock,
+			viewerID:    userUuidMock,
+			requesterID: followedUserUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					ApproveFollowRequest(gomock.Any(), uuid.MustParse(userUuidMock), uuid.MustParse(followedUserUuidMock)).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"message":"Follow request approved"}`,
+		},
+		{
+			name:        "Failure - No pending request",
+			userID:      userUuidMock,
+			viewerID:    userUuidMock,
+			requesterID: followedUserUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					ApproveFollowRequest(gomock.Any(), uuid.MustParse(userUuidMock), uuid.MustParse(followedUserUuidMock)).
+					Return(domain.ErrFollowRequestNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"error":"follow request not found","code":"FOLLOW_REQUEST_NOT_FOUND"}`,
+		},
+		{
+			name:               "Failure - Invalid requester ID",
+			userID:             userUuidMock,
+			viewerID:           userUuidMock,
+			requesterID:        "invalid-uuid",
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Invalid requester ID","code":"INVALID_REQUESTER_ID"}`,
+		},
+		{
+			name:               "Failure - Someone else's requests",
+			userID:             userUuidMock,
+			viewerID:           followedUserUuidMock,
+			requesterID:        followedUserUuidMock,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"Only the user can resolve their follow requests","code":"FOLLOW_REQUESTS_FORBIDDEN"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up mock expectations
+			tt.setupMock()
+
+			// Create a new HTTP request
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/users/%s/follow-requests/%s/approve", tt.userID, tt.requesterID), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.viewerID != "" {
+				req.Header.Set("X-User-ID", tt.viewerID)
+			}
+
+			// Create a response recorder to capture the response
+			rr := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: "id", Value: tt.userID},
+				{Key: "requester_id", Value: tt.requesterID},
+			}
+
+			handler.ApproveFollowRequest(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestUserHandler_UpdatePrivacy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_ports.NewMockUserService(ctrl)
+
+	// Create the handler with the mock service
+	handler := NewUserHandler(mockService)
+
+	tests := []struct {
+		name               string
+		viewerID           string
+		requestBody        string
+		setupMock          func()
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:        "Success - Account protected",
+			viewerID:    userUuidMock,
+			requestBody: `{"protected":true}`,
+			setupMock: func() {
+				mockService.EXPECT().
+					SetProtected(gomock.Any(), uuid.MustParse(userUuidMock), true).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"message":"Privacy updated successfully"}`,
+		},
+		{
+			name:               "Failure - Missing protected flag",
+			viewerID:           userUuidMock,
+			requestBody:        `{}`,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Key: 'UpdatePrivacyBody.Protected' Error:Field validation for 'Protected' failed on the 'required' tag","code":"INVALID_REQUEST_BODY"}`,
+		},
+		{
+			name:               "Failure - Someone else's account",
+			viewerID:           followedUserUuidMock,
+			requestBody:        `{"protected":true}`,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"Only the user can change their privacy","code":"PRIVACY_FORBIDDEN"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up mock expectations
+			tt.setupMock()
+
+			// Create a new HTTP request
+			req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/users/%s/privacy", userUuidMock), bytes.NewBufferString(tt.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.Header.Set("Content-Type", "application/json")
+			if tt.viewerID != "" {
+				req.Header.Set("X-User-ID", tt.viewerID)
+			}
+
+			// Create a response recorder to capture the response
+			rr := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: "id", Value: userUuidMock},
+			}
+
+			handler.UpdatePrivacy(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestUserHandler_GetFollowRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_ports.NewMockUserService(ctrl)
+
+	// Create the handler with the mock service
+	handler := NewUserHandler(mockService)
+
+	tests := []struct {
+		name               string
+		viewerID           string
+		setupMock          func()
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:     "Success - Requests retrieved",
+			viewerID: userUuidMock,
+			setupMock: func() {
+				requesters := []domain.User{
+					{ID: uuid.MustParse(followedUserUuidMock), Name: "Jane Doe", Email: "jane@example.com"},
+				}
+				mockService.EXPECT().
+					GetFollowRequests(gomock.Any(), uuid.MustParse(userUuidMock), domain.Pagination{Limit: domain.DefaultPageLimit}).
+					Return(requesters, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   fmt.Sprintf(`{"message":"Follow requests retrieved successfully","data":{"users":[{"id":"%s","name":"Jane Doe"}],"limit":20,"offset":0}}`, followedUserUuidMock),
+		},
+		{
+			name:               "Failure - Someone else's requests",
+			viewerID:           followedUserUuidMock,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"The follow requests are only visible to their owner","code":"FOLLOW_REQUESTS_FORBIDDEN"}`,
+		},
+		{
+			name:               "Failure - Anonymous viewer",
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"The follow requests are only visible to their owner","code":"FOLLOW_REQUESTS_FORBIDDEN"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up mock expectations
+			tt.setupMock()
+
+			// Create a new HTTP request
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/users/%s/follow-requests", userUuidMock), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.viewerID != "" {
+				req.Header.Set("X-User-ID", tt.viewerID)
+			}
+
+			// Create a response recorder to capture the response
+			rr := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: "id", Value: userUuidMock},
+			}
+
+			handler.GetFollowRequests(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
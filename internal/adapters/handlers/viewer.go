@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// viewerHeader identifies the user performing a read so visibility rules
+// (protected accounts, blocks) can be applied. Requests without it are anonymous.
+const viewerHeader = "X-User-ID"
+
+func parseViewerID(ctx *gin.Context) (uuid.UUID, error) {
+	raw := ctx.GetHeader(viewerHeader)
+	if raw == "" {
+		return uuid.Nil, nil
+	}
+
+	return uuid.Parse(raw)
+}
+
+// requireViewer lets through only requests whose viewer is userID, for the routes
+// that act as a user or read what only they may see; the others are answered 403
+// with message and code. It reports whether the request may go on.
+func requireViewer(ctx *gin.Context, userID uuid.UUID, message, code string) bool {
+	viewerID, err := parseViewerID(ctx)
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid viewer ID", "INVALID_VIEWER_ID"))
+		return false
+	}
+
+	if viewerID == uuid.Nil || viewerID != userID {
+		writeError(ctx, http.StatusForbidden, NewErrorResponseWithCode(message, code))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/services"
+	"net/http"
+)
+
+type WebhookHandler struct {
+	service services.WebhookService
+}
+
+func NewWebhookHandler(service services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+	}
+}
+
+type CreateWebhookBody struct {
+	URL              string             `json:"url" binding:"required"`
+	Events           []domain.EventType `json:"events" binding:"required"`
+	Secret           string             `json:"secret"`
+	IncludeProtected bool               `json:"include_protected"`
+}
+
+// Create subscribes a URL to events. The response is the only place the secret is shown.
+func (h *WebhookHandler) Create(ctx *gin.Context) {
+	var body CreateWebhookBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode(err.Error(), "INVALID_REQUEST_BODY"))
+		return
+	}
+
+	webhook, err := h.service.CreateWebhook(ctx, body.URL, body.Events, body.Secret, body.IncludeProtected)
+	if err != nil {
+		respondWebhookError(ctx, err)
+		return
+	}
+
+	response := ToWebhookResponse(webhook)
+	response.Secret = webhook.Secret
+	ctx.JSON(http.StatusCreated, NewSuccessResponse("Webhook created successfully", response))
+}
+
+func (h *WebhookHandler) List(ctx *gin.Context) {
+	webhooks, err := h.service.GetWebhooks(ctx)
+	if err != nil {
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+		return
+	}
+
+	webhookResponses := make([]WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		webhookResponses[i] = ToWebhookResponse(webhook)
+	}
+
+	ctx.JSON(http.StatusOK, NewSuccessResponse("Webhooks retrieved successfully", webhookResponses))
+}
+
+func (h *WebhookHandler) Get(ctx *gin.Context) {
+	webhookID, err := uuid.Parse(ctx.Param("webhook_id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid webhook ID", "INVALID_WEBHOOK_ID"))
+		return
+	}
+
+	webhook, err := h.service.GetWebhook(ctx, webhookID)
+	if err != nil {
+		respondWebhookError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, NewSuccessResponse("Webhook retrieved successfully", ToWebhookResponse(webhook)))
+}
+
+func (h *WebhookHandler) Delete(ctx *gin.Context) {
+	webhookID, err := uuid.Parse(ctx.Param("webhook_id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid webhook ID", "INVALID_WEBHOOK_ID"))
+		return
+	}
+
+	if err := h.service.DeleteWebhook(ctx, webhookID); err != nil {
+		respondWebhookError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, NewSuccessResponse("Webhook deleted successfully", nil))
+}
+
+// GetDeliveries returns the delivery log of a webhook. The optional status query
+// parameter filters it; status=dead is the dead-letter list.
+func (h *WebhookHandler) GetDeliveries(ctx *gin.Context) {
+	webhookID, err := uuid.Parse(ctx.Param("webhook_id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid webhook ID", "INVALID_WEBHOOK_ID"))
+		return
+	}
+
+	status := domain.WebhookDeliveryStatus(ctx.Query("status"))
+	switch status {
+	case "", domain.WebhookDeliveryPending, domain.WebhookDeliverySucceeded, domain.WebhookDeliveryDead:
+	default:
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("status must be pending, succeeded or dead", "INVALID_STATUS"))
+		return
+	}
+
+	page, err := parsePagination(ctx)
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode(err.Error(), "INVALID_PAGINATION"))
+		return
+	}
+
+	deliveries, err := h.service.GetDeliveries(ctx, webhookID, status, page)
+	if err != nil {
+		respondWebhookError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, NewSuccessResponse("Deliveries retrieved successfully", ToWebhookDeliveryListResponse(deliveries, page)))
+}
+
+// RetryDelivery requeues a delivery, e.g. from the dead-letter list, for an immediate attempt.
+func (h *WebhookHandler) RetryDelivery(ctx *gin.Context) {
+	webhookID, err := uuid.Parse(ctx.Param("webhook_id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid webhook ID", "INVALID_WEBHOOK_ID"))
+		return
+	}
+
+	deliveryID, err := uuid.Parse(ctx.Param("delivery_id"))
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode("Invalid delivery ID", "INVALID_DELIVERY_ID"))
+		return
+	}
+
+	delivery, err := h.service.RetryDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		respondWebhookError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, NewSuccessResponse("Delivery scheduled for retry", ToWebhookDeliveryResponse(delivery)))
+}
+
+func respondWebhookError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidWebhook):
+		writeError(ctx, http.StatusBadRequest, NewErrorResponseWithCode(err.Error(), "INVALID_WEBHOOK"))
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		writeError(ctx, http.StatusNotFound, NewErrorResponseWithCode(err.Error(), "WEBHOOK_NOT_FOUND"))
+	case errors.Is(err, domain.ErrWebhookDeliveryNotFound):
+		writeError(ctx, http.StatusNotFound, NewErrorResponseWithCode(err.Error(), "WEBHOOK_DELIVERY_NOT_FOUND"))
+	default:
+		writeError(ctx, http.StatusInternalServerError, NewErrorResponse(err.Error()))
+	}
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	mock_ports "github.com/juanignaciorc/microbloggin-pltf/mocks"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookHandler_Create(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_ports.NewMockWebhookService(ctrl)
+
+	// Create the handler with the mock service
+	handler := NewWebhookHandler(mockService)
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	events := []domain.EventType{domain.EventTypeTweetCreated}
+
+	tests := []struct {
+		name               string
+		requestBody        string
+		setupMock          func()
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:        "Success - Webhook created with its secret",
+			requestBody: `{"url":"https://example.com/hook","events":["tweet.created"],"include_protected":true}`,
+			setupMock: func() {
+				mockService.EXPECT().
+					CreateWebhook(gomock.Any(), "https://example.com/hook", events, "", true).
+					Return(domain.Webhook{ID: uuid.MustParse(uuidMock), URL: "https://example.com/hook", Events: events, IncludeProtected: true, Secret: "s3cret", CreatedAt: createdAt}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse:   fmt.Sprintf(`{"message":"Webhook created successfully","data":{"id":"%s","url":"https://example.com/hook","events":["tweet.created"],"include_protected":true,"secret":"s3cret","created_at":"2024-01-02T03:04:05Z"}}`, uuidMock),
+		},
+		{
+			name:        "Failure - Invalid webhook",
+			requestBody: `{"url":"ftp://example.com","events":["tweet.created"]}`,
+			setupMock: func() {
+				mockService.EXPECT().
+					CreateWebhook(gomock.Any(), "ftp://example.com", events, "", false).
+					Return(domain.Webhook{}, fmt.Errorf("%w: url must be an absolute http or https URL", domain.ErrInvalidWebhook))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"invalid webhook: url must be an absolute http or https URL","code":"INVALID_WEBHOOK"}`,
+		},
+		{
+			name:               "Failure - Missing fields",
+			requestBody:        `{"events":["tweet.created"]}`,
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Key: 'CreateWebhookBody.URL' Error:Field validation for 'URL' failed on the 'required' tag","code":"INVALID_REQUEST_BODY"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up mock expectations
+			tt.setupMock()
+
+			// Create a new HTTP request
+			req, err := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(tt.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.Header.Set("Content-Type", "application/json")
+
+			// Create a response recorder to capture the response
+			rr := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request = req
+
+			handler.Create(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestWebhookHandler_GetDeliveries(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_ports.NewMockWebhookService(ctrl)
+
+	// Create the handler with the mock service
+	handler := NewWebhookHandler(mockService)
+
+	webhookID := uuid.MustParse(userUuidMock)
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		webhookID          string
+		query              string
+		setupMock          func()
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:      "Success - Dead-letter list",
+			webhookID: userUuidMock,
+			query:     "?status=dead",
+			setupMock: func() {
+				deliveries := []domain.WebhookDelivery{
+					{
+						ID:             uuid.MustParse(uuidMock),
+						WebhookID:      webhookID,
+						EventID:        uuid.MustParse(followedUserUuidMock),
+						EventType:      domain.EventTypeUserFollowed,
+						Status:         domain.WebhookDeliveryDead,
+						Attempts:       8,
+						LastStatusCode: 500,
+						LastError:      "receiver answered 500: boom",
+						CreatedAt:      updatedAt,
+						UpdatedAt:      updatedAt,
+					},
+				}
+				mockService.EXPECT().
+					GetDeliveries(gomock.Any(), webhookID, domain.WebhookDeliveryDead, domain.Pagination{Limit: domain.DefaultPageLimit}).
+					Return(deliveries, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: fmt.Sprintf(`{"message":"Deliveries retrieved successfully","data":{"deliveries":[{"id":"%s","webhook_id":"%s","event_id":"%s","event_type":"user.followed","status":"dead","attempts":8,"last_status_code":500,"last_error":"receiver answered 500: boom","created_at":"2024-01-02T03:04:05Z","updated_at":"2024-01-02T03:04:05Z"}],"limit":20,"offset":0}}`,
+				uuidMock, userUuidMock, followedUserUuidMock),
+		},
+		{
+			name:      "Failure - Webhook not found",
+			webhookID: userUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					GetDeliveries(gomock.Any(), webhookID, domain.WebhookDeliveryStatus(""), gomock.Any()).
+					Return(nil, domain.ErrWebhookNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"error":"webhook not found","code":"WEBHOOK_NOT_FOUND"}`,
+		},
+		{
+			name:               "Failure - Invalid status",
+			webhookID:          userUuidMock,
+			query:              "?status=failed",
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"status must be pending, succeeded or dead","code":"INVALID_STATUS"}`,
+		},
+		{
+			name:               "Failure - Invalid UUID",
+			webhookID:          "invalid-uuid",
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Invalid webhook ID","code":"INVALID_WEBHOOK_ID"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up mock expectations
+			tt.setupMock()
+
+			// Create a new HTTP request
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/webhooks/%s/deliveries%s", tt.webhookID, tt.query), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Create a response recorder to capture the response
+			rr := httptest.NewRecorder()
+
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: "webhook_id", Value: tt.webhookID},
+			}
+
+			handler.GetDeliveries(ctx)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,514 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/streaming"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/services"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Channels a WebSocket client can subscribe to.
+const (
+	ChannelHomeTimeline = "home_timeline"
+	ChannelMentions     = "mentions"
+	ChannelUserTweets   = "user_tweets"
+)
+
+// Client and server message types of the WebSocket protocol.
+const (
+	wsMessageSubscribe    = "subscribe"
+	wsMessageUnsubscribe  = "unsubscribe"
+	wsMessagePing         = "ping"
+	wsMessagePong         = "pong"
+	wsMessageSubscribed   = "subscribed"
+	wsMessageUnsubscribed = "unsubscribed"
+	wsMessageEvent        = "event"
+	wsMessageError        = "error"
+)
+
+const wsMaxMessageSize = 4096
+
+// WebSocketConfig tunes keepalive and backpressure for WebSocket connections.
+type WebSocketConfig struct {
+	// SendQueueSize is how many outgoing messages a connection may have pending
+	// before it is considered a slow consumer and closed.
+	SendQueueSize int
+	// PingInterval is how often the server pings the client and refreshes the
+	// home timeline authors. A client that doesn't answer within two intervals is dropped.
+	PingInterval time.Duration
+	// WriteTimeout bounds every single write to the socket.
+	WriteTimeout time.Duration
+	// AllowedOrigins are the origins, such as "https://app.example.com", whose pages
+	// may open a connection besides the API's own. Browsers send cookies and the
+	// user_id query parameter from any page, so other origins are refused; clients
+	// that send no Origin header are not browsers and are always accepted.
+	AllowedOrigins []string
+}
+
+var DefaultWebSocketConfig = WebSocketConfig{
+	SendQueueSize: 256,
+	PingInterval:  DefaultHeartbeatInterval,
+	WriteTimeout:  10 * time.Second,
+}
+
+// WSClientMessage is a message sent by the client. ID is chosen by the client and
+// identifies the subscription in every later message about it.
+type WSClientMessage struct {
+	Type        string `json:"type"`
+	ID          string `json:"id,omitempty"`
+	Channel     string `json:"channel,omitempty"`
+	UserID      string `json:"user_id,omitempty"`
+	LastEventID uint64 `json:"last_event_id,omitempty"`
+}
+
+// WSServerMessage is a message sent by the server.
+type WSServerMessage struct {
+	Type    string         `json:"type"`
+	ID      string         `json:"id,omitempty"`
+	Channel string         `json:"channel,omitempty"`
+	EventID uint64         `json:"event_id,omitempty"`
+	Data    *TweetResponse `json:"data,omitempty"`
+	Error   string         `json:"error,omitempty"`
+	Code    string         `json:"code,omitempty"`
+}
+
+// WebSocketHandler multiplexes tweet subscriptions over a single WebSocket connection.
+type WebSocketHandler struct {
+	hub         *streaming.Hub
+	userService services.UserService
+	config      WebSocketConfig
+	upgrader    websocket.Upgrader
+}
+
+func NewWebSocketHandler(hub *streaming.Hub, userService services.UserService, config WebSocketConfig) *WebSocketHandler {
+	h := &WebSocketHandler{
+		hub:         hub,
+		userService: userService,
+		config:      config,
+	}
+	h.upgrader = websocket.Upgrader{CheckOrigin: h.checkOrigin}
+	return h
+}
+
+// checkOrigin accepts handshakes without an Origin header, from the API's own host
+// or from one of the allowed origins.
+func (h *WebSocketHandler) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+
+	for _, allowed := range h.config.AllowedOrigins {
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Connect authenticates the user and upgrades the request to a WebSocket. The user
+// is taken from the X-User-ID header or, for browsers that can't set headers on the
+// handshake, from the user_id query parameter, and must exist.
+func (h *WebSocketHandler) Connect(ctx *gin.Context) {
+	rawID := ctx.GetHeader(viewerHeader)
+	if rawID == "" {
+		rawID = ctx.Query("user_id")
+	}
+
+	userID, err := uuid.Parse(rawID)
+	if err != nil {
+		writeError(ctx, http.StatusUnauthorized, NewErrorResponseWithCode("A valid user ID is required", "UNAUTHORIZED"))
+		return
+	}
+
+	if _, err := h.userService.GetUser(ctx, userID); err != nil {
+		writeError(ctx, http.StatusUnauthorized, NewErrorResponseWithCode("Unknown user", "UNAUTHORIZED"))
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		// The upgrader has already replied with an HTTP error.
+		return
+	}
+
+	newWSConnection(h, conn, userID).run()
+}
+
+// wsConnection is the state of one client connection. Only the write loop writes
+// to the socket; everything else queues messages through send.
+type wsConnection struct {
+	handler *WebSocketHandler
+	conn    *websocket.Conn
+	userID  uuid.UUID
+	send    chan WSServerMessage
+
+	// ctx is cancelled when the connection goes away; it scopes service calls.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu            sync.Mutex
+	subscriptions map[string]*wsSubscription
+	closeReason   *websocket.CloseError
+}
+
+type wsSubscription struct {
+	channel      string
+	hub          *streaming.Subscription
+	authors      *authorSet
+	unsubscribed bool
+}
+
+func newWSConnection(h *WebSocketHandler, conn *websocket.Conn, userID uuid.UUID) *wsConnection {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &wsConnection{
+		handler:       h,
+		conn:          conn,
+		userID:        userID,
+		send:          make(chan WSServerMessage, h.config.SendQueueSize),
+		ctx:           ctx,
+		cancel:        cancel,
+		subscriptions: make(map[string]*wsSubscription),
+	}
+}
+
+func (c *wsConnection) run() {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.writeLoop()
+	}()
+
+	c.readLoop()
+
+	c.cancel()
+	<-done
+	c.closeSubscriptions()
+	c.conn.Close()
+}
+
+func (c *wsConnection) readLoop() {
+	pongWait := 2 * c.handler.config.PingInterval
+
+	c.conn.SetReadLimit(wsMaxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		var message WSClientMessage
+		if err := c.conn.ReadJSON(&message); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				c.enqueue(wsError("", "Invalid message", "INVALID_MESSAGE"))
+				continue
+			}
+			return
+		}
+
+		c.handle(message)
+	}
+}
+
+func (c *wsConnection) writeLoop() {
+	ping := time.NewTicker(c.handler.config.PingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			c.writeClose()
+			return
+		case message := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(c.handler.config.WriteTimeout))
+			if err := c.conn.WriteJSON(message); err != nil {
+				c.abort()
+				return
+			}
+		case <-ping.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(c.handler.config.WriteTimeout))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.abort()
+				return
+			}
+
+			c.refreshHomeTimelines()
+		}
+	}
+}
+
+// writeClose sends the close frame and unblocks the read loop, which would
+// otherwise wait for the client until the read deadline.
+func (c *wsConnection) writeClose() {
+	c.mu.Lock()
+	reason := c.closeReason
+	c.mu.Unlock()
+
+	if reason == nil {
+		reason = &websocket.CloseError{Code: websocket.CloseNormalClosure}
+	}
+
+	deadline := time.Now().Add(c.handler.config.WriteTimeout)
+	_ = c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(reason.Code, reason.Text), deadline)
+	_ = c.conn.SetReadDeadline(time.Now())
+}
+
+// abort gives up on a connection whose socket can no longer be written to.
+func (c *wsConnection) abort() {
+	c.cancel()
+	_ = c.conn.SetReadDeadline(time.Now())
+}
+
+// enqueue queues a message without blocking. A client that lets the queue fill up
+// is too slow to keep up and is disconnected; it can reconnect and resubscribe
+// with the last event ID it received.
+func (c *wsConnection) enqueue(message WSServerMessage) bool {
+	select {
+	case <-c.ctx.Done():
+		return false
+	default:
+	}
+
+	select {
+	case c.send <- message:
+		return true
+	default:
+		c.closeWith(websocket.ClosePolicyViolation, "slow consumer")
+		return false
+	}
+}
+
+func (c *wsConnection) closeWith(code int, text string) {
+	c.mu.Lock()
+	if c.closeReason == nil {
+		c.closeReason = &websocket.CloseError{Code: code, Text: text}
+	}
+	c.mu.Unlock()
+
+	c.cancel()
+}
+
+func (c *wsConnection) handle(message WSClientMessage) {
+	switch message.Type {
+	case wsMessageSubscribe:
+		c.subscribe(message)
+	case wsMessageUnsubscribe:
+		c.unsubscribe(message.ID)
+	case wsMessagePing:
+		c.enqueue(WSServerMessage{Type: wsMessagePong})
+	default:
+		c.enqueue(wsError(message.ID, "Unknown message type", "INVALID_MESSAGE"))
+	}
+}
+
+func (c *wsConnection) subscribe(message WSClientMessage) {
+	if message.ID == "" {
+		c.enqueue(wsError("", "Subscription ID is required", "INVALID_SUBSCRIPTION_ID"))
+		return
+	}
+
+	c.mu.Lock()
+	_, exists := c.subscriptions[message.ID]
+	c.mu.Unlock()
+	if exists {
+		c.enqueue(wsError(message.ID, "Subscription ID already in use", "INVALID_SUBSCRIPTION_ID"))
+		return
+	}
+
+	subscription := &wsSubscription{channel: message.Channel}
+	var filter streaming.Filter
+
+	switch message.Channel {
+	case ChannelHomeTimeline:
+		subscription.authors = &authorSet{}
+		if err := c.refreshAuthors(subscription.authors); err != nil {
+			c.enqueue(wsError(message.ID, err.Error(), "INTERNAL_ERROR"))
+			return
+		}
+		filter = subscription.authors.contains
+	case ChannelMentions:
+		filter = mentionsFilter(c.userID)
+	case ChannelUserTweets:
+		authorID, err := uuid.Parse(message.UserID)
+		if err != nil {
+			c.enqueue(wsError(message.ID, "Invalid user ID", "INVALID_USER_ID"))
+			return
+		}
+
+		canView, err := c.handler.userService.CanViewTweets(c.ctx, c.userID, authorID)
+		if err != nil {
+			c.enqueue(wsError(message.ID, err.Error(), "INTERNAL_ERROR"))
+			return
+		}
+		if !canView {
+			c.enqueue(wsError(message.ID, "Tweets are not visible to this user", "FORBIDDEN"))
+			return
+		}
+		filter = func(tweet domain.Tweet) bool { return tweet.UserID == authorID }
+	default:
+		c.enqueue(wsError(message.ID, "Unknown channel", "INVALID_CHANNEL"))
+		return
+	}
+
+	// Acknowledge before subscribing so the confirmation precedes any replayed event.
+	if !c.enqueue(WSServerMessage{Type: wsMessageSubscribed, ID: message.ID, Channel: message.Channel}) {
+		return
+	}
+
+	subscription.hub = c.handler.hub.Subscribe(filter, message.LastEventID)
+
+	c.mu.Lock()
+	c.subscriptions[message.ID] = subscription
+	c.mu.Unlock()
+
+	go c.forward(message.ID, subscription)
+}
+
+// forward relays the events of one hub subscription to the client.
+func (c *wsConnection) forward(id string, subscription *wsSubscription) {
+	for event := range subscription.hub.Events() {
+		if !c.canView(subscription, event.Tweet) {
+			continue
+		}
+
+		response := ToTweetResponseSimple(event.Tweet)
+		message := WSServerMessage{Type: wsMessageEvent, ID: id, Channel: subscription.channel, EventID: event.ID, Data: &response}
+		if !c.enqueue(message) {
+			return
+		}
+	}
+
+	c.mu.Lock()
+	dropped := !subscription.unsubscribed
+	if dropped {
+		delete(c.subscriptions, id)
+	}
+	c.mu.Unlock()
+
+	if dropped {
+		// The hub dropped the subscription for falling behind; the client can
+		// subscribe again with the last event ID it received.
+		c.enqueue(wsError(id, "Subscription dropped for falling behind", "SUBSCRIPTION_DROPPED"))
+	}
+}
+
+// canView reports whether the tweet may be delivered. Blocks, follows and privacy
+// change while a subscription is open, so mentions and user_tweets check every tweet
+// rather than only the author at subscribe time; home_timeline authors are already
+// the ones the user can see. Mentions from muted authors are left out, as they are
+// from the notifications. If a check fails the tweet is not delivered.
+func (c *wsConnection) canView(subscription *wsSubscription, tweet domain.Tweet) bool {
+	if subscription.authors != nil {
+		return true
+	}
+
+	canView, err := c.handler.userService.CanViewTweets(c.ctx, c.userID, tweet.UserID)
+	if err != nil || !canView {
+		return false
+	}
+
+	if subscription.channel != ChannelMentions {
+		return true
+	}
+
+	muted, err := c.handler.userService.IsMuted(c.ctx, c.userID, tweet.UserID)
+	return err == nil && !muted
+}
+
+func (c *wsConnection) unsubscribe(id string) {
+	c.mu.Lock()
+	subscription, ok := c.subscriptions[id]
+	if ok {
+		subscription.unsubscribed = true
+		delete(c.subscriptions, id)
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		c.enqueue(wsError(id, "Subscription not found", "SUBSCRIPTION_NOT_FOUND"))
+		return
+	}
+
+	subscription.hub.Close()
+	c.enqueue(WSServerMessage{Type: wsMessageUnsubscribed, ID: id})
+}
+
+func (c *wsConnection) closeSubscriptions() {
+	c.mu.Lock()
+	subscriptions := c.subscriptions
+	c.subscriptions = make(map[string]*wsSubscription)
+	for _, subscription := range subscriptions {
+		subscription.unsubscribed = true
+	}
+	c.mu.Unlock()
+
+	for _, subscription := range subscriptions {
+		subscription.hub.Close()
+	}
+}
+
+func (c *wsConnection) refreshHomeTimelines() {
+	c.mu.Lock()
+	var sets []*authorSet
+	for _, subscription := range c.subscriptions {
+		if subscription.authors != nil {
+			sets = append(sets, subscription.authors)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, authors := range sets {
+		// Keep delivering with the previous set if the refresh fails.
+		_ = c.refreshAuthors(authors)
+	}
+}
+
+func (c *wsConnection) refreshAuthors(authors *authorSet) error {
+	authorIDs, err := c.handler.userService.GetTimelineAuthorIDs(c.ctx, c.userID)
+	if err != nil {
+		return err
+	}
+
+	authors.replace(authorIDs)
+	return nil
+}
+
+func mentionsFilter(userID uuid.UUID) streaming.Filter {
+	return func(tweet domain.Tweet) bool {
+		if tweet.UserID == userID {
+			return false
+		}
+
+		for _, id := range tweet.MentionedUserIDs() {
+			if id == userID {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func wsError(id, message, code string) WSServerMessage {
+	return WSServerMessage{Type: wsMessageError, ID: id, Error: message, Code: code}
+}
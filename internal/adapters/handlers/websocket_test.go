@@ -0,0 +1,373 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/streaming"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	mock_ports "github.com/juanignaciorc/microbloggin-pltf/mocks"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testWebSocketConfig = WebSocketConfig{
+	SendQueueSize:  16,
+	PingInterval:   time.Minute,
+	WriteTimeout:   time.Second,
+	AllowedOrigins: []string{"https://app.example.com"},
+}
+
+// startWebSocketServer serves the handler on a real listener so the tests can use
+// an in-process gorilla client. It returns the ws:// URL of the endpoint.
+func startWebSocketServer(t *testing.T, hub *streaming.Hub, service *mock_ports.MockUserService) string {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws", NewWebSocketHandler(hub, service, testWebSocketConfig).Connect)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+}
+
+func dialWebSocket(t *testing.T, url string, userID string) *websocket.Conn {
+	t.Helper()
+
+	header := http.Header{}
+	header.Set(viewerHeader, userID)
+	header.Set("Origin", "https://app.example.com")
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, header)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func readServerMessage(t *testing.T, conn *websocket.Conn) WSServerMessage {
+	t.Helper()
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	var message WSServerMessage
+	if err := conn.ReadJSON(&message); err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+	return message
+}
+
+func TestWebSocketHandler_Connect_Unauthorized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mock_ports.NewMockUserService(ctrl)
+	hub := streaming.NewHub(streaming.DefaultBufferSize, streaming.DefaultHistorySize)
+	url := startWebSocketServer(t, hub, mockService)
+
+	tests := []struct {
+		name      string
+		url       string
+		setupMock func()
+	}{
+		{
+			name: "Missing user ID",
+			url:  url,
+		},
+		{
+			name: "Invalid user ID",
+			url:  url + "?user_id=invalid",
+		},
+		{
+			name: "Unknown user",
+			url:  url + "?user_id=" + userUuidMock,
+			setupMock: func() {
+				mockService.EXPECT().
+					GetUser(gomock.Any(), uuid.MustParse(userUuidMock)).
+					Return(domain.User{}, errors.New("user not found"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setupMock != nil {
+				tt.setupMock()
+			}
+
+			_, resp, err := websocket.DefaultDialer.Dial(tt.url, nil)
+
+			assert.Equal(t, websocket.ErrBadHandshake, err)
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		})
+	}
+}
+
+func TestWebSocketHandler_HomeTimeline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.MustParse(userUuidMock)
+	followedID := uuid.MustParse(followedUserUuidMock)
+	tweetID := uuid.MustParse(uuidMock)
+
+	mockService := mock_ports.NewMockUserService(ctrl)
+	mockService.EXPECT().GetUser(gomock.Any(), userID).Return(domain.User{ID: userID}, nil)
+	mockService.EXPECT().GetTimelineAuthorIDs(gomock.Any(), userID).Return([]uuid.UUID{followedID}, nil)
+
+	hub := streaming.NewHub(streaming.DefaultBufferSize, streaming.DefaultHistorySize)
+	conn := dialWebSocket(t, startWebSocketServer(t, hub, mockService), userUuidMock)
+
+	_ = conn.WriteJSON(WSClientMessage{Type: "subscribe", ID: "home", Channel: ChannelHomeTimeline})
+	assert.Equal(t, WSServerMessage{Type: "subscribed", ID: "home", Channel: ChannelHomeTimeline}, readServerMessage(t, conn))
+	waitForSubscribers(t, hub, 1)
+
+	hub.PublishTweet(context.Background(), domain.Tweet{ID: uuid.New(), UserID: uuid.New(), Message: "Not followed"})
+	hub.PublishTweet(context.Background(), domain.Tweet{ID: tweetID, UserID: followedID, Message: "Hello"})
+
+	expected := ToTweetResponseSimple(domain.Tweet{ID: tweetID, UserID: followedID, Message: "Hello"})
+	assert.Equal(t, WSServerMessage{Type: "event", ID: "home", Channel: ChannelHomeTimeline, EventID: 2, Data: &expected}, readServerMessage(t, conn))
+}
+
+func TestWebSocketHandler_MultiplexesSubscriptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.MustParse(userUuidMock)
+	authorID := uuid.MustParse(followedUserUuidMock)
+
+	mockService := mock_ports.NewMockUserService(ctrl)
+	mockService.EXPECT().GetUser(gomock.Any(), userID).Return(domain.User{ID: userID}, nil)
+	mockService.EXPECT().CanViewTweets(gomock.Any(), userID, gomock.Any()).Return(true, nil).AnyTimes()
+	mockService.EXPECT().IsMuted(gomock.Any(), userID, gomock.Any()).Return(false, nil).AnyTimes()
+
+	hub := streaming.NewHub(streaming.DefaultBufferSize, streaming.DefaultHistorySize)
+	conn := dialWebSocket(t, startWebSocketServer(t, hub, mockService), userUuidMock)
+
+	_ = conn.WriteJSON(WSClientMessage{Type: "subscribe", ID: "mentions", Channel: ChannelMentions})
+	assert.Equal(t, "subscribed", readServerMessage(t, conn).Type)
+	_ = conn.WriteJSON(WSClientMessage{Type: "subscribe", ID: "author", Channel: ChannelUserTweets, UserID: followedUserUuidMock})
+	assert.Equal(t, "subscribed", readServerMessage(t, conn).Type)
+	waitForSubscribers(t, hub, 2)
+
+	// Matches both subscriptions, so it is delivered once per subscription.
+	hub.PublishTweet(context.Background(), domain.Tweet{ID: uuid.New(), UserID: authorID, Message: "hi @" + userUuidMock})
+
+	received := map[string]uint64{}
+	for i := 0; i < 2; i++ {
+		message := readServerMessage(t, conn)
+		assert.Equal(t, "event", message.Type)
+		received[message.ID] = message.EventID
+	}
+	assert.Equal(t, map[string]uint64{"mentions": 1, "author": 1}, received)
+
+	_ = conn.WriteJSON(WSClientMessage{Type: "unsubscribe", ID: "author"})
+	assert.Equal(t, WSServerMessage{Type: "unsubscribed", ID: "author"}, readServerMessage(t, conn))
+	waitForSubscribers(t, hub, 1)
+
+	hub.PublishTweet(context.Background(), domain.Tweet{ID: uuid.New(), UserID: authorID, Message: "Only for the author channel"})
+	hub.PublishTweet(context.Background(), domain.Tweet{ID: uuid.New(), UserID: uuid.New(), Message: "cc @" + userUuidMock})
+
+	message := readServerMessage(t, conn)
+	assert.Equal(t, "mentions", message.ID)
+	assert.Equal(t, uint64(3), message.EventID)
+}
+
+func TestWebSocketHandler_ChecksVisibilityPerTweet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.MustParse(userUuidMock)
+	authorID := uuid.MustParse(followedUserUuidMock)
+	blockerID := uuid.New()
+
+	mockService := mock_ports.NewMockUserService(ctrl)
+	mockService.EXPECT().GetUser(gomock.Any(), userID).Return(domain.User{ID: userID}, nil)
+	gomock.InOrder(
+		// Visible when subscribing, then the author protects the account, then
+		// approves the user's follow request.
+		mockService.EXPECT().CanViewTweets(gomock.Any(), userID, authorID).Return(true, nil),
+		mockService.EXPECT().CanViewTweets(gomock.Any(), userID, authorID).Return(false, nil),
+		mockService.EXPECT().CanViewTweets(gomock.Any(), userID, authorID).Return(true, nil),
+	)
+	mockService.EXPECT().CanViewTweets(gomock.Any(), userID, blockerID).Return(false, nil)
+
+	hub := streaming.NewHub(streaming.DefaultBufferSize, streaming.DefaultHistorySize)
+	conn := dialWebSocket(t, startWebSocketServer(t, hub, mockService), userUuidMock)
+
+	_ = conn.WriteJSON(WSClientMessage{Type: "subscribe", ID: "author", Channel: ChannelUserTweets, UserID: followedUserUuidMock})
+	assert.Equal(t, "subscribed", readServerMessage(t, conn).Type)
+	waitForSubscribers(t, hub, 1)
+
+	hub.PublishTweet(context.Background(), domain.Tweet{ID: uuid.New(), UserID: authorID, Message: "Protected"})
+	hub.PublishTweet(context.Background(), domain.Tweet{ID: uuid.New(), UserID: authorID, Message: "Approved"})
+
+	message := readServerMessage(t, conn)
+	assert.Equal(t, "author", message.ID)
+	assert.Equal(t, uint64(2), message.EventID)
+
+	_ = conn.WriteJSON(WSClientMessage{Type: "unsubscribe", ID: "author"})
+	assert.Equal(t, "unsubscribed", readServerMessage(t, conn).Type)
+	_ = conn.WriteJSON(WSClientMessage{Type: "subscribe", ID: "mentions", Channel: ChannelMentions})
+	assert.Equal(t, "subscribed", readServerMessage(t, conn).Type)
+	waitForSubscribers(t, hub, 1)
+
+	// A user who blocked the subscriber can still mention them; the mention is not delivered.
+	hub.PublishTweet(context.Background(), domain.Tweet{ID: uuid.New(), UserID: blockerID, Message: "hi @" + userUuidMock})
+	_ = conn.WriteJSON(WSClientMessage{Type: "ping"})
+	assert.Equal(t, WSServerMessage{Type: "pong"}, readServerMessage(t, conn))
+}
+
+func TestWebSocketHandler_SkipsMentionsFromMutedAuthors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.MustParse(userUuidMock)
+	authorID := uuid.MustParse(followedUserUuidMock)
+	mutedID := uuid.New()
+
+	mockService := mock_ports.NewMockUserService(ctrl)
+	mockService.EXPECT().GetUser(gomock.Any(), userID).Return(domain.User{ID: userID}, nil)
+	mockService.EXPECT().CanViewTweets(gomock.Any(), userID, gomock.Any()).Return(true, nil).Times(2)
+	mockService.EXPECT().IsMuted(gomock.Any(), userID, mutedID).Return(true, nil)
+	mockService.EXPECT().IsMuted(gomock.Any(), userID, authorID).Return(false, nil)
+
+	hub := streaming.NewHub(streaming.DefaultBufferSize, streaming.DefaultHistorySize)
+	conn := dialWebSocket(t, startWebSocketServer(t, hub, mockService), userUuidMock)
+
+	_ = conn.WriteJSON(WSClientMessage{Type: "subscribe", ID: "mentions", Channel: ChannelMentions})
+	assert.Equal(t, "subscribed", readServerMessage(t, conn).Type)
+	waitForSubscribers(t, hub, 1)
+
+	hub.PublishTweet(context.Background(), domain.Tweet{ID: uuid.New(), UserID: mutedID, Message: "hi @" + userUuidMock})
+	hub.PublishTweet(context.Background(), domain.Tweet{ID: uuid.New(), UserID: authorID, Message: "hey @" + userUuidMock})
+
+	message := readServerMessage(t, conn)
+	assert.Equal(t, "mentions", message.ID)
+	assert.Equal(t, uint64(2), message.EventID)
+}
+
+func TestWebSocketHandler_Connect_CheckOrigin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.MustParse(userUuidMock)
+
+	mockService := mock_ports.NewMockUserService(ctrl)
+	mockService.EXPECT().GetUser(gomock.Any(), userID).Return(domain.User{ID: userID}, nil).AnyTimes()
+
+	hub := streaming.NewHub(streaming.DefaultBufferSize, streaming.DefaultHistorySize)
+	url := startWebSocketServer(t, hub, mockService) + "?user_id=" + userUuidMock
+	host := strings.TrimPrefix(strings.TrimSuffix(url, "/ws?user_id="+userUuidMock), "ws://")
+
+	tests := []struct {
+		name           string
+		origin         string
+		expectedStatus int
+	}{
+		{name: "No origin", expectedStatus: http.StatusSwitchingProtocols},
+		{name: "Same origin", origin: "http://" + host, expectedStatus: http.StatusSwitchingProtocols},
+		{name: "Allowed origin", origin: "https://app.example.com", expectedStatus: http.StatusSwitchingProtocols},
+		{name: "Other origin", origin: "https://evil.example.com", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.origin != "" {
+				header.Set("Origin", tt.origin)
+			}
+
+			conn, resp, _ := websocket.DefaultDialer.Dial(url, header)
+			if conn != nil {
+				conn.Close()
+			}
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestWebSocketHandler_SubscribeErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.MustParse(userUuidMock)
+	authorID := uuid.MustParse(followedUserUuidMock)
+
+	mockService := mock_ports.NewMockUserService(ctrl)
+	mockService.EXPECT().GetUser(gomock.Any(), userID).Return(domain.User{ID: userID}, nil)
+	mockService.EXPECT().CanViewTweets(gomock.Any(), userID, authorID).Return(false, nil)
+
+	hub := streaming.NewHub(streaming.DefaultBufferSize, streaming.DefaultHistorySize)
+	conn := dialWebSocket(t, startWebSocketServer(t, hub, mockService), userUuidMock)
+
+	tests := []struct {
+		name         string
+		message      WSClientMessage
+		expectedCode string
+	}{
+		{
+			name:         "Missing subscription ID",
+			message:      WSClientMessage{Type: "subscribe", Channel: ChannelMentions},
+			expectedCode: "INVALID_SUBSCRIPTION_ID",
+		},
+		{
+			name:         "Unknown channel",
+			message:      WSClientMessage{Type: "subscribe", ID: "1", Channel: "trending"},
+			expectedCode: "INVALID_CHANNEL",
+		},
+		{
+			name:         "Invalid user ID",
+			message:      WSClientMessage{Type: "subscribe", ID: "1", Channel: ChannelUserTweets, UserID: "invalid"},
+			expectedCode: "INVALID_USER_ID",
+		},
+		{
+			name:         "Protected author",
+			message:      WSClientMessage{Type: "subscribe", ID: "1", Channel: ChannelUserTweets, UserID: followedUserUuidMock},
+			expectedCode: "FORBIDDEN",
+		},
+		{
+			name:         "Unknown subscription",
+			message:      WSClientMessage{Type: "unsubscribe", ID: "1"},
+			expectedCode: "SUBSCRIPTION_NOT_FOUND",
+		},
+		{
+			name:         "Unknown message type",
+			message:      WSClientMessage{Type: "publish"},
+			expectedCode: "INVALID_MESSAGE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = conn.WriteJSON(tt.message)
+
+			message := readServerMessage(t, conn)
+			assert.Equal(t, "error", message.Type)
+			assert.Equal(t, tt.expectedCode, message.Code)
+		})
+	}
+
+	assert.Equal(t, 0, hub.SubscriberCount())
+}
+
+func TestWSConnection_SlowConsumerIsDisconnected(t *testing.T) {
+	handler := NewWebSocketHandler(nil, nil, WebSocketConfig{SendQueueSize: 1})
+	c := newWSConnection(handler, nil, uuid.New())
+
+	assert.Equal(t, true, c.enqueue(WSServerMessage{Type: "pong"}))
+	assert.Equal(t, false, c.enqueue(WSServerMessage{Type: "pong"}))
+
+	assert.Equal(t, context.Canceled, c.ctx.Err())
+	assert.Equal(t, websocket.ClosePolicyViolation, c.closeReason.Code)
+}
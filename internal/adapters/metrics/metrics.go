@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/caching"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "microblog"
+
+// unmatchedRoute labels the requests that matched no route, so unknown paths do
+// not each get their own series.
+const unmatchedRoute = "unmatched"
+
+const (
+	cacheHitsHelp   = "Reads served from the repository cache, by kind of value read."
+	cacheMissesHelp = "Reads the repository cache could not serve, by kind of value read."
+)
+
+// Metrics holds the Prometheus metrics of the API, in a registry of its own.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests       *prometheus.CounterVec
+	httpDuration       *prometheus.HistogramVec
+	repositoryDuration *prometheus.HistogramVec
+	tweetsCreated      prometheus.Counter
+	follows            prometheus.Counter
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests handled, by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time taken to handle HTTP requests, by method and route template.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		repositoryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_operation_duration_seconds",
+			Help:      "Time taken by repository operations, by repository, operation and outcome.",
+			Buckets:   []float64{.0001, .0005, .001, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"repository", "operation", "outcome"}),
+		tweetsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tweets_created_total",
+			Help:      "Tweets created.",
+		}),
+		follows: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "follows_total",
+			Help:      "Follows created, directly or by approving a follow request.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.repositoryDuration,
+		m.tweetsCreated,
+		m.follows,
+	)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Middleware counts and times every request. Requests are labeled with the route
+// template they matched, such as /api/v1/users/:id, rather than their path.
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		m.httpRequests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		m.httpDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// RegisterPool exposes the statistics of a PostgreSQL connection pool.
+func (m *Metrics) RegisterPool(stat func() *pgxpool.Stat) {
+	m.registry.MustRegister(newPoolCollector(stat))
+}
+
+// RegisterCache exposes the hits and misses of the repository cache.
+func (m *Metrics) RegisterCache(cache *caching.Cache) {
+	for _, counter := range []struct {
+		name  string
+		help  string
+		kind  string
+		value func(caching.Stats) uint64
+	}{
+		{"cache_hits_total", cacheHitsHelp, "profile", func(s caching.Stats) uint64 { return s.ProfileHits }},
+		{"cache_misses_total", cacheMissesHelp, "profile", func(s caching.Stats) uint64 { return s.ProfileMisses }},
+		{"cache_hits_total", cacheHitsHelp, "timeline", func(s caching.Stats) uint64 { return s.TimelineHits }},
+		{"cache_misses_total", cacheMissesHelp, "timeline", func(s caching.Stats) uint64 { return s.TimelineMisses }},
+	} {
+		value := counter.value
+		m.registry.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        counter.name,
+			Help:        counter.help,
+			ConstLabels: prometheus.Labels{"kind": counter.kind},
+		}, func() float64 {
+			return float64(value(cache.Stats()))
+		}))
+	}
+}
+
+// observe records how long an operation of a repository took since start.
+func (m *Metrics) observe(repository, operation string, start time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+
+	m.repositoryDuration.WithLabelValues(repository, operation, outcome).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/caching"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/repositories/in_memory_db"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_Scrape(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	m := New()
+	db := in_memory_db.NewInMemoryDB()
+	users := m.UsersRepository(db)
+	tweets := m.TweetRepository(db)
+	cache := caching.New(caching.NewLRU(caching.DefaultSize), caching.DefaultOptions)
+	m.RegisterCache(cache)
+
+	router := gin.New()
+	router.Use(m.Middleware())
+	router.GET("/metrics", gin.WrapH(m.Handler()))
+	router.GET("/users/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	alice, _ := users.CreateUser(ctx, domain.User{Name: "alice"})
+	bob, _ := users.CreateUser(ctx, domain.User{Name: "bob"})
+	assert.NoError(t, users.FollowUser(ctx, alice.ID, bob.ID))
+	_, err := tweets.CreateTweet(ctx, domain.Tweet{UserID: bob.ID, Message: "hello"})
+	assert.NoError(t, err)
+	_, err = users.GetUser(ctx, domain.User{}.ID)
+	assert.Error(t, err)
+	_, _ = caching.NewUsersRepository(users, cache).GetUser(ctx, alice.ID)
+
+	for _, path := range []string{"/users/" + alice.ID.String(), "/users/" + bob.ID.String(), "/missing"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	body, _ := io.ReadAll(recorder.Body)
+	scraped := string(body)
+
+	for _, line := range []string{
+		// Requests are labeled by route template, not by path.
+		`microblog_http_requests_total{method="GET",route="/users/:id",status="200"} 2`,
+		`microblog_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`microblog_http_request_duration_seconds_count{method="GET",route="/users/:id"} 2`,
+		`microblog_repository_operation_duration_seconds_count{operation="CreateUser",outcome="success",repository="users"} 2`,
+		`microblog_repository_operation_duration_seconds_count{operation="GetUser",outcome="error",repository="users"} 1`,
+		`microblog_repository_operation_duration_seconds_count{operation="GetUser",outcome="success",repository="users"} 1`,
+		`microblog_repository_operation_duration_seconds_count{operation="CreateTweet",outcome="success",repository="tweets"} 1`,
+		`microblog_tweets_created_total 1`,
+		`microblog_follows_total 1`,
+		`microblog_cache_misses_total{kind="profile"} 1`,
+		`microblog_cache_hits_total{kind="timeline"} 0`,
+		`go_goroutines`,
+	} {
+		assert.Contains(t, scraped, line)
+	}
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolCollector reads the statistics of a pgxpool.Pool on every scrape.
+type poolCollector struct {
+	stat func() *pgxpool.Stat
+
+	acquiredConns     *prometheus.Desc
+	idleConns         *prometheus.Desc
+	totalConns        *prometheus.Desc
+	maxConns          *prometheus.Desc
+	acquires          *prometheus.Desc
+	emptyAcquires     *prometheus.Desc
+	canceledAcquires  *prometheus.Desc
+	acquireWait       *prometheus.Desc
+	newConns          *prometheus.Desc
+	destroyedLifetime *prometheus.Desc
+	destroyedIdle     *prometheus.Desc
+}
+
+func newPoolCollector(stat func() *pgxpool.Stat) *poolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	return &poolCollector{
+		stat:              stat,
+		acquiredConns:     desc("acquired_connections", "Connections currently in use."),
+		idleConns:         desc("idle_connections", "Connections currently idle."),
+		totalConns:        desc("total_connections", "Connections currently open, including those being established."),
+		maxConns:          desc("max_connections", "Maximum size of the pool."),
+		acquires:          desc("acquires_total", "Connections acquired from the pool."),
+		emptyAcquires:     desc("empty_acquires_total", "Acquires that had to wait because the pool had no idle connection."),
+		canceledAcquires:  desc("canceled_acquires_total", "Acquires canceled by their context."),
+		acquireWait:       desc("acquire_wait_seconds_total", "Time spent waiting to acquire connections."),
+		newConns:          desc("new_connections_total", "Connections opened."),
+		destroyedLifetime: desc("max_lifetime_destroyed_total", "Connections closed for exceeding their maximum lifetime."),
+		destroyedIdle:     desc("max_idle_destroyed_total", "Connections closed for exceeding their maximum idle time."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.stat()
+
+	for _, gauge := range []struct {
+		desc  *prometheus.Desc
+		value int32
+	}{
+		{c.acquiredConns, stat.AcquiredConns()},
+		{c.idleConns, stat.IdleConns()},
+		{c.totalConns, stat.TotalConns()},
+		{c.maxConns, stat.MaxConns()},
+	} {
+		ch <- prometheus.MustNewConstMetric(gauge.desc, prometheus.GaugeValue, float64(gauge.value))
+	}
+
+	for _, counter := range []struct {
+		desc  *prometheus.Desc
+		value int64
+	}{
+		{c.acquires, stat.AcquireCount()},
+		{c.emptyAcquires, stat.EmptyAcquireCount()},
+		{c.canceledAcquires, stat.CanceledAcquireCount()},
+		{c.newConns, stat.NewConnsCount()},
+		{c.destroyedLifetime, stat.MaxLifetimeDestroyCount()},
+		{c.destroyedIdle, stat.MaxIdleDestroyCount()},
+	} {
+		ch <- prometheus.MustNewConstMetric(counter.desc, prometheus.CounterValue, float64(counter.value))
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.acquireWait, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
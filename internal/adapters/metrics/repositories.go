@@ -0,0 +1,252 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	ports "github.com/juanignaciorc/microbloggin-pltf/internal/ports/repositories"
+)
+
+// The repository decorators time every operation of the repository they wrap.
+// The users and tweets decorators also count the follows and tweets their writes
+// create; a write made in a unit of work that later rolls back is still counted.
+
+type usersRepository struct {
+	next    ports.UsersRepository
+	metrics *Metrics
+}
+
+// UsersRepository decorates next so its operations are timed.
+func (m *Metrics) UsersRepository(next ports.UsersRepository) ports.UsersRepository {
+	return &usersRepository{next: next, metrics: m}
+}
+
+func (r *usersRepository) CreateUser(ctx context.Context, user domain.User, events ...domain.Event) (domain.User, error) {
+	start := time.Now()
+	result, err := r.next.CreateUser(ctx, user, events...)
+	r.metrics.observe("users", "CreateUser", start, err)
+	return result, err
+}
+
+func (r *usersRepository) GetUser(ctx context.Context, id uuid.UUID) (domain.User, error) {
+	start := time.Now()
+	result, err := r.next.GetUser(ctx, id)
+	r.metrics.observe("users", "GetUser", start, err)
+	return result, err
+}
+
+func (r *usersRepository) GetUsers(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	start := time.Now()
+	result, err := r.next.GetUsers(ctx, ids)
+	r.metrics.observe("users", "GetUsers", start, err)
+	return result, err
+}
+
+func (r *usersRepository) FollowUser(ctx context.Context, userID uuid.UUID, followedID uuid.UUID, events ...domain.Event) error {
+	start := time.Now()
+	err := r.next.FollowUser(ctx, userID, followedID, events...)
+	r.metrics.observe("users", "FollowUser", start, err)
+	if err == nil {
+		r.metrics.follows.Inc()
+	}
+
+	return err
+}
+
+func (r *usersRepository) GetUserTimeline(ctx context.Context, userID uuid.UUID) ([]domain.Tweet, error) {
+	start := time.Now()
+	result, err := r.next.GetUserTimeline(ctx, userID)
+	r.metrics.observe("users", "GetUserTimeline", start, err)
+	return result, err
+}
+
+func (r *usersRepository) GetFollowers(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	start := time.Now()
+	result, err := r.next.GetFollowers(ctx, userID, page)
+	r.metrics.observe("users", "GetFollowers", start, err)
+	return result, err
+}
+
+func (r *usersRepository) GetFollowing(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	start := time.Now()
+	result, err := r.next.GetFollowing(ctx, userID, page)
+	r.metrics.observe("users", "GetFollowing", start, err)
+	return result, err
+}
+
+func (r *usersRepository) IsFollowing(ctx context.Context, userID uuid.UUID, followedID uuid.UUID) (bool, error) {
+	start := time.Now()
+	result, err := r.next.IsFollowing(ctx, userID, followedID)
+	r.metrics.observe("users", "IsFollowing", start, err)
+	return result, err
+}
+
+func (r *usersRepository) GetFollowedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	start := time.Now()
+	result, err := r.next.GetFollowedUserIDs(ctx, userID)
+	r.metrics.observe("users", "GetFollowedUserIDs", start, err)
+	return result, err
+}
+
+func (r *usersRepository) BlockUser(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error {
+	start := time.Now()
+	err := r.next.BlockUser(ctx, userID, blockedID)
+	r.metrics.observe("users", "BlockUser", start, err)
+	return err
+}
+
+func (r *usersRepository) UnblockUser(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error {
+	start := time.Now()
+	err := r.next.UnblockUser(ctx, userID, blockedID)
+	r.metrics.observe("users", "UnblockUser", start, err)
+	return err
+}
+
+func (r *usersRepository) IsBlocked(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) (bool, error) {
+	start := time.Now()
+	result, err := r.next.IsBlocked(ctx, userID, blockedID)
+	r.metrics.observe("users", "IsBlocked", start, err)
+	return result, err
+}
+
+func (r *usersRepository) GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	start := time.Now()
+	result, err := r.next.GetBlockedUserIDs(ctx, userID)
+	r.metrics.observe("users", "GetBlockedUserIDs", start, err)
+	return result, err
+}
+
+func (r *usersRepository) MuteUser(ctx context.Context, userID uuid.UUID, mutedID uuid.UUID) error {
+	start := time.Now()
+	err := r.next.MuteUser(ctx, userID, mutedID)
+	r.metrics.observe("users", "MuteUser", start, err)
+	return err
+}
+
+func (r *usersRepository) UnmuteUser(ctx context.Context, userID uuid.UUID, mutedID uuid.UUID) error {
+	start := time.Now()
+	err := r.next.UnmuteUser(ctx, userID, mutedID)
+	r.metrics.observe("users", "UnmuteUser", start, err)
+	return err
+}
+
+func (r *usersRepository) GetMutedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	start := time.Now()
+	result, err := r.next.GetMutedUserIDs(ctx, userID)
+	r.metrics.observe("users", "GetMutedUserIDs", start, err)
+	return result, err
+}
+
+func (r *usersRepository) SetProtected(ctx context.Context, userID uuid.UUID, protected bool) error {
+	start := time.Now()
+	err := r.next.SetProtected(ctx, userID, protected)
+	r.metrics.observe("users", "SetProtected", start, err)
+	return err
+}
+
+func (r *usersRepository) CreateFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID) error {
+	start := time.Now()
+	err := r.next.CreateFollowRequest(ctx, followerID, userID)
+	r.metrics.observe("users", "CreateFollowRequest", start, err)
+	return err
+}
+
+func (r *usersRepository) GetFollowRequests(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	start := time.Now()
+	result, err := r.next.GetFollowRequests(ctx, userID, page)
+	r.metrics.observe("users", "GetFollowRequests", start, err)
+	return result, err
+}
+
+func (r *usersRepository) ApproveFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID, events ...domain.Event) error {
+	start := time.Now()
+	err := r.next.ApproveFollowRequest(ctx, followerID, userID, events...)
+	r.metrics.observe("users", "ApproveFollowRequest", start, err)
+	if err == nil {
+		r.metrics.follows.Inc()
+	}
+
+	return err
+}
+
+func (r *usersRepository) RejectFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID) error {
+	start := time.Now()
+	err := r.next.RejectFollowRequest(ctx, followerID, userID)
+	r.metrics.observe("users", "RejectFollowRequest", start, err)
+	return err
+}
+
+type tweetRepository struct {
+	next    ports.TweetRepository
+	metrics *Metrics
+}
+
+// TweetRepository decorates next so its operations are timed.
+func (m *Metrics) TweetRepository(next ports.TweetRepository) ports.TweetRepository {
+	return &tweetRepository{next: next, metrics: m}
+}
+
+func (r *tweetRepository) CreateTweet(ctx context.Context, tweet domain.Tweet, events ...domain.Event) (domain.Tweet, error) {
+	start := time.Now()
+	result, err := r.next.CreateTweet(ctx, tweet, events...)
+	r.metrics.observe("tweets", "CreateTweet", start, err)
+	if err == nil {
+		r.metrics.tweetsCreated.Inc()
+	}
+
+	return result, err
+}
+
+func (r *tweetRepository) GetTweet(ctx context.Context, id uuid.UUID) (domain.Tweet, error) {
+	start := time.Now()
+	result, err := r.next.GetTweet(ctx, id)
+	r.metrics.observe("tweets", "GetTweet", start, err)
+	return result, err
+}
+
+func (r *tweetRepository) GetUserTweets(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.Tweet, error) {
+	start := time.Now()
+	result, err := r.next.GetUserTweets(ctx, userID, page)
+	r.metrics.observe("tweets", "GetUserTweets", start, err)
+	return result, err
+}
+
+type notificationsRepository struct {
+	next    ports.NotificationsRepository
+	metrics *Metrics
+}
+
+// NotificationsRepository decorates next so its operations are timed.
+func (m *Metrics) NotificationsRepository(next ports.NotificationsRepository) ports.NotificationsRepository {
+	return &notificationsRepository{next: next, metrics: m}
+}
+
+func (r *notificationsRepository) CreateNotification(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	start := time.Now()
+	result, err := r.next.CreateNotification(ctx, notification)
+	r.metrics.observe("notifications", "CreateNotification", start, err)
+	return result, err
+}
+
+func (r *notificationsRepository) GetNotifications(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.Notification, error) {
+	start := time.Now()
+	result, err := r.next.GetNotifications(ctx, userID, page)
+	r.metrics.observe("notifications", "GetNotifications", start, err)
+	return result, err
+}
+
+func (r *notificationsRepository) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error) {
+	start := time.Now()
+	result, err := r.next.CountUnreadNotifications(ctx, userID)
+	r.metrics.observe("notifications", "CountUnreadNotifications", start, err)
+	return result, err
+}
+
+func (r *notificationsRepository) MarkNotificationsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
+	start := time.Now()
+	err := r.next.MarkNotificationsRead(ctx, userID, ids)
+	r.metrics.observe("notifications", "MarkNotificationsRead", start, err)
+	return err
+}
@@ -0,0 +1,134 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	doc, err := Load()
+	assert.NoError(t, err)
+
+	handler, err := Handler(doc)
+	assert.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/openapi.json", handler)
+	router.GET("/docs", DocsHandler)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	var served map[string]any
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &served))
+	assert.Equal(t, "3.0.3", served["openapi"])
+	assert.Contains(t, served["paths"], "/api/v1/users/{id}")
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), `url: "/openapi.json"`)
+}
+
+func TestValidator_Middleware(t *testing.T) {
+	doc, err := Load()
+	assert.NoError(t, err)
+	validator, err := NewValidator(doc)
+	assert.NoError(t, err)
+
+	const userID = "4d4e5f6a-1b2c-4d3e-8f9a-0b1c2d3e4f5a"
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		status   int
+		response string
+		reported []string
+	}{
+		{
+			name:     "Matches the document",
+			method:   http.MethodGet,
+			path:     "/api/v1/users/" + userID + "/followers",
+			status:   http.StatusOK,
+			response: `{"message":"Followers retrieved successfully","data":{"users":[],"limit":20,"offset":0}}`,
+		},
+		{
+			name:     "Rejected request with a documented error",
+			method:   http.MethodGet,
+			path:     "/api/v1/users/not-a-uuid/followers",
+			status:   http.StatusBadRequest,
+			response: `{"error":"Invalid user ID","code":"INVALID_USER_ID"}`,
+		},
+		{
+			name:     "Undocumented response field",
+			method:   http.MethodGet,
+			path:     "/api/v1/users/" + userID + "/followers",
+			status:   http.StatusOK,
+			response: `{"message":"Followers retrieved successfully","data":{"users":[],"limit":20,"offset":0,"total":0}}`,
+			reported: []string{"invalid response"},
+		},
+		{
+			name:     "Undocumented status",
+			method:   http.MethodGet,
+			path:     "/api/v1/users/" + userID + "/followers",
+			status:   http.StatusTeapot,
+			response: `{"error":"teapot"}`,
+			reported: []string{"invalid response"},
+		},
+		{
+			name:     "Invalid request accepted",
+			method:   http.MethodPost,
+			path:     "/api/v1/users/" + userID + "/tweet",
+			body:     `{}`,
+			status:   http.StatusCreated,
+			response: `{"message":"Tweet created successfully","data":{"id":"` + userID + `","message":"","user":{"id":"` + userID + `","name":"alice"}}}`,
+			reported: []string{"the request is invalid"},
+		},
+		{
+			name:     "Undocumented route",
+			method:   http.MethodGet,
+			path:     "/api/v1/unknown",
+			status:   http.StatusOK,
+			response: `{}`,
+			reported: []string{"is not documented"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var reported []error
+			router := gin.New()
+			router.Use(validator.Middleware(func(err error) { reported = append(reported, err) }))
+			router.Handle(tc.method, strings.Split(tc.path, "?")[0], func(ctx *gin.Context) {
+				ctx.Data(tc.status, "application/json; charset=utf-8", []byte(tc.response))
+			})
+
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if tc.body != "" {
+				request.Header.Set("Content-Type", "application/json")
+			}
+			router.ServeHTTP(response, request)
+
+			// The response reaches the client unchanged.
+			assert.Equal(t, tc.status, response.Code)
+			assert.Equal(t, tc.response, response.Body.String())
+
+			assert.Len(t, reported, len(tc.reported))
+			for i, message := range tc.reported {
+				if i < len(reported) {
+					assert.Contains(t, reported[i].Error(), message)
+				}
+			}
+		})
+	}
+}
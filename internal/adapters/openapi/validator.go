@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+)
+
+// Validator checks the requests the API receives and the responses it sends
+// against the OpenAPI document. It keeps a copy of every response body, so it is
+// meant for tests rather than for serving traffic.
+type Validator struct {
+	router routers.Router
+}
+
+func NewValidator(doc *openapi3.T) (*Validator, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Validator{router: router}, nil
+}
+
+// Middleware passes to report every mismatch with the document: routes it does
+// not describe, requests it considers invalid that the API accepted, and
+// responses whose status or body it does not describe. Requests the API rejects,
+// such as one with a malformed ID, are expected to break the document, so only
+// their error responses are checked. WebSocket handshakes are not checked.
+func (v *Validator) Middleware(report func(error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route, pathParams, err := v.router.FindRoute(c.Request)
+		if err != nil {
+			if c.FullPath() != "" {
+				report(fmt.Errorf("%s %s is not documented: %w", c.Request.Method, c.FullPath(), err))
+			}
+			c.Next()
+			return
+		}
+
+		if c.IsWebsocket() {
+			c.Next()
+			return
+		}
+
+		request := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			// The handlers check credentials; here they only need to be documented.
+			Options: &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+		}
+		// ValidateRequest puts back the body it reads, for the handler.
+		requestErr := openapi3filter.ValidateRequest(c.Request.Context(), request)
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := c.Writer.Status()
+		if requestErr != nil && status < http.StatusBadRequest {
+			report(fmt.Errorf("%s %s was answered %d but the request is invalid: %w", c.Request.Method, c.Request.URL, status, requestErr))
+		}
+
+		response := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: request,
+			Status:                 status,
+			Header:                 c.Writer.Header(),
+			Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+		}
+		response.SetBodyBytes(recorder.body.Bytes())
+		if err := openapi3filter.ValidateResponse(c.Request.Context(), response); err != nil {
+			report(fmt.Errorf("%s %s answered %d with an invalid response: %w", c.Request.Method, c.Request.URL, status, err))
+		}
+	}
+}
+
+// bodyRecorder keeps a copy of what is written to the response.
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *bodyRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,100 @@
+package in_memory_db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+// historySizes are the numbers of tweets and follows the writing user already
+// has. The cost per write should stay flat across them.
+var historySizes = []int{0, 1_000, 10_000}
+
+// userWithHistory creates a user who has tweeted and followed others size times.
+func userWithHistory(b *testing.B, db *InMemoryDB, size int) uuid.UUID {
+	ctx := context.Background()
+
+	user, err := db.CreateUser(ctx, domain.User{Name: "writer", Email: "writer@example.com"})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for i := 0; i < size; i++ {
+		if _, err := db.CreateTweet(ctx, domain.Tweet{UserID: user.ID, Message: "tweet"}); err != nil {
+			b.Fatal(err)
+		}
+
+		followed, err := db.CreateUser(ctx, domain.User{Name: "followed", Email: "followed@example.com"})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := db.FollowUser(ctx, user.ID, followed.ID); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	return user.ID
+}
+
+func BenchmarkInMemoryDB_CreateTweet(b *testing.B) {
+	for _, size := range historySizes {
+		b.Run(fmt.Sprintf("history=%d", size), func(b *testing.B) {
+			ctx := context.Background()
+			db := NewInMemoryDB()
+			userID := userWithHistory(b, db, size)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := db.CreateTweet(ctx, domain.Tweet{UserID: userID, Message: "tweet"}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkInMemoryDB_FollowUser(b *testing.B) {
+	for _, size := range historySizes {
+		b.Run(fmt.Sprintf("history=%d", size), func(b *testing.B) {
+			ctx := context.Background()
+			db := NewInMemoryDB()
+			userID := userWithHistory(b, db, size)
+			followedID := userWithHistory(b, db, size)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := db.FollowUser(ctx, userID, followedID); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// The follow service writes in a unit of work, which must not copy the store either.
+func BenchmarkInMemoryDB_FollowUserInUnitOfWork(b *testing.B) {
+	for _, size := range historySizes {
+		b.Run(fmt.Sprintf("history=%d", size), func(b *testing.B) {
+			ctx := context.Background()
+			db := NewInMemoryDB()
+			userID := userWithHistory(b, db, size)
+			followedID := userWithHistory(b, db, size)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				err := db.Do(ctx, func(ctx context.Context) error {
+					return db.FollowUser(ctx, userID, followedID)
+				})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package in_memory_db
+
+import (
+	"bytes"
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"sort"
+)
+
+// The exports hold the read lock for the whole walk, so they see one state of the
+// store; fn must not write to it.
+func (db *InMemoryDB) ExportUsers(ctx context.Context, fn func(domain.User) error) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, id := range db.sortedUserIDs() {
+		if err := fn(db.users[id]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (db *InMemoryDB) ExportFollows(ctx context.Context, fn func(domain.Follow) error) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, id := range db.sortedUserIDs() {
+		following := append([]uuid.UUID(nil), db.following[id]...)
+		sortIDs(following)
+
+		for _, followedID := range following {
+			if err := fn(domain.Follow{FollowerID: id, FollowedID: followedID}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (db *InMemoryDB) ExportTweets(ctx context.Context, fn func(domain.Tweet) error) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	tweets := make([]domain.Tweet, 0, len(db.tweets))
+	for _, tweet := range db.tweets {
+		tweets = append(tweets, tweet)
+	}
+
+	sort.Slice(tweets, func(i, j int) bool {
+		return tweetBefore(tweets[i], tweets[j])
+	})
+
+	for _, tweet := range tweets {
+		if err := fn(tweet); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (db *InMemoryDB) ImportUsers(ctx context.Context, users []domain.User) (int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var records []record
+	seen := make(map[uuid.UUID]struct{})
+	for _, user := range users {
+		if _, ok := db.users[user.ID]; ok {
+			continue
+		}
+		if _, ok := seen[user.ID]; ok {
+			continue
+		}
+
+		records = append(records, putUserRecord(user))
+		seen[user.ID] = struct{}{}
+	}
+
+	return db.writeImport(ctx, records)
+}
+
+func (db *InMemoryDB) ImportFollows(ctx context.Context, follows []domain.Follow) (int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var records []record
+	seen := make(map[domain.Follow]struct{})
+	for _, follow := range follows {
+		if follow.FollowerID == follow.FollowedID {
+			continue
+		}
+		if db.checkUsers(follow.FollowerID, follow.FollowedID) != nil {
+			continue
+		}
+		if _, ok := seen[follow]; ok || containsID(db.following[follow.FollowerID], follow.FollowedID) {
+			continue
+		}
+
+		records = append(records, edgeRecord(opFollow, follow.FollowerID, follow.FollowedID))
+		seen[follow] = struct{}{}
+	}
+
+	return db.writeImport(ctx, records)
+}
+
+func (db *InMemoryDB) ImportTweets(ctx context.Context, tweets []domain.Tweet) (int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var records []record
+	seen := make(map[uuid.UUID]struct{})
+	for i, tweet := range tweets {
+		if _, ok := db.tweets[tweet.ID]; ok {
+			continue
+		}
+		if _, ok := seen[tweet.ID]; ok {
+			continue
+		}
+		if db.checkUsers(tweet.UserID) != nil {
+			continue
+		}
+
+		records = append(records, record{Op: opAddTweet, Tweet: &tweets[i]})
+		seen[tweet.ID] = struct{}{}
+	}
+
+	return db.writeImport(ctx, records)
+}
+
+// writeImport saves what an import kept of its batch in one write, and returns how
+// many items that was.
+func (db *InMemoryDB) writeImport(ctx context.Context, records []record) (int, error) {
+	if len(records) == 0 {
+		return 0, nil
+	}
+
+	if err := db.write(ctx, nil, records...); err != nil {
+		return 0, err
+	}
+
+	return len(records), nil
+}
+
+func (db *InMemoryDB) sortedUserIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(db.users))
+	for id := range db.users {
+		ids = append(ids, id)
+	}
+	sortIDs(ids)
+
+	return ids
+}
+
+// sortIDs orders ids bytewise, the order Postgres uses for uuid columns.
+func sortIDs(ids []uuid.UUID) {
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
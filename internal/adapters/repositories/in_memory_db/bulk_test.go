@@ -0,0 +1,106 @@
+package in_memory_db
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestInMemoryDB_ImportIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	db := NewInMemoryDB()
+
+	alice := domain.User{ID: uuid.New(), Name: "alice", Email: "alice@example.com", Protected: true}
+	bob := domain.User{ID: uuid.New(), Name: "bob", Email: "bob@example.com"}
+	unknown := uuid.New()
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tweet := domain.Tweet{ID: uuid.New(), UserID: alice.ID, Message: "hello", CreatedAt: createdAt}
+
+	for run := 0; run < 2; run++ {
+		want := 1
+		if run == 1 {
+			want = 0
+		}
+
+		imported, err := db.ImportUsers(ctx, []domain.User{alice, bob})
+		assert.NoError(t, err)
+		assert.Equal(t, 2*want, imported)
+
+		imported, err = db.ImportFollows(ctx, []domain.Follow{
+			{FollowerID: bob.ID, FollowedID: alice.ID},
+			{FollowerID: bob.ID, FollowedID: bob.ID},
+			{FollowerID: bob.ID, FollowedID: unknown},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, want, imported)
+
+		imported, err = db.ImportTweets(ctx, []domain.Tweet{tweet, {ID: uuid.New(), UserID: unknown, Message: "orphan"}})
+		assert.NoError(t, err)
+		assert.Equal(t, want, imported)
+	}
+
+	gotAlice, err := db.GetUser(ctx, alice.ID)
+	assert.NoError(t, err)
+	assert.True(t, gotAlice.Protected)
+	assert.Equal(t, 1, gotAlice.FollowersCount)
+	tweets, err := db.GetUserTweets(ctx, alice.ID, domain.NewPagination(10, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Tweet{tweet}, tweets)
+
+	following, err := db.GetFollowedUserIDs(ctx, bob.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{alice.ID}, following)
+}
+
+func TestInMemoryDB_ExportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source := NewInMemoryDB()
+
+	alice, _ := source.CreateUser(ctx, domain.User{Name: "alice", Email: "alice@example.com"})
+	bob, _ := source.CreateUser(ctx, domain.User{Name: "bob", Email: "bob@example.com"})
+	assert.NoError(t, source.FollowUser(ctx, alice.ID, bob.ID))
+	later, _ := source.CreateTweet(ctx, domain.Tweet{UserID: bob.ID, Message: "second", CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)})
+	earlier, _ := source.CreateTweet(ctx, domain.Tweet{UserID: alice.ID, Message: "first", CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)})
+
+	var users []domain.User
+	assert.NoError(t, source.ExportUsers(ctx, func(user domain.User) error {
+		users = append(users, user)
+		return nil
+	}))
+	var follows []domain.Follow
+	assert.NoError(t, source.ExportFollows(ctx, func(follow domain.Follow) error {
+		follows = append(follows, follow)
+		return nil
+	}))
+	var tweets []domain.Tweet
+	assert.NoError(t, source.ExportTweets(ctx, func(tweet domain.Tweet) error {
+		tweets = append(tweets, tweet)
+		return nil
+	}))
+
+	assert.Len(t, users, 2)
+	for _, user := range users {
+		assert.Zero(t, user.FollowingCount)
+		assert.Zero(t, user.TweetsCount)
+	}
+	assert.Equal(t, []domain.Follow{{FollowerID: alice.ID, FollowedID: bob.ID}}, follows)
+	assert.Equal(t, []domain.Tweet{earlier, later}, tweets)
+
+	target := NewInMemoryDB()
+	_, err := target.ImportUsers(ctx, users)
+	assert.NoError(t, err)
+	_, err = target.ImportFollows(ctx, follows)
+	assert.NoError(t, err)
+	_, err = target.ImportTweets(ctx, tweets)
+	assert.NoError(t, err)
+
+	for _, id := range []uuid.UUID{alice.ID, bob.ID} {
+		want, _ := source.GetUser(ctx, id)
+		got, err := target.GetUser(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,75 @@
+package in_memory_db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+func (db *InMemoryDB) SetProtected(ctx context.Context, userID uuid.UUID, protected bool) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.checkUsers(userID); err != nil {
+		return err
+	}
+
+	user := db.users[userID]
+	user.Protected = protected
+
+	return db.write(ctx, nil, putUserRecord(user))
+}
+
+func (db *InMemoryDB) CreateFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.checkUsers(followerID, userID); err != nil {
+		return err
+	}
+
+	if containsID(db.followRequests[userID], followerID) {
+		return nil
+	}
+
+	return db.write(ctx, nil, edgeRecord(opAddFollowRequest, followerID, userID))
+}
+
+func (db *InMemoryDB) GetFollowRequests(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.checkUsers(userID); err != nil {
+		return nil, err
+	}
+
+	return db.getUsers(paginateIDs(db.followRequests[userID], page))
+}
+
+// ApproveFollowRequest removes the request and adds the follow edge in one write.
+func (db *InMemoryDB) ApproveFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID, events ...domain.Event) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if !containsID(db.followRequests[userID], followerID) {
+		return domain.ErrFollowRequestNotFound
+	}
+
+	if err := db.checkUsers(followerID, userID); err != nil {
+		return err
+	}
+
+	return db.write(ctx, events, edgeRecord(opFollow, followerID, userID), edgeRecord(opRemoveFollowRequest, followerID, userID))
+}
+
+func (db *InMemoryDB) RejectFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if !containsID(db.followRequests[userID], followerID) {
+		return domain.ErrFollowRequestNotFound
+	}
+
+	return db.write(ctx, nil, edgeRecord(opRemoveFollowRequest, followerID, userID))
+}
@@ -0,0 +1,87 @@
+package in_memory_db
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestInMemoryDB_FollowRequests(t *testing.T) {
+	tests := []struct {
+		name       string
+		resolve    func(*InMemoryDB, uuid.UUID, uuid.UUID) error
+		wantErr    error
+		wantFollow bool
+	}{
+		{
+			name: "Approve turns the request into a follow",
+			resolve: func(db *InMemoryDB, followerID, userID uuid.UUID) error {
+				return db.ApproveFollowRequest(context.Background(), followerID, userID)
+			},
+			wantFollow: true,
+		},
+		{
+			name: "Reject drops the request",
+			resolve: func(db *InMemoryDB, followerID, userID uuid.UUID) error {
+				return db.RejectFollowRequest(context.Background(), followerID, userID)
+			},
+			wantFollow: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := NewInMemoryDB()
+			ctx := context.Background()
+
+			owner, _ := db.CreateUser(ctx, domain.User{Name: "owner", Email: "owner@example.com"})
+			requester, _ := db.CreateUser(ctx, domain.User{Name: "requester", Email: "requester@example.com"})
+			assert.NoError(t, db.SetProtected(ctx, owner.ID, true))
+
+			storedOwner, err := db.GetUser(ctx, owner.ID)
+			assert.NoError(t, err)
+			assert.True(t, storedOwner.Protected)
+
+			// Requesting twice keeps a single pending request
+			assert.NoError(t, db.CreateFollowRequest(ctx, requester.ID, owner.ID))
+			assert.NoError(t, db.CreateFollowRequest(ctx, requester.ID, owner.ID))
+
+			requests, err := db.GetFollowRequests(ctx, owner.ID, domain.NewPagination(0, 0))
+			assert.NoError(t, err)
+			assert.Len(t, requests, 1)
+			assert.Equal(t, requester.ID, requests[0].ID)
+
+			assert.NoError(t, tt.resolve(db, requester.ID, owner.ID))
+
+			following, err := db.IsFollowing(ctx, requester.ID, owner.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFollow, following)
+
+			requests, err = db.GetFollowRequests(ctx, owner.ID, domain.NewPagination(0, 0))
+			assert.NoError(t, err)
+			assert.Empty(t, requests)
+
+			// The request is gone, so resolving it again fails
+			assert.ErrorIs(t, tt.resolve(db, requester.ID, owner.ID), domain.ErrFollowRequestNotFound)
+		})
+	}
+}
+
+func TestInMemoryDB_GetTweet(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+
+	author, _ := db.CreateUser(ctx, domain.User{Name: "author", Email: "author@example.com"})
+	created, err := db.CreateTweet(ctx, domain.Tweet{UserID: author.ID, Message: "hello"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, created.ID)
+
+	got, err := db.GetTweet(ctx, created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, created, got)
+
+	_, err = db.GetTweet(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrTweetNotFound)
+}
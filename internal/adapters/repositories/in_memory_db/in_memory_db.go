@@ -4,18 +4,59 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"sync"
 )
 
 type InMemoryDBTweetsInterface interface {
-	CreateTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, error)
+	CreateTweet(ctx context.Context, tweet domain.Tweet, events ...domain.Event) (domain.Tweet, error)
 }
 
 type InMemoryDB struct {
-	data map[uuid.UUID][]byte
+	// mu guards the maps below. Writes hold it from their checks until the change is
+	// logged and applied, so the log has them in the order they were applied.
+	mu sync.RWMutex
+	// users holds the profiles; relationships and tweets live in their own maps
+	// so a write only touches what it changes.
+	users map[uuid.UUID]domain.User
+	// tweets are indexed by author in the order they were created.
+	tweets         map[uuid.UUID]domain.Tweet
+	tweetsByAuthor map[uuid.UUID][]uuid.UUID
+	// following and followers index the follow edges from both ends, in the order
+	// they were made.
+	following map[uuid.UUID][]uuid.UUID
+	followers map[uuid.UUID][]uuid.UUID
+
+	blocks         map[uuid.UUID]map[uuid.UUID]struct{}
+	mutes          map[uuid.UUID]map[uuid.UUID]struct{}
+	followRequests map[uuid.UUID][]uuid.UUID
+	notifications  map[uuid.UUID][]domain.Notification
+
+	// txMu serializes units of work.
+	txMu sync.Mutex
+
+	// The outbox is read by the event dispatcher from its own goroutine.
+	outboxMu sync.Mutex
+	outbox   []domain.Event
+
+	// Webhooks are written by the API and read by the delivery worker concurrently.
+	webhooksMu sync.Mutex
+	webhooks   []domain.Webhook
+	deliveries []domain.WebhookDelivery
+
+	// wal is set by OpenInMemoryDB; without it changes are only kept in memory.
+	wal *writeAheadLog
 }
 
 func NewInMemoryDB() *InMemoryDB {
 	return &InMemoryDB{
-		data: make(map[uuid.UUID][]byte),
+		users:          make(map[uuid.UUID]domain.User),
+		tweets:         make(map[uuid.UUID]domain.Tweet),
+		tweetsByAuthor: make(map[uuid.UUID][]uuid.UUID),
+		following:      make(map[uuid.UUID][]uuid.UUID),
+		followers:      make(map[uuid.UUID][]uuid.UUID),
+		blocks:         make(map[uuid.UUID]map[uuid.UUID]struct{}),
+		mutes:          make(map[uuid.UUID]map[uuid.UUID]struct{}),
+		followRequests: make(map[uuid.UUID][]uuid.UUID),
+		notifications:  make(map[uuid.UUID][]domain.Notification),
 	}
 }
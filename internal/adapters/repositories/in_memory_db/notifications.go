@@ -0,0 +1,95 @@
+package in_memory_db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+func (db *InMemoryDB) CreateNotification(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.checkUsers(notification.UserID); err != nil {
+		return domain.Notification{}, err
+	}
+
+	notification.ID = uuid.New()
+	notification.CreatedAt = time.Now()
+	if err := db.write(ctx, nil, record{Op: opAddNotification, Notification: &notification}); err != nil {
+		return domain.Notification{}, err
+	}
+
+	return notification, nil
+}
+
+func (db *InMemoryDB) GetNotifications(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.Notification, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	stored := db.notifications[userID]
+
+	newestFirst := make([]domain.Notification, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		newestFirst = append(newestFirst, stored[i])
+	}
+
+	if page.Offset >= len(newestFirst) {
+		return []domain.Notification{}, nil
+	}
+
+	end := page.Offset + page.Limit
+	if end > len(newestFirst) {
+		end = len(newestFirst)
+	}
+
+	return newestFirst[page.Offset:end], nil
+}
+
+func (db *InMemoryDB) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	unread := 0
+	for _, notification := range db.notifications[userID] {
+		if !notification.Read {
+			unread++
+		}
+	}
+
+	return unread, nil
+}
+
+func (db *InMemoryDB) MarkNotificationsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.write(ctx, nil, record{Op: opMarkNotificationsRead, UserID: &userID, IDs: ids})
+}
+
+// markNotificationsRead marks the given notifications of the user as read, or all
+// of them when ids is empty.
+func (db *InMemoryDB) markNotificationsRead(userID uuid.UUID, ids []uuid.UUID) {
+	selected := selectNotifications(ids)
+	notifications := db.notifications[userID]
+	for i, notification := range notifications {
+		if selected(notification) {
+			notifications[i].Read = true
+		}
+	}
+}
+
+// selectNotifications reports whether a notification is one of ids, or any of them
+// when ids is empty.
+func selectNotifications(ids []uuid.UUID) func(domain.Notification) bool {
+	selected := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		selected[id] = true
+	}
+
+	return func(notification domain.Notification) bool {
+		return len(ids) == 0 || selected[notification.ID]
+	}
+}
@@ -0,0 +1,50 @@
+package in_memory_db
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestInMemoryDB_Notifications(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+
+	user, _ := db.CreateUser(ctx, domain.User{Name: "user", Email: "user@example.com"})
+	actor, _ := db.CreateUser(ctx, domain.User{Name: "actor", Email: "actor@example.com"})
+
+	first, err := db.CreateNotification(ctx, domain.Notification{UserID: user.ID, ActorID: actor.ID, Type: domain.NotificationTypeFollow})
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, first.ID)
+	assert.False(t, first.CreatedAt.IsZero())
+
+	second, err := db.CreateNotification(ctx, domain.Notification{UserID: user.ID, ActorID: actor.ID, Type: domain.NotificationTypeMention})
+	assert.NoError(t, err)
+
+	_, err = db.CreateNotification(ctx, domain.Notification{UserID: uuid.New(), ActorID: actor.ID, Type: domain.NotificationTypeFollow})
+	assert.Error(t, err)
+
+	notifications, err := db.GetNotifications(ctx, user.ID, domain.NewPagination(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Notification{second, first}, notifications)
+
+	notifications, err = db.GetNotifications(ctx, user.ID, domain.NewPagination(1, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Notification{first}, notifications)
+
+	unread, err := db.CountUnreadNotifications(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, unread)
+
+	assert.NoError(t, db.MarkNotificationsRead(ctx, user.ID, []uuid.UUID{first.ID}))
+	unread, err = db.CountUnreadNotifications(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, unread)
+
+	assert.NoError(t, db.MarkNotificationsRead(ctx, user.ID, nil))
+	unread, err = db.CountUnreadNotifications(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, unread)
+}
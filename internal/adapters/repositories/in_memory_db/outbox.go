@@ -0,0 +1,71 @@
+package in_memory_db
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+// GetPendingEvents needs no locks that outlive the call: units of work are
+// serialized, so a second dispatcher waits for the first one to finish.
+func (db *InMemoryDB) GetPendingEvents(ctx context.Context, now time.Time, limit int) ([]domain.Event, error) {
+	db.outboxMu.Lock()
+	defer db.outboxMu.Unlock()
+
+	events := []domain.Event{}
+	for _, event := range db.outbox {
+		if event.DeadAt == nil && !event.NextAttemptAt.After(now) {
+			events = append(events, event)
+		}
+	}
+
+	// The outbox is in the order the events occurred, which breaks the ties.
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].NextAttemptAt.Before(events[j].NextAttemptAt)
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
+
+func (db *InMemoryDB) MarkEventsDispatched(ctx context.Context, ids []uuid.UUID) error {
+	db.outboxMu.Lock()
+	defer db.outboxMu.Unlock()
+
+	return db.write(ctx, nil, record{Op: opDispatchEvents, IDs: ids})
+}
+
+func (db *InMemoryDB) MarkEventFailed(ctx context.Context, event domain.Event) error {
+	db.outboxMu.Lock()
+	defer db.outboxMu.Unlock()
+
+	return db.write(ctx, nil, record{Op: opFailEvent, Event: &event})
+}
+
+func (db *InMemoryDB) replaceEvent(event domain.Event) {
+	for i, existing := range db.outbox {
+		if existing.ID == event.ID {
+			db.outbox[i] = event
+		}
+	}
+}
+
+func (db *InMemoryDB) removeDispatched(ids []uuid.UUID) {
+	dispatched := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		dispatched[id] = struct{}{}
+	}
+
+	pending := db.outbox[:0]
+	for _, event := range db.outbox {
+		if _, ok := dispatched[event.ID]; !ok {
+			pending = append(pending, event)
+		}
+	}
+	db.outbox = pending
+}
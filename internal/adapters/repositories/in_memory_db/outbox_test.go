@@ -0,0 +1,109 @@
+package in_memory_db
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestInMemoryDB_Outbox(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+
+	user := domain.User{ID: uuid.New(), Name: "user", Email: "user@example.com"}
+	created := domain.NewUserCreatedEvent(user)
+	_, err := db.CreateUser(ctx, user, created)
+	assert.NoError(t, err)
+
+	tweet := domain.Tweet{ID: uuid.New(), UserID: user.ID, Message: "hello"}
+	tweeted := domain.NewTweetCreatedEvent(tweet)
+	_, err = db.CreateTweet(ctx, tweet, tweeted)
+	assert.NoError(t, err)
+
+	// A failed write records no event.
+	_, err = db.CreateTweet(ctx, domain.Tweet{UserID: uuid.New()}, domain.NewTweetCreatedEvent(domain.Tweet{}))
+	assert.Error(t, err)
+
+	events, err := db.GetPendingEvents(ctx, time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Event{created, tweeted}, events)
+
+	events, err = db.GetPendingEvents(ctx, time.Now(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Event{created}, events)
+
+	assert.NoError(t, db.MarkEventsDispatched(ctx, []uuid.UUID{created.ID}))
+
+	events, err = db.GetPendingEvents(ctx, time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Event{tweeted}, events)
+}
+
+func TestInMemoryDB_Outbox_MarkEventFailed(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+
+	var events []domain.Event
+	for _, name := range []string{"first", "second", "third"} {
+		user := domain.User{ID: uuid.New(), Name: name}
+		event := domain.NewUserCreatedEvent(user)
+		_, err := db.CreateUser(ctx, user, event)
+		assert.NoError(t, err)
+		events = append(events, event)
+	}
+	now := events[2].OccurredAt
+
+	retried := events[0]
+	retried.Attempts = 1
+	retried.NextAttemptAt = now.Add(time.Minute)
+	assert.NoError(t, db.MarkEventFailed(ctx, retried))
+
+	dead := events[1]
+	dead.Attempts = 3
+	dead.DeadAt = &now
+	assert.NoError(t, db.MarkEventFailed(ctx, dead))
+
+	pending, err := db.GetPendingEvents(ctx, now, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Event{events[2]}, pending)
+
+	// Once due, the retried event comes after the ones that were due before it.
+	pending, err = db.GetPendingEvents(ctx, now.Add(time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Event{events[2], retried}, pending)
+}
+
+func TestInMemoryDB_Outbox_WritesWithoutEventsAlongsideDispatch(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+
+	user := domain.User{ID: uuid.New(), Name: "user"}
+	created := domain.NewUserCreatedEvent(user)
+	_, err := db.CreateUser(ctx, user, created)
+	assert.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// What the dispatcher does on every poll.
+		for i := 0; i < 100; i++ {
+			pending, _ := db.GetPendingEvents(ctx, time.Now(), 10)
+			for _, event := range pending {
+				_ = db.MarkEventsDispatched(ctx, []uuid.UUID{event.ID})
+			}
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		_, err := db.CreateTweet(ctx, domain.Tweet{ID: uuid.New(), UserID: user.ID, Message: "no event"})
+		assert.NoError(t, err)
+	}
+	<-done
+
+	pending, err := db.GetPendingEvents(ctx, time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+}
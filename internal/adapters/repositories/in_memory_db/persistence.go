@@ -0,0 +1,640 @@
+package in_memory_db
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+// SyncPolicy says when appends to the write-ahead log are flushed to disk.
+type SyncPolicy string
+
+const (
+	// SyncAlways flushes before every write returns, so no acknowledged write is lost.
+	SyncAlways SyncPolicy = "always"
+	// SyncInterval flushes every SyncInterval; a crash loses at most that much.
+	SyncInterval SyncPolicy = "interval"
+	// SyncNever leaves flushing to the operating system.
+	SyncNever SyncPolicy = "never"
+)
+
+func ParseSyncPolicy(s string) (SyncPolicy, error) {
+	switch SyncPolicy(s) {
+	case SyncAlways, SyncInterval, SyncNever:
+		return SyncPolicy(s), nil
+	}
+	return "", fmt.Errorf("unknown sync policy %q, want always, interval or never", s)
+}
+
+// PersistenceOptions configure a store that survives restarts.
+type PersistenceOptions struct {
+	// Dir holds the snapshot and the log. Only one process may use it at a time.
+	Dir          string
+	Sync         SyncPolicy
+	SyncInterval time.Duration
+	// SnapshotInterval is how often the log is compacted into a new snapshot. With
+	// 0 it only happens on Close.
+	SnapshotInterval time.Duration
+}
+
+var DefaultPersistenceOptions = PersistenceOptions{
+	Sync:             SyncAlways,
+	SyncInterval:     time.Second,
+	SnapshotInterval: 10 * time.Minute,
+}
+
+const (
+	snapshotName  = "snapshot.json"
+	segmentPrefix = "wal-"
+	segmentSuffix = ".log"
+)
+
+var errClosed = errors.New("in-memory database is closed")
+
+// OpenInMemoryDB loads the store saved in options.Dir, creating the directory if
+// needed, and logs every later change there. The log is a sequence of segments of
+// numbered records; a snapshot holds the state up to some record, and recovery
+// applies the records after it. Close the store to take a final snapshot.
+func OpenInMemoryDB(options PersistenceOptions) (*InMemoryDB, error) {
+	if _, err := ParseSyncPolicy(string(options.Sync)); err != nil {
+		return nil, err
+	}
+	if options.Sync == SyncInterval && options.SyncInterval <= 0 {
+		return nil, errors.New("the interval sync policy needs a positive sync interval")
+	}
+
+	if err := os.MkdirAll(options.Dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	segments, err := listSegments(options.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	db := NewInMemoryDB()
+	lastSeq, err := recoverState(options.Dir, db, segments, true)
+	if err != nil {
+		return nil, err
+	}
+
+	wal := &writeAheadLog{dir: options.Dir, policy: options.Sync, stop: make(chan struct{}), done: make(chan struct{})}
+	if err := wal.openSegment(lastSeq + 1); err != nil {
+		return nil, err
+	}
+
+	db.wal = wal
+	go db.runPersistence(options)
+
+	return db, nil
+}
+
+// Compact writes a snapshot of everything logged so far and deletes the log
+// segments it covers. It builds the snapshot from the files, not from the live
+// store, so writes carry on meanwhile.
+func (db *InMemoryDB) Compact() error {
+	if db.wal == nil {
+		return nil
+	}
+
+	db.wal.compactMu.Lock()
+	defer db.wal.compactMu.Unlock()
+
+	current, err := db.wal.rotate()
+	if err != nil {
+		return err
+	}
+
+	segments, err := listSegments(db.wal.dir)
+	if err != nil {
+		return err
+	}
+
+	var sealed []segment
+	for _, s := range segments {
+		if s.start < current {
+			sealed = append(sealed, s)
+		}
+	}
+	if len(sealed) == 0 {
+		return nil
+	}
+
+	state := NewInMemoryDB()
+	lastSeq, err := recoverState(db.wal.dir, state, sealed, false)
+	if err != nil {
+		return err
+	}
+
+	if err := writeSnapshot(db.wal.dir, state, lastSeq); err != nil {
+		return err
+	}
+
+	for _, s := range sealed {
+		if err := os.Remove(s.path); err != nil {
+			return err
+		}
+	}
+	return syncDir(db.wal.dir)
+}
+
+// Close stops the background sync and snapshots, compacts the log and closes it.
+// Writes after Close fail. A store that was not opened with OpenInMemoryDB has
+// nothing to close.
+func (db *InMemoryDB) Close() error {
+	if db.wal == nil {
+		return nil
+	}
+
+	var err error
+	db.wal.closeOnce.Do(func() {
+		close(db.wal.stop)
+		<-db.wal.done
+
+		err = errors.Join(db.Compact(), db.wal.close())
+	})
+	return err
+}
+
+func (db *InMemoryDB) runPersistence(options PersistenceOptions) {
+	defer close(db.wal.done)
+
+	var syncTicks, snapshotTicks <-chan time.Time
+	if options.Sync == SyncInterval {
+		ticker := time.NewTicker(options.SyncInterval)
+		defer ticker.Stop()
+		syncTicks = ticker.C
+	}
+	if options.SnapshotInterval > 0 {
+		ticker := time.NewTicker(options.SnapshotInterval)
+		defer ticker.Stop()
+		snapshotTicks = ticker.C
+	}
+
+	for {
+		select {
+		case <-db.wal.stop:
+			return
+		case <-syncTicks:
+			if err := db.wal.sync(); err != nil {
+				slog.Error("Failed to sync the write-ahead log", "error", err)
+			}
+		case <-snapshotTicks:
+			if err := db.Compact(); err != nil {
+				slog.Error("Failed to compact the write-ahead log", "error", err)
+			}
+		}
+	}
+}
+
+// writeAheadLog appends records to the current segment, one per line as
+// "<crc32 of the JSON in hex> <JSON>". The checksum tells a record cut short by a
+// crash from a complete one.
+type writeAheadLog struct {
+	dir    string
+	policy SyncPolicy
+
+	mu      sync.Mutex
+	file    *os.File
+	start   uint64 // first sequence number of the current segment
+	nextSeq uint64
+	size    int64
+	dirty   bool
+	buf     bytes.Buffer
+
+	compactMu sync.Mutex
+	closeOnce sync.Once
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// append numbers and writes the records as one batch. On failure the segment is
+// cut back to where the batch began, so none of it is recovered.
+func (w *writeAheadLog) append(records []record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return errClosed
+	}
+
+	w.buf.Reset()
+	seq := w.nextSeq
+	for i, r := range records {
+		r.Seq = seq
+		seq++
+		if i == 0 {
+			r.Batch = len(records)
+		}
+
+		line, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&w.buf, "%08x %s\n", crc32.ChecksumIEEE(line), line)
+	}
+
+	n, err := w.file.Write(w.buf.Bytes())
+	if err == nil && w.policy == SyncAlways {
+		err = w.file.Sync()
+	}
+	if err != nil {
+		if truncateErr := w.file.Truncate(w.size); truncateErr != nil {
+			return errors.Join(err, truncateErr)
+		}
+		return err
+	}
+
+	w.nextSeq = seq
+	w.size += int64(n)
+	w.dirty = w.policy != SyncAlways
+	return nil
+}
+
+func (w *writeAheadLog) sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil || !w.dirty {
+		return nil
+	}
+
+	w.dirty = false
+	return w.file.Sync()
+}
+
+// rotate seals the current segment, unless it is empty, and starts a new one. It
+// returns the first sequence number of the segment now being written.
+func (w *writeAheadLog) rotate() (uint64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return 0, errClosed
+	}
+	if w.nextSeq == w.start {
+		return w.start, nil
+	}
+
+	if err := w.closeSegment(); err != nil {
+		return 0, err
+	}
+	if err := w.openSegment(w.nextSeq); err != nil {
+		return 0, err
+	}
+	return w.start, nil
+}
+
+func (w *writeAheadLog) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	return w.closeSegment()
+}
+
+func (w *writeAheadLog) closeSegment() error {
+	err := errors.Join(w.file.Sync(), w.file.Close())
+	w.file = nil
+	w.dirty = false
+	return err
+}
+
+// openSegment starts the segment whose first record is start. A segment by that
+// name can only exist empty, left by a run that wrote nothing.
+func (w *writeAheadLog) openSegment(start uint64) error {
+	file, err := os.OpenFile(filepath.Join(w.dir, segmentName(start)), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := syncDir(w.dir); err != nil {
+		file.Close()
+		return err
+	}
+
+	w.file = file
+	w.start = start
+	w.nextSeq = start
+	w.size = info.Size()
+	return nil
+}
+
+type segment struct {
+	path  string
+	start uint64
+}
+
+func segmentName(start uint64) string {
+	return fmt.Sprintf("%s%020d%s", segmentPrefix, start, segmentSuffix)
+}
+
+// listSegments returns the log segments in dir, oldest first.
+func listSegments(dir string) ([]segment, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []segment
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, segmentPrefix) || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+
+		start, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, segmentPrefix), segmentSuffix), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected log segment %s", name)
+		}
+		segments = append(segments, segment{path: filepath.Join(dir, name), start: start})
+	}
+
+	sort.Slice(segments, func(i, j int) bool { return segments[i].start < segments[j].start })
+	return segments, nil
+}
+
+// recoverState loads the snapshot in dir into db, then applies the records of the
+// segments that come after it, and returns the number of the last record applied.
+// With repair, a record cut short at the end of the last segment is truncated away;
+// any other damage is an error.
+func recoverState(dir string, db *InMemoryDB, segments []segment, repair bool) (uint64, error) {
+	lastSeq, err := readSnapshot(dir, db)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, s := range segments {
+		err := readSegment(s.path, repair && i == len(segments)-1, func(r record) error {
+			if r.Seq <= lastSeq {
+				return nil
+			}
+			if r.Seq != lastSeq+1 {
+				return fmt.Errorf("%s: expected record %d, found %d", s.path, lastSeq+1, r.Seq)
+			}
+
+			db.apply(r)
+			lastSeq = r.Seq
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return lastSeq, nil
+}
+
+// readSegment calls fn for every record of the complete batches in the segment.
+// Only the last batch can be incomplete, cut short by a crash; with repair it is
+// truncated away, otherwise it is an error.
+func readSegment(path string, repair bool, fn func(record) error) error {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var batch []record
+	var batchOffset, offset int64
+	for line := 1; ; line++ {
+		text, err := reader.ReadBytes('\n')
+		if len(text) == 0 && errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		r, decodeErr := decodeRecord(text)
+		if decodeErr == nil && len(batch) == 0 && r.Batch < 1 {
+			decodeErr = errors.New("record does not start a batch")
+		}
+		if decodeErr != nil {
+			if _, peekErr := reader.Peek(1); errors.Is(peekErr, io.EOF) {
+				break
+			}
+			return fmt.Errorf("%s:%d: %w", path, line, decodeErr)
+		}
+
+		if len(batch) == 0 {
+			batchOffset = offset
+		}
+		offset += int64(len(text))
+		batch = append(batch, r)
+		if len(batch) < batch[0].Batch {
+			continue
+		}
+
+		for _, r := range batch {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+		batch = batch[:0]
+		batchOffset = offset
+	}
+
+	if end, err := file.Seek(0, io.SeekEnd); err != nil || end == batchOffset {
+		return err
+	}
+	if !repair {
+		return fmt.Errorf("%s: incomplete batch at the end", path)
+	}
+
+	slog.Warn("Truncating an incomplete batch at the end of the write-ahead log", "path", path)
+	return file.Truncate(batchOffset)
+}
+
+func decodeRecord(text []byte) (record, error) {
+	if len(text) < 10 || text[len(text)-1] != '\n' || text[8] != ' ' {
+		return record{}, errors.New("incomplete record")
+	}
+
+	checksum, err := strconv.ParseUint(string(text[:8]), 16, 32)
+	if err != nil {
+		return record{}, errors.New("invalid checksum")
+	}
+
+	data := text[9 : len(text)-1]
+	if crc32.ChecksumIEEE(data) != uint32(checksum) {
+		return record{}, errors.New("checksum mismatch")
+	}
+
+	var r record
+	if err := json.Unmarshal(data, &r); err != nil {
+		return record{}, err
+	}
+	return r, nil
+}
+
+// savedState is the content of a snapshot: the whole store as of record Seq.
+type savedState struct {
+	Seq            uint64                              `json:"seq"`
+	Users          map[uuid.UUID]domain.User           `json:"users"`
+	Tweets         map[uuid.UUID][]domain.Tweet        `json:"tweets"`
+	Following      map[uuid.UUID][]uuid.UUID           `json:"following"`
+	Followers      map[uuid.UUID][]uuid.UUID           `json:"followers"`
+	Blocks         map[uuid.UUID][]uuid.UUID           `json:"blocks"`
+	Mutes          map[uuid.UUID][]uuid.UUID           `json:"mutes"`
+	FollowRequests map[uuid.UUID][]uuid.UUID           `json:"follow_requests"`
+	Notifications  map[uuid.UUID][]domain.Notification `json:"notifications"`
+	Outbox         []domain.Event                      `json:"outbox"`
+	Webhooks       []storedWebhook                     `json:"webhooks"`
+	Deliveries     []domain.WebhookDelivery            `json:"deliveries"`
+}
+
+// readSnapshot loads the snapshot in dir, if there is one, into db and returns the
+// number of the last record it includes.
+func readSnapshot(dir string, db *InMemoryDB) (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(dir, snapshotName))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var state savedState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return 0, fmt.Errorf("reading snapshot: %w", err)
+	}
+
+	for id, user := range state.Users {
+		db.users[id] = user
+	}
+	for authorID, tweets := range state.Tweets {
+		for _, tweet := range tweets {
+			db.tweets[tweet.ID] = tweet
+			db.tweetsByAuthor[authorID] = append(db.tweetsByAuthor[authorID], tweet.ID)
+		}
+	}
+	for id, followedIDs := range state.Following {
+		db.following[id] = followedIDs
+	}
+	for id, followerIDs := range state.Followers {
+		db.followers[id] = followerIDs
+	}
+	for from, targets := range state.Blocks {
+		for _, to := range targets {
+			addEdge(db.blocks, from, to)
+		}
+	}
+	for from, targets := range state.Mutes {
+		for _, to := range targets {
+			addEdge(db.mutes, from, to)
+		}
+	}
+	for id, requesterIDs := range state.FollowRequests {
+		db.followRequests[id] = requesterIDs
+	}
+	for id, notifications := range state.Notifications {
+		db.notifications[id] = notifications
+	}
+	db.outbox = state.Outbox
+	for _, webhook := range state.Webhooks {
+		stored := webhook.Webhook
+		stored.Secret = webhook.Secret
+		db.webhooks = append(db.webhooks, stored)
+	}
+	db.deliveries = state.Deliveries
+
+	return state.Seq, nil
+}
+
+// writeSnapshot saves db, which must not be in use, as the snapshot of the state
+// up to record seq. The file is replaced atomically.
+func writeSnapshot(dir string, db *InMemoryDB, seq uint64) error {
+	state := savedState{
+		Seq:            seq,
+		Users:          db.users,
+		Tweets:         make(map[uuid.UUID][]domain.Tweet, len(db.tweetsByAuthor)),
+		Following:      db.following,
+		Followers:      db.followers,
+		Blocks:         make(map[uuid.UUID][]uuid.UUID, len(db.blocks)),
+		Mutes:          make(map[uuid.UUID][]uuid.UUID, len(db.mutes)),
+		FollowRequests: db.followRequests,
+		Notifications:  db.notifications,
+		Outbox:         db.outbox,
+		Deliveries:     db.deliveries,
+	}
+	for authorID := range db.tweetsByAuthor {
+		state.Tweets[authorID] = db.tweetsBy(authorID)
+	}
+	for from := range db.blocks {
+		state.Blocks[from] = edgeTargets(db.blocks, from)
+	}
+	for from := range db.mutes {
+		state.Mutes[from] = edgeTargets(db.mutes, from)
+	}
+	for _, webhook := range db.webhooks {
+		state.Webhooks = append(state.Webhooks, storedWebhook{Webhook: webhook, Secret: webhook.Secret})
+	}
+
+	path := filepath.Join(dir, snapshotName)
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	err = json.NewEncoder(writer).Encode(state)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// syncDir makes file creations, renames and removals in dir durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
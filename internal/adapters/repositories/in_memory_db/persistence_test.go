@@ -0,0 +1,244 @@
+package in_memory_db
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func openTestDB(t *testing.T, dir string) *InMemoryDB {
+	options := DefaultPersistenceOptions
+	options.Dir = dir
+	options.SnapshotInterval = 0
+
+	db, err := OpenInMemoryDB(options)
+	assert.NoError(t, err)
+	return db
+}
+
+// populate makes one change of every kind the log records.
+func populate(t *testing.T, db *InMemoryDB) (domain.User, domain.User, domain.Webhook) {
+	ctx := context.Background()
+
+	alice, err := db.CreateUser(ctx, domain.User{Name: "alice", Email: "alice@example.com"}, domain.NewUserCreatedEvent(domain.User{}))
+	assert.NoError(t, err)
+	bob, err := db.CreateUser(ctx, domain.User{Name: "bob", Email: "bob@example.com"})
+	assert.NoError(t, err)
+	carol, err := db.CreateUser(ctx, domain.User{Name: "carol", Email: "carol@example.com"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, db.FollowUser(ctx, alice.ID, bob.ID))
+	_, err = db.CreateTweet(ctx, domain.Tweet{UserID: bob.ID, Message: "hello"})
+	assert.NoError(t, err)
+	assert.NoError(t, db.SetProtected(ctx, bob.ID, true))
+	assert.NoError(t, db.CreateFollowRequest(ctx, carol.ID, bob.ID))
+	assert.NoError(t, db.CreateFollowRequest(ctx, alice.ID, carol.ID))
+	assert.NoError(t, db.ApproveFollowRequest(ctx, carol.ID, bob.ID))
+	assert.NoError(t, db.BlockUser(ctx, carol.ID, alice.ID))
+	assert.NoError(t, db.MuteUser(ctx, alice.ID, carol.ID))
+
+	notification, err := db.CreateNotification(ctx, domain.Notification{UserID: bob.ID, ActorID: alice.ID, Type: domain.NotificationTypeFollow})
+	assert.NoError(t, err)
+	assert.NoError(t, db.MarkNotificationsRead(ctx, bob.ID, []uuid.UUID{notification.ID}))
+
+	webhook, err := db.CreateWebhook(ctx, domain.Webhook{URL: "https://example.com/hook", Events: domain.EventTypes, Secret: "s3cret"})
+	assert.NoError(t, err)
+	delivery := domain.WebhookDelivery{ID: uuid.New(), WebhookID: webhook.ID, EventID: uuid.New(), Payload: []byte(`{}`), Status: domain.WebhookDeliveryPending}
+	assert.NoError(t, db.CreateDelivery(ctx, delivery))
+	delivery.Attempts = 1
+	assert.NoError(t, db.UpdateDelivery(ctx, delivery))
+
+	return alice, bob, webhook
+}
+
+// assertSameState compares everything the store holds.
+func assertSameState(t *testing.T, want, got *InMemoryDB) {
+	assert.Equal(t, want.users, got.users)
+	assert.Equal(t, want.tweets, got.tweets)
+	assert.Equal(t, want.tweetsByAuthor, got.tweetsByAuthor)
+	assert.Equal(t, want.following, got.following)
+	assert.Equal(t, want.followers, got.followers)
+	assert.Equal(t, want.blocks, got.blocks)
+	assert.Equal(t, want.mutes, got.mutes)
+	assert.Equal(t, want.followRequests, got.followRequests)
+	assert.Equal(t, len(want.notifications), len(got.notifications))
+	for userID, notifications := range want.notifications {
+		assert.Len(t, got.notifications[userID], len(notifications))
+		for i := range notifications {
+			assert.True(t, notifications[i].CreatedAt.Equal(got.notifications[userID][i].CreatedAt))
+			got.notifications[userID][i].CreatedAt = notifications[i].CreatedAt
+		}
+		assert.Equal(t, notifications, got.notifications[userID])
+	}
+	assert.Equal(t, len(want.outbox), len(got.outbox))
+	for i := range want.outbox {
+		assert.Equal(t, want.outbox[i].ID, got.outbox[i].ID)
+		assert.JSONEq(t, string(want.outbox[i].Payload), string(got.outbox[i].Payload))
+	}
+	assert.ElementsMatch(t, want.webhooks, got.webhooks)
+	assert.ElementsMatch(t, want.deliveries, got.deliveries)
+}
+
+func TestOpenInMemoryDB_RecoversFromLog(t *testing.T) {
+	dir := t.TempDir()
+	db := openTestDB(t, dir)
+	populate(t, db)
+
+	// Without Close, as after a crash: everything comes from the log.
+	recovered := openTestDB(t, dir)
+	assertSameState(t, db, recovered)
+
+	webhooks, _ := recovered.GetWebhooks(context.Background())
+	assert.Equal(t, "s3cret", webhooks[0].Secret)
+}
+
+func TestOpenInMemoryDB_RecoversFromSnapshotAndLog(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db := openTestDB(t, dir)
+	alice, bob, webhook := populate(t, db)
+
+	assert.NoError(t, db.Compact())
+	segments, _ := listSegments(dir)
+	assert.Len(t, segments, 1)
+	assert.FileExists(t, filepath.Join(dir, snapshotName))
+
+	// Changes after the snapshot are replayed on top of it.
+	assert.NoError(t, db.UnmuteUser(ctx, alice.ID, bob.ID))
+	assert.ErrorIs(t, db.RejectFollowRequest(ctx, bob.ID, alice.ID), domain.ErrFollowRequestNotFound)
+	assert.NoError(t, db.DeleteWebhook(ctx, webhook.ID))
+	assert.NoError(t, db.MarkEventsDispatched(ctx, []uuid.UUID{db.outbox[0].ID}))
+	assert.NoError(t, db.MarkNotificationsRead(ctx, bob.ID, nil))
+
+	recovered := openTestDB(t, dir)
+	assertSameState(t, db, recovered)
+	assert.Empty(t, recovered.outbox)
+	assert.Empty(t, recovered.webhooks)
+
+	// Close compacts everything into the snapshot.
+	assert.NoError(t, recovered.Close())
+	assert.ErrorIs(t, recovered.MuteUser(ctx, alice.ID, bob.ID), errClosed)
+
+	reopened := openTestDB(t, dir)
+	assertSameState(t, db, reopened)
+	assert.NoError(t, reopened.Close())
+}
+
+func TestOpenInMemoryDB_UnitOfWorkIsLoggedOnCommit(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db := openTestDB(t, dir)
+
+	var committed domain.User
+	assert.NoError(t, db.Do(ctx, func(ctx context.Context) error {
+		var err error
+		committed, err = db.CreateUser(ctx, domain.User{Name: "committed", Email: "committed@example.com"}, domain.NewUserCreatedEvent(domain.User{}))
+		return err
+	}))
+
+	rollback := errors.New("rollback")
+	assert.ErrorIs(t, db.Do(ctx, func(ctx context.Context) error {
+		if _, err := db.CreateUser(ctx, domain.User{Name: "rolled back", Email: "rolled@example.com"}); err != nil {
+			return err
+		}
+		return rollback
+	}), rollback)
+
+	recovered := openTestDB(t, dir)
+	assert.Len(t, recovered.users, 1)
+	_, err := recovered.GetUser(ctx, committed.ID)
+	assert.NoError(t, err)
+	assert.Len(t, recovered.outbox, 1)
+}
+
+func TestOpenInMemoryDB_TruncatesIncompleteBatch(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db := openTestDB(t, dir)
+
+	user, err := db.CreateUser(ctx, domain.User{Name: "alice", Email: "alice@example.com"})
+	assert.NoError(t, err)
+	other, err := db.CreateUser(ctx, domain.User{Name: "bob", Email: "bob@example.com"})
+	assert.NoError(t, err)
+
+	segments, _ := listSegments(dir)
+	path := segments[len(segments)-1].path
+	complete, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	// A follow and its event are one batch of two records; keep the first and half
+	// of the second, as if the process died while writing it.
+	assert.NoError(t, db.FollowUser(ctx, user.ID, other.ID, domain.NewUserFollowedEvent(user.ID, other.ID)))
+	withFollow, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	firstRecordEnd := len(complete) + bytes.IndexByte(withFollow[len(complete):], '\n') + 1
+	assert.NoError(t, os.WriteFile(path, withFollow[:firstRecordEnd+20], 0o644))
+
+	recovered := openTestDB(t, dir)
+	gotUser, err := recovered.GetUser(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Zero(t, gotUser.FollowingCount)
+	gotOther, err := recovered.GetUser(ctx, other.ID)
+	assert.NoError(t, err)
+	assert.Zero(t, gotOther.FollowersCount)
+	assert.Empty(t, recovered.outbox)
+
+	truncated, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, complete, truncated)
+
+	// The log carries on after the repaired end.
+	assert.NoError(t, recovered.FollowUser(ctx, user.ID, other.ID))
+	reopened := openTestDB(t, dir)
+	assertSameState(t, recovered, reopened)
+}
+
+func TestOpenInMemoryDB_RejectsCorruptRecord(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db := openTestDB(t, dir)
+
+	_, err := db.CreateUser(ctx, domain.User{Name: "alice", Email: "alice@example.com"})
+	assert.NoError(t, err)
+	_, err = db.CreateUser(ctx, domain.User{Name: "bob", Email: "bob@example.com"})
+	assert.NoError(t, err)
+
+	segments, _ := listSegments(dir)
+	data, err := os.ReadFile(segments[0].path)
+	assert.NoError(t, err)
+	data[20] ^= 0xff
+	assert.NoError(t, os.WriteFile(segments[0].path, data, 0o644))
+
+	_, err = OpenInMemoryDB(PersistenceOptions{Dir: dir, Sync: SyncNever})
+	assert.ErrorContains(t, err, "checksum mismatch")
+}
+
+func TestOpenInMemoryDB_IntervalSync(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	_, err := OpenInMemoryDB(PersistenceOptions{Dir: dir, Sync: SyncInterval})
+	assert.EqualError(t, err, "the interval sync policy needs a positive sync interval")
+
+	db, err := OpenInMemoryDB(PersistenceOptions{Dir: dir, Sync: SyncInterval, SyncInterval: time.Millisecond, SnapshotInterval: time.Millisecond})
+	assert.NoError(t, err)
+	_, err = db.CreateUser(ctx, domain.User{Name: "alice", Email: "alice@example.com"})
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(filepath.Join(dir, snapshotName))
+		return err == nil
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, db.Close())
+
+	recovered := openTestDB(t, dir)
+	assertSameState(t, db, recovered)
+}
@@ -0,0 +1,192 @@
+package in_memory_db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+type op string
+
+// Every change to the store is one of these records. Writes log them before
+// applying them, and recovery applies the logged ones again in order, so both go
+// through apply.
+const (
+	opPutUser               op = "put_user"
+	opAddTweet              op = "add_tweet"
+	opFollow                op = "follow"
+	opUnfollow              op = "unfollow"
+	opBlock                 op = "block"
+	opUnblock               op = "unblock"
+	opMute                  op = "mute"
+	opUnmute                op = "unmute"
+	opAddFollowRequest      op = "add_follow_request"
+	opRemoveFollowRequest   op = "remove_follow_request"
+	opAddNotification       op = "add_notification"
+	opMarkNotificationsRead op = "mark_notifications_read"
+	opAddEvent              op = "add_event"
+	opDispatchEvents        op = "dispatch_events"
+	opFailEvent             op = "fail_event"
+	opCreateWebhook         op = "create_webhook"
+	opDeleteWebhook         op = "delete_webhook"
+	opCreateDelivery        op = "create_delivery"
+	opUpdateDelivery        op = "update_delivery"
+
+	// opSetFollowRequests replaced the whole list of requests of a user. Logs written
+	// before follow requests had records of their own still have it.
+	opSetFollowRequests op = "set_follow_requests"
+)
+
+// record is one change. Only the fields of its op are set.
+type record struct {
+	Seq uint64 `json:"seq"`
+	// Batch is set on the first record of a batch to the number of records in it.
+	// Recovery applies a batch whole or not at all.
+	Batch int `json:"batch,omitempty"`
+	Op    op  `json:"op"`
+	// User is the profile only; relationships and tweets have records of their own.
+	User         *domain.User            `json:"user,omitempty"`
+	Tweet        *domain.Tweet           `json:"tweet,omitempty"`
+	UserID       *uuid.UUID              `json:"user_id,omitempty"`
+	From         *uuid.UUID              `json:"from,omitempty"`
+	To           *uuid.UUID              `json:"to,omitempty"`
+	WebhookID    *uuid.UUID              `json:"webhook_id,omitempty"`
+	IDs          []uuid.UUID             `json:"ids,omitempty"`
+	Notification *domain.Notification    `json:"notification,omitempty"`
+	Event        *domain.Event           `json:"event,omitempty"`
+	Webhook      *storedWebhook          `json:"webhook,omitempty"`
+	Delivery     *domain.WebhookDelivery `json:"delivery,omitempty"`
+}
+
+// storedWebhook keeps the secret, which domain.Webhook leaves out of its JSON.
+type storedWebhook struct {
+	domain.Webhook
+	Secret string `json:"secret"`
+}
+
+func putUserRecord(user domain.User) record {
+	profile := profileOf(user)
+	return record{Op: opPutUser, User: &profile}
+}
+
+func edgeRecord(op op, from, to uuid.UUID) record {
+	return record{Op: op, From: &from, To: &to}
+}
+
+// write makes records durable, together with the events the change produced, then
+// applies them. Callers hold the locks apply needs. Inside a unit of work the records
+// are applied right away but logged only when it commits, and the events are held
+// back until then too.
+func (db *InMemoryDB) write(ctx context.Context, events []domain.Event, records ...record) error {
+	if tx, ok := ctx.Value(txKey{}).(*transaction); ok {
+		for _, r := range records {
+			undo, err := db.undo(r)
+			if err != nil {
+				return err
+			}
+			tx.undo = append(tx.undo, undo)
+			tx.records = append(tx.records, r)
+			db.apply(r)
+		}
+		tx.events = append(tx.events, events...)
+		return nil
+	}
+
+	if err := db.logChange(records, events); err != nil {
+		return err
+	}
+
+	db.apply(records...)
+	return nil
+}
+
+// logChange appends records and the events as one batch to the write-ahead log, if
+// there is one, and adds the events to the outbox.
+func (db *InMemoryDB) logChange(records []record, events []domain.Event) error {
+	if len(events) > 0 {
+		db.outboxMu.Lock()
+		defer db.outboxMu.Unlock()
+	}
+
+	if db.wal != nil {
+		batch := records[:len(records):len(records)]
+		for i := range events {
+			batch = append(batch, record{Op: opAddEvent, Event: &events[i]})
+		}
+		if err := db.wal.append(batch); err != nil {
+			return err
+		}
+	}
+
+	// The outbox lock is only held when there are events, so writes without any
+	// leave the outbox alone.
+	if len(events) > 0 {
+		db.outbox = append(db.outbox, events...)
+	}
+	return nil
+}
+
+// apply performs the records on the store. It takes no locks: callers hold mu, or
+// the lock of the outbox or the webhooks when the records touch those, and recovery
+// runs before the store is shared.
+func (db *InMemoryDB) apply(records ...record) {
+	for _, r := range records {
+		switch r.Op {
+		case opPutUser:
+			db.users[r.User.ID] = *r.User
+		case opAddTweet:
+			db.indexTweet(*r.Tweet)
+			db.tweets[r.Tweet.ID] = *r.Tweet
+		case opFollow:
+			// Logs may repeat a follow; an edge is only added once.
+			if containsID(db.following[*r.From], *r.To) {
+				continue
+			}
+			db.following[*r.From] = append(db.following[*r.From], *r.To)
+			db.followers[*r.To] = append(db.followers[*r.To], *r.From)
+		case opUnfollow:
+			unlink(db.following, *r.From, *r.To)
+			unlink(db.followers, *r.To, *r.From)
+		case opBlock:
+			addEdge(db.blocks, *r.From, *r.To)
+		case opUnblock:
+			delete(db.blocks[*r.From], *r.To)
+		case opMute:
+			addEdge(db.mutes, *r.From, *r.To)
+		case opUnmute:
+			delete(db.mutes[*r.From], *r.To)
+		case opAddFollowRequest:
+			db.followRequests[*r.To] = append(db.followRequests[*r.To], *r.From)
+		case opRemoveFollowRequest:
+			unlink(db.followRequests, *r.To, *r.From)
+		case opSetFollowRequests:
+			db.followRequests[*r.UserID] = r.IDs
+		case opAddNotification:
+			userID := r.Notification.UserID
+			db.notifications[userID] = append(db.notifications[userID], *r.Notification)
+		case opMarkNotificationsRead:
+			db.markNotificationsRead(*r.UserID, r.IDs)
+		case opAddEvent:
+			db.outbox = append(db.outbox, *r.Event)
+		case opDispatchEvents:
+			db.removeDispatched(r.IDs)
+		case opFailEvent:
+			db.replaceEvent(*r.Event)
+		case opCreateWebhook:
+			webhook := r.Webhook.Webhook
+			webhook.Secret = r.Webhook.Secret
+			db.webhooks = append(db.webhooks, webhook)
+		case opDeleteWebhook:
+			db.removeWebhook(*r.WebhookID)
+		case opCreateDelivery:
+			db.deliveries = append(db.deliveries, *r.Delivery)
+		case opUpdateDelivery:
+			for i, existing := range db.deliveries {
+				if existing.ID == r.Delivery.ID {
+					db.deliveries[i] = *r.Delivery
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package in_memory_db
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// BlockUser records the block and drops any follow edge between the two users, in both directions.
+func (db *InMemoryDB) BlockUser(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.checkUsers(userID, blockedID); err != nil {
+		return err
+	}
+
+	var records []record
+	if containsID(db.following[userID], blockedID) {
+		records = append(records, edgeRecord(opUnfollow, userID, blockedID))
+	}
+	if containsID(db.following[blockedID], userID) {
+		records = append(records, edgeRecord(opUnfollow, blockedID, userID))
+	}
+
+	return db.write(ctx, nil, append(records, edgeRecord(opBlock, userID, blockedID))...)
+}
+
+func (db *InMemoryDB) UnblockUser(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.checkUsers(userID); err != nil {
+		return err
+	}
+
+	return db.write(ctx, nil, edgeRecord(opUnblock, userID, blockedID))
+}
+
+func (db *InMemoryDB) IsBlocked(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) (bool, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	_, ok := db.blocks[userID][blockedID]
+	return ok, nil
+}
+
+func (db *InMemoryDB) GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return edgeTargets(db.blocks, userID), nil
+}
+
+func (db *InMemoryDB) MuteUser(ctx context.Context, userID uuid.UUID, mutedID uuid.UUID) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.checkUsers(userID, mutedID); err != nil {
+		return err
+	}
+
+	return db.write(ctx, nil, edgeRecord(opMute, userID, mutedID))
+}
+
+func (db *InMemoryDB) UnmuteUser(ctx context.Context, userID uuid.UUID, mutedID uuid.UUID) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.checkUsers(userID); err != nil {
+		return err
+	}
+
+	return db.write(ctx, nil, edgeRecord(opUnmute, userID, mutedID))
+}
+
+func (db *InMemoryDB) GetMutedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return edgeTargets(db.mutes, userID), nil
+}
+
+func addEdge(edges map[uuid.UUID]map[uuid.UUID]struct{}, from, to uuid.UUID) {
+	if edges[from] == nil {
+		edges[from] = make(map[uuid.UUID]struct{})
+	}
+
+	edges[from][to] = struct{}{}
+}
+
+func edgeTargets(edges map[uuid.UUID]map[uuid.UUID]struct{}, from uuid.UUID) []uuid.UUID {
+	targets := make([]uuid.UUID, 0, len(edges[from]))
+	for id := range edges[from] {
+		targets = append(targets, id)
+	}
+
+	return targets
+}
+
+// unlink removes id from the list index keeps under key.
+func unlink(index map[uuid.UUID][]uuid.UUID, key, id uuid.UUID) {
+	ids := slices.DeleteFunc(index[key], func(existing uuid.UUID) bool {
+		return existing == id
+	})
+
+	if len(ids) == 0 {
+		delete(index, key)
+		return
+	}
+	index[key] = ids
+}
@@ -0,0 +1,119 @@
+package in_memory_db
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestInMemoryDB_BlockUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(*InMemoryDB) (uuid.UUID, uuid.UUID)
+		wantErr    bool
+		checkState func(*testing.T, *InMemoryDB, uuid.UUID, uuid.UUID)
+	}{
+		{
+			name: "Block removes follow edges in both directions",
+			setup: func(db *InMemoryDB) (uuid.UUID, uuid.UUID) {
+				blocker, _ := db.CreateUser(context.Background(), domain.User{Name: "blocker", Email: "blocker@example.com"})
+				blocked, _ := db.CreateUser(context.Background(), domain.User{Name: "blocked", Email: "blocked@example.com"})
+				_ = db.FollowUser(context.Background(), blocker.ID, blocked.ID)
+				_ = db.FollowUser(context.Background(), blocked.ID, blocker.ID)
+				return blocker.ID, blocked.ID
+			},
+			wantErr: false,
+			checkState: func(t *testing.T, db *InMemoryDB, blockerID, blockedID uuid.UUID) {
+				following, err := db.IsFollowing(context.Background(), blockerID, blockedID)
+				assert.NoError(t, err)
+				assert.False(t, following)
+				following, err = db.IsFollowing(context.Background(), blockedID, blockerID)
+				assert.NoError(t, err)
+				assert.False(t, following)
+
+				blocker, err := db.GetUser(context.Background(), blockerID)
+				assert.NoError(t, err)
+				assert.Zero(t, blocker.FollowersCount)
+				assert.Zero(t, blocker.FollowingCount)
+
+				isBlocked, err := db.IsBlocked(context.Background(), blockerID, blockedID)
+				assert.NoError(t, err)
+				assert.True(t, isBlocked)
+
+				blockedIDs, err := db.GetBlockedUserIDs(context.Background(), blockerID)
+				assert.NoError(t, err)
+				assert.Equal(t, []uuid.UUID{blockedID}, blockedIDs)
+
+				assert.NoError(t, db.UnblockUser(context.Background(), blockerID, blockedID))
+				isBlocked, err = db.IsBlocked(context.Background(), blockerID, blockedID)
+				assert.NoError(t, err)
+				assert.False(t, isBlocked)
+			},
+		},
+		{
+			name: "Fail when blocked user doesn't exist",
+			setup: func(db *InMemoryDB) (uuid.UUID, uuid.UUID) {
+				blocker, _ := db.CreateUser(context.Background(), domain.User{Name: "blocker", Email: "blocker@example.com"})
+				return blocker.ID, uuid.New()
+			},
+			wantErr: true,
+			checkState: func(t *testing.T, db *InMemoryDB, blockerID, blockedID uuid.UUID) {
+				isBlocked, err := db.IsBlocked(context.Background(), blockerID, blockedID)
+				assert.NoError(t, err)
+				assert.False(t, isBlocked)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := NewInMemoryDB()
+			blockerID, blockedID := tt.setup(db)
+
+			err := db.BlockUser(context.Background(), blockerID, blockedID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.checkState != nil {
+				tt.checkState(t, db, blockerID, blockedID)
+			}
+		})
+	}
+}
+
+func TestInMemoryDB_MuteUser(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+
+	muter, _ := db.CreateUser(ctx, domain.User{Name: "muter", Email: "muter@example.com"})
+	muted, _ := db.CreateUser(ctx, domain.User{Name: "muted", Email: "muted@example.com"})
+	_ = db.FollowUser(ctx, muter.ID, muted.ID)
+
+	assert.NoError(t, db.MuteUser(ctx, muter.ID, muted.ID))
+
+	mutedIDs, err := db.GetMutedUserIDs(ctx, muter.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{muted.ID}, mutedIDs)
+
+	// Muting is one-way and keeps the follow edge
+	following, err := db.IsFollowing(ctx, muter.ID, muted.ID)
+	assert.NoError(t, err)
+	assert.True(t, following)
+
+	mutedIDs, err = db.GetMutedUserIDs(ctx, muted.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, mutedIDs)
+
+	assert.NoError(t, db.UnmuteUser(ctx, muter.ID, muted.ID))
+	mutedIDs, err = db.GetMutedUserIDs(ctx, muter.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, mutedIDs)
+
+	assert.Error(t, db.MuteUser(ctx, muter.ID, uuid.New()))
+}
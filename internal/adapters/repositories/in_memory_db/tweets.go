@@ -1,26 +1,92 @@
 package in_memory_db
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
+	"github.com/google/uuid"
 	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"slices"
+	"time"
 )
 
-func (db *InMemoryDB) CreateTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, error) {
-	userID := tweet.UserID
-	user, err := db.GetUser(ctx, userID)
-	if err != nil {
+func (db *InMemoryDB) CreateTweet(ctx context.Context, tweet domain.Tweet, events ...domain.Event) (domain.Tweet, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.checkUsers(tweet.UserID); err != nil {
 		return domain.Tweet{}, err
 	}
 
-	user.Tweets = append(user.Tweets, tweet)
+	if tweet.ID == uuid.Nil {
+		tweet.ID = uuid.New()
+	}
+	if tweet.CreatedAt.IsZero() {
+		tweet.CreatedAt = time.Now().UTC()
+	}
 
-	userBytes, err := json.Marshal(user)
-	if err != nil {
+	if err := db.write(ctx, events, record{Op: opAddTweet, Tweet: &tweet}); err != nil {
 		return domain.Tweet{}, err
 	}
 
-	db.data[userID] = userBytes
+	return tweet, nil
+}
+
+func (db *InMemoryDB) GetTweet(ctx context.Context, id uuid.UUID) (domain.Tweet, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	tweet, ok := db.tweets[id]
+	if !ok {
+		return domain.Tweet{}, domain.ErrTweetNotFound
+	}
 
 	return tweet, nil
 }
+
+func (db *InMemoryDB) GetUserTweets(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.Tweet, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.checkUsers(userID); err != nil {
+		return nil, err
+	}
+
+	// The index is oldest first; walk backwards for newest first.
+	ids := db.tweetsByAuthor[userID]
+	tweets := []domain.Tweet{}
+	for i := len(ids) - 1 - page.Offset; i >= 0 && len(tweets) < page.Limit; i-- {
+		tweets = append(tweets, db.tweets[ids[i]])
+	}
+
+	return tweets, nil
+}
+
+// tweetsBy returns the tweets of the author, oldest first.
+func (db *InMemoryDB) tweetsBy(authorID uuid.UUID) []domain.Tweet {
+	var tweets []domain.Tweet
+	for _, id := range db.tweetsByAuthor[authorID] {
+		tweets = append(tweets, db.tweets[id])
+	}
+
+	return tweets
+}
+
+// indexTweet adds the tweet to its author's index, which is ordered by creation
+// time and then ID, like the SQL backends sort tweets. New tweets are normally the
+// latest and are appended; an older one, e.g. from an import, is inserted in place.
+func (db *InMemoryDB) indexTweet(tweet domain.Tweet) {
+	ids := db.tweetsByAuthor[tweet.UserID]
+	i := len(ids)
+	for i > 0 && tweetBefore(tweet, db.tweets[ids[i-1]]) {
+		i--
+	}
+
+	db.tweetsByAuthor[tweet.UserID] = slices.Insert(ids, i, tweet.ID)
+}
+
+func tweetBefore(a, b domain.Tweet) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return bytes.Compare(a.ID[:], b.ID[:]) < 0
+}
@@ -2,11 +2,11 @@ package in_memory_db
 
 import (
 	"context"
-	"encoding/json"
 	"github.com/google/uuid"
 	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 const uuidMock = "77dae0ef-658c-44c6-803f-f849854a7033"
@@ -28,8 +28,7 @@ func TestInMemoryDB_CreateTweet(t *testing.T) {
 					Name:  "testuser",
 					Email: "test@example.com",
 				}
-				userBytes, _ := json.Marshal(user)
-				db.data[user.ID] = userBytes
+				db.users[user.ID] = user
 			},
 			tweet: domain.Tweet{
 				ID:      uuid.MustParse(uuidMock),
@@ -69,12 +68,40 @@ func TestInMemoryDB_CreateTweet(t *testing.T) {
 				assert.Equal(t, tt.tweet.ID, gotTweet.ID)
 				assert.Equal(t, tt.tweet.UserID, gotTweet.UserID)
 				assert.Equal(t, tt.tweet.Message, gotTweet.Message)
+				assert.False(t, gotTweet.CreatedAt.IsZero())
 
 				// Verify the tweet was actually stored in the user's tweets
-				user, err := db.GetUser(context.Background(), tt.tweet.UserID)
+				tweets, err := db.GetUserTweets(context.Background(), tt.tweet.UserID, domain.NewPagination(10, 0))
 				assert.NoError(t, err)
-				assert.Contains(t, user.Tweets, tt.tweet)
+				assert.Contains(t, tweets, gotTweet)
 			}
 		})
 	}
 }
+
+func TestInMemoryDB_GetUserTweets(t *testing.T) {
+	ctx := context.Background()
+	db := NewInMemoryDB()
+
+	alice, _ := db.CreateUser(ctx, domain.User{Name: "alice", Email: "alice@example.com"})
+	second, _ := db.CreateTweet(ctx, domain.Tweet{UserID: alice.ID, Message: "second", CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)})
+	third, _ := db.CreateTweet(ctx, domain.Tweet{UserID: alice.ID, Message: "third", CreatedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)})
+
+	// An imported tweet older than the stored ones still lands in order.
+	first := domain.Tweet{ID: uuid.New(), UserID: alice.ID, Message: "first", CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	_, err := db.ImportTweets(ctx, []domain.Tweet{first})
+	assert.NoError(t, err)
+
+	page, err := db.GetUserTweets(ctx, alice.ID, domain.NewPagination(2, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Tweet{third, second}, page)
+	page, err = db.GetUserTweets(ctx, alice.ID, domain.NewPagination(2, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Tweet{first}, page)
+
+	user, _ := db.GetUser(ctx, alice.ID)
+	assert.Equal(t, 3, user.TweetsCount)
+
+	_, err = db.GetUserTweets(ctx, uuid.New(), domain.NewPagination(2, 0))
+	assert.Error(t, err)
+}
@@ -0,0 +1,285 @@
+package in_memory_db
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+type txKey struct{}
+
+// transaction is the state of a unit of work: what undoes the changes made so far,
+// and the changes to log and the events to add to the outbox once it commits.
+type transaction struct {
+	undo    []func()
+	records []record
+	events  []domain.Event
+}
+
+// Do implements ports.UnitOfWork. Units of work are serialized, and each change
+// they make keeps what undoes it, so a rollback costs as much as the changes it
+// reverts rather than the size of the store. The changes of a unit of work are
+// logged in one batch when it commits.
+func (db *InMemoryDB) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	parent, nested := ctx.Value(txKey{}).(*transaction)
+	if !nested {
+		db.txMu.Lock()
+		defer db.txMu.Unlock()
+	}
+
+	tx := &transaction{}
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		tx.rollback()
+		return err
+	}
+
+	if nested {
+		parent.undo = append(parent.undo, tx.undo...)
+		parent.records = append(parent.records, tx.records...)
+		parent.events = append(parent.events, tx.events...)
+		return nil
+	}
+
+	if err := db.logChange(tx.records, tx.events); err != nil {
+		tx.rollback()
+		return err
+	}
+	return nil
+}
+
+func (tx *transaction) rollback() {
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		tx.undo[i]()
+	}
+}
+
+// undo returns what reverts r, which is about to be applied: it takes out exactly
+// what r adds and puts back exactly what r removes, so the changes made meanwhile
+// outside the unit of work stay. Like apply, it is called with the locks r needs
+// held; the function it returns takes them itself.
+func (db *InMemoryDB) undo(r record) (func(), error) {
+	switch r.Op {
+	case opPutUser:
+		return withLock(&db.mu, restoreEntry(db.users, r.User.ID)), nil
+	case opAddTweet:
+		restoreTweet := restoreEntry(db.tweets, r.Tweet.ID)
+		return withLock(&db.mu, func() {
+			restoreTweet()
+			removeID(db.tweetsByAuthor, r.Tweet.UserID, r.Tweet.ID)
+		}), nil
+	case opFollow:
+		if containsID(db.following[*r.From], *r.To) {
+			return func() {}, nil
+		}
+		return withLock(&db.mu, func() {
+			removeID(db.following, *r.From, *r.To)
+			removeID(db.followers, *r.To, *r.From)
+		}), nil
+	case opUnfollow:
+		following := extract(db.following[*r.From], equalTo(*r.To))
+		followers := extract(db.followers[*r.To], equalTo(*r.From))
+		return withLock(&db.mu, func() {
+			reinsertIDs(db.following, *r.From, following)
+			reinsertIDs(db.followers, *r.To, followers)
+		}), nil
+	case opBlock, opUnblock:
+		return withLock(&db.mu, restoreEdge(db.blocks, *r.From, *r.To)), nil
+	case opMute, opUnmute:
+		return withLock(&db.mu, restoreEdge(db.mutes, *r.From, *r.To)), nil
+	case opAddFollowRequest:
+		return withLock(&db.mu, func() {
+			removeID(db.followRequests, *r.To, *r.From)
+		}), nil
+	case opRemoveFollowRequest:
+		requests := extract(db.followRequests[*r.To], equalTo(*r.From))
+		return withLock(&db.mu, func() {
+			reinsertIDs(db.followRequests, *r.To, requests)
+		}), nil
+	case opSetFollowRequests:
+		return withLock(&db.mu, restoreEntry(db.followRequests, *r.UserID)), nil
+	case opAddNotification:
+		userID, id := r.Notification.UserID, r.Notification.ID
+		return withLock(&db.mu, func() {
+			notifications := slices.DeleteFunc(db.notifications[userID], func(notification domain.Notification) bool {
+				return notification.ID == id
+			})
+			if len(notifications) == 0 {
+				delete(db.notifications, userID)
+				return
+			}
+			db.notifications[userID] = notifications
+		}), nil
+	case opMarkNotificationsRead:
+		selected := selectNotifications(r.IDs)
+		marked := make(map[uuid.UUID]bool)
+		for _, notification := range db.notifications[*r.UserID] {
+			if !notification.Read && selected(notification) {
+				marked[notification.ID] = true
+			}
+		}
+		return withLock(&db.mu, func() {
+			notifications := db.notifications[*r.UserID]
+			for i, notification := range notifications {
+				if marked[notification.ID] {
+					notifications[i].Read = false
+				}
+			}
+		}), nil
+	case opAddEvent:
+		id := r.Event.ID
+		return withLock(&db.outboxMu, func() {
+			db.outbox = slices.DeleteFunc(db.outbox, func(event domain.Event) bool {
+				return event.ID == id
+			})
+		}), nil
+	case opDispatchEvents:
+		dispatched := extract(db.outbox, func(event domain.Event) bool {
+			return slices.Contains(r.IDs, event.ID)
+		})
+		return withLock(&db.outboxMu, func() {
+			db.outbox = reinsert(db.outbox, dispatched)
+		}), nil
+	case opFailEvent:
+		previous := extract(db.outbox, func(event domain.Event) bool {
+			return event.ID == r.Event.ID
+		})
+		return withLock(&db.outboxMu, func() {
+			for _, event := range previous {
+				db.replaceEvent(event.value)
+			}
+		}), nil
+	case opCreateWebhook:
+		id := r.Webhook.ID
+		return withLock(&db.webhooksMu, func() {
+			db.webhooks = slices.DeleteFunc(db.webhooks, func(webhook domain.Webhook) bool {
+				return webhook.ID == id
+			})
+		}), nil
+	case opDeleteWebhook:
+		id := *r.WebhookID
+		webhooks := extract(db.webhooks, func(webhook domain.Webhook) bool {
+			return webhook.ID == id
+		})
+		deliveries := extract(db.deliveries, func(delivery domain.WebhookDelivery) bool {
+			return delivery.WebhookID == id
+		})
+		return withLock(&db.webhooksMu, func() {
+			db.webhooks = reinsert(db.webhooks, webhooks)
+			db.deliveries = reinsert(db.deliveries, deliveries)
+		}), nil
+	case opCreateDelivery:
+		id := r.Delivery.ID
+		return withLock(&db.webhooksMu, func() {
+			db.deliveries = slices.DeleteFunc(db.deliveries, func(delivery domain.WebhookDelivery) bool {
+				return delivery.ID == id
+			})
+		}), nil
+	case opUpdateDelivery:
+		previous := extract(db.deliveries, func(delivery domain.WebhookDelivery) bool {
+			return delivery.ID == r.Delivery.ID
+		})
+		return withLock(&db.webhooksMu, func() {
+			for _, delivery := range previous {
+				db.apply(record{Op: opUpdateDelivery, Delivery: &delivery.value})
+			}
+		}), nil
+	}
+
+	return nil, fmt.Errorf("in_memory_db: no undo for op %q", r.Op)
+}
+
+// withLock returns undo made to hold mu while it runs.
+func withLock(mu sync.Locker, undo func()) func() {
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		undo()
+	}
+}
+
+// restoreEntry returns what sets m[key] back to its current value, or deletes it
+// if there is none.
+func restoreEntry[V any](m map[uuid.UUID]V, key uuid.UUID) func() {
+	value, ok := m[key]
+	return func() {
+		if ok {
+			m[key] = value
+		} else {
+			delete(m, key)
+		}
+	}
+}
+
+func restoreEdge(edges map[uuid.UUID]map[uuid.UUID]struct{}, from, to uuid.UUID) func() {
+	_, ok := edges[from][to]
+	return func() {
+		if ok {
+			addEdge(edges, from, to)
+		} else {
+			delete(edges[from], to)
+		}
+	}
+}
+
+// positioned is an element of a list with the index it has in it.
+type positioned[T any] struct {
+	index int
+	value T
+}
+
+// extract returns the elements of list that match, with their indexes, for a change
+// about to remove them.
+func extract[T any](list []T, match func(T) bool) []positioned[T] {
+	var extracted []positioned[T]
+	for i, value := range list {
+		if match(value) {
+			extracted = append(extracted, positioned[T]{index: i, value: value})
+		}
+	}
+	return extracted
+}
+
+// reinsert puts the extracted elements back where they were. Undos run in reverse,
+// so list is as the change that removed them left it, give or take what was written
+// meanwhile outside the unit of work.
+func reinsert[T any](list []T, extracted []positioned[T]) []T {
+	for _, e := range extracted {
+		list = slices.Insert(list, min(e.index, len(list)), e.value)
+	}
+	return list
+}
+
+func reinsertIDs(index map[uuid.UUID][]uuid.UUID, key uuid.UUID, extracted []positioned[uuid.UUID]) {
+	if len(extracted) > 0 {
+		index[key] = reinsert(index[key], extracted)
+	}
+}
+
+func equalTo(id uuid.UUID) func(uuid.UUID) bool {
+	return func(candidate uuid.UUID) bool {
+		return candidate == id
+	}
+}
+
+// removeID takes the last occurrence of id out of the list index keeps under key:
+// the one the change being undone added.
+func removeID(index map[uuid.UUID][]uuid.UUID, key, id uuid.UUID) {
+	ids := index[key]
+	for i := len(ids) - 1; i >= 0; i-- {
+		if ids[i] != id {
+			continue
+		}
+
+		if len(ids) == 1 {
+			delete(index, key)
+			return
+		}
+		index[key] = slices.Delete(ids, i, i+1)
+		return
+	}
+}
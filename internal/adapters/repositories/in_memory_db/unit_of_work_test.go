@@ -0,0 +1,257 @@
+package in_memory_db
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestInMemoryDB_UnitOfWork(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	user := domain.User{ID: uuid.New(), Name: "user", Email: "user@example.com"}
+	other := domain.User{ID: uuid.New(), Name: "other", Email: "other@example.com"}
+
+	// A failed unit of work leaves neither its writes nor its events behind.
+	err := db.Do(ctx, func(ctx context.Context) error {
+		if _, err := db.CreateUser(ctx, user, domain.NewUserCreatedEvent(user)); err != nil {
+			return err
+		}
+		if _, err := db.CreateUser(ctx, other, domain.NewUserCreatedEvent(other)); err != nil {
+			return err
+		}
+		if err := db.BlockUser(ctx, user.ID, other.ID); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+
+	_, err = db.GetUser(ctx, user.ID)
+	assert.Error(t, err)
+	blocked, _ := db.IsBlocked(ctx, user.ID, other.ID)
+	assert.False(t, blocked)
+	events, _ := db.GetPendingEvents(ctx, time.Now(), 10)
+	assert.Empty(t, events)
+
+	// A nested unit of work rolls back on its own; the outer one still commits.
+	userCreated := domain.NewUserCreatedEvent(user)
+	err = db.Do(ctx, func(ctx context.Context) error {
+		if _, err := db.CreateUser(ctx, user, userCreated); err != nil {
+			return err
+		}
+
+		nestedErr := db.Do(ctx, func(ctx context.Context) error {
+			if _, err := db.CreateUser(ctx, other, domain.NewUserCreatedEvent(other)); err != nil {
+				return err
+			}
+			return errFailed
+		})
+		assert.ErrorIs(t, nestedErr, errFailed)
+
+		// Events are only visible once the outermost unit of work commits.
+		events, _ := db.GetPendingEvents(ctx, time.Now(), 10)
+		assert.Empty(t, events)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	_, err = db.GetUser(ctx, user.ID)
+	assert.NoError(t, err)
+	_, err = db.GetUser(ctx, other.ID)
+	assert.Error(t, err)
+	events, _ = db.GetPendingEvents(ctx, time.Now(), 10)
+	assert.Equal(t, []domain.Event{userCreated}, events)
+}
+
+func TestInMemoryDB_UnitOfWorkRollbackRestoresIndexes(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	alice, _ := db.CreateUser(ctx, domain.User{Name: "alice", Email: "alice@example.com"})
+	bob, _ := db.CreateUser(ctx, domain.User{Name: "bob", Email: "bob@example.com"})
+	assert.NoError(t, db.FollowUser(ctx, alice.ID, bob.ID))
+	tweet, _ := db.CreateTweet(ctx, domain.Tweet{UserID: bob.ID, Message: "kept"})
+	notification, _ := db.CreateNotification(ctx, domain.Notification{UserID: bob.ID, ActorID: alice.ID, Type: domain.NotificationTypeFollow})
+
+	err := db.Do(ctx, func(ctx context.Context) error {
+		if _, err := db.CreateTweet(ctx, domain.Tweet{UserID: bob.ID, Message: "rolled back"}); err != nil {
+			return err
+		}
+		if err := db.FollowUser(ctx, bob.ID, alice.ID); err != nil {
+			return err
+		}
+		if err := db.BlockUser(ctx, alice.ID, bob.ID); err != nil {
+			return err
+		}
+		if err := db.SetProtected(ctx, bob.ID, true); err != nil {
+			return err
+		}
+		if err := db.MarkNotificationsRead(ctx, bob.ID, nil); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+
+	following, _ := db.GetFollowedUserIDs(ctx, alice.ID)
+	assert.Equal(t, []uuid.UUID{bob.ID}, following)
+	following, _ = db.GetFollowedUserIDs(ctx, bob.ID)
+	assert.Empty(t, following)
+	gotBob, _ := db.GetUser(ctx, bob.ID)
+	assert.Equal(t, domain.User{ID: bob.ID, Name: "bob", Email: "bob@example.com", FollowersCount: 1, TweetsCount: 1}, gotBob)
+	tweets, _ := db.GetUserTweets(ctx, bob.ID, domain.NewPagination(10, 0))
+	assert.Equal(t, []domain.Tweet{tweet}, tweets)
+	blocked, _ := db.IsBlocked(ctx, alice.ID, bob.ID)
+	assert.False(t, blocked)
+	notifications, _ := db.GetNotifications(ctx, bob.ID, domain.NewPagination(10, 0))
+	assert.Equal(t, []domain.Notification{notification}, notifications)
+}
+
+func TestInMemoryDB_UnitOfWorkRollbackKeepsOtherWrites(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	alice, _ := db.CreateUser(ctx, domain.User{Name: "alice", Email: "alice@example.com"})
+	bob, _ := db.CreateUser(ctx, domain.User{Name: "bob", Email: "bob@example.com"})
+
+	err := db.Do(ctx, func(txCtx context.Context) error {
+		if err := db.FollowUser(txCtx, alice.ID, bob.ID); err != nil {
+			return err
+		}
+		if err := db.CreateFollowRequest(txCtx, alice.ID, bob.ID); err != nil {
+			return err
+		}
+
+		// Written meanwhile outside the unit of work, as the webhook worker does.
+		if err := db.MuteUser(ctx, bob.ID, alice.ID); err != nil {
+			return err
+		}
+		if _, err := db.CreateNotification(ctx, domain.Notification{UserID: alice.ID, ActorID: bob.ID, Type: domain.NotificationTypeFollow}); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+
+	following, _ := db.IsFollowing(ctx, alice.ID, bob.ID)
+	assert.False(t, following)
+	requests, _ := db.GetFollowRequests(ctx, bob.ID, domain.NewPagination(10, 0))
+	assert.Empty(t, requests)
+	muted, _ := db.GetMutedUserIDs(ctx, bob.ID)
+	assert.Equal(t, []uuid.UUID{alice.ID}, muted)
+	notifications, _ := db.GetNotifications(ctx, alice.ID, domain.NewPagination(10, 0))
+	assert.Len(t, notifications, 1)
+}
+
+func TestInMemoryDB_UnitOfWorkRollbackKeepsOtherWritesToTheSameLists(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	alice, _ := db.CreateUser(ctx, domain.User{Name: "alice"})
+	bob, _ := db.CreateUser(ctx, domain.User{Name: "bob"})
+	carol, _ := db.CreateUser(ctx, domain.User{Name: "carol"})
+	assert.NoError(t, db.FollowUser(ctx, alice.ID, carol.ID))
+	assert.NoError(t, db.CreateFollowRequest(ctx, alice.ID, carol.ID))
+
+	var kept domain.Tweet
+	err := db.Do(ctx, func(txCtx context.Context) error {
+		if err := db.FollowUser(txCtx, alice.ID, bob.ID); err != nil {
+			return err
+		}
+		if _, err := db.CreateTweet(txCtx, domain.Tweet{UserID: bob.ID, Message: "rolled back"}); err != nil {
+			return err
+		}
+		if err := db.RejectFollowRequest(txCtx, alice.ID, carol.ID); err != nil {
+			return err
+		}
+
+		// The same lists, written meanwhile outside the unit of work.
+		if err := db.FollowUser(ctx, carol.ID, bob.ID); err != nil {
+			return err
+		}
+		var err error
+		if kept, err = db.CreateTweet(ctx, domain.Tweet{UserID: bob.ID, Message: "kept"}); err != nil {
+			return err
+		}
+		if err := db.CreateFollowRequest(ctx, bob.ID, carol.ID); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+
+	following, _ := db.GetFollowedUserIDs(ctx, alice.ID)
+	assert.Equal(t, []uuid.UUID{carol.ID}, following)
+	followers, _ := db.GetFollowers(ctx, bob.ID, domain.NewPagination(10, 0))
+	assert.Equal(t, []domain.User{{ID: carol.ID, Name: "carol", FollowersCount: 1, FollowingCount: 1}}, followers)
+	tweets, _ := db.GetUserTweets(ctx, bob.ID, domain.NewPagination(10, 0))
+	assert.Equal(t, []domain.Tweet{kept}, tweets)
+	requests, _ := db.GetFollowRequests(ctx, carol.ID, domain.NewPagination(10, 0))
+	assert.Equal(t, []uuid.UUID{alice.ID, bob.ID}, []uuid.UUID{requests[0].ID, requests[1].ID})
+}
+
+func TestInMemoryDB_UnitOfWorkUnknownOp(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+
+	err := db.Do(ctx, func(ctx context.Context) error {
+		return db.write(ctx, nil, record{Op: "unknown"})
+	})
+	assert.EqualError(t, err, `in_memory_db: no undo for op "unknown"`)
+}
+
+func TestInMemoryDB_ConcurrentReadsAndWrites(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	alice, _ := db.CreateUser(ctx, domain.User{Name: "alice"})
+	bob, _ := db.CreateUser(ctx, domain.User{Name: "bob"})
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			_, _ = db.CreateTweet(ctx, domain.Tweet{UserID: bob.ID, Message: "hello"})
+			_ = db.MuteUser(ctx, alice.ID, bob.ID)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		// Rollbacks undo their changes while the others write and read.
+		for i := 0; i < 50; i++ {
+			_ = db.Do(ctx, func(ctx context.Context) error {
+				if err := db.FollowUser(ctx, alice.ID, bob.ID); err != nil {
+					return err
+				}
+				return errFailed
+			})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			_, _ = db.GetUser(ctx, bob.ID)
+			_, _ = db.GetUserTimeline(ctx, alice.ID)
+			_, _ = db.GetFollowers(ctx, bob.ID, domain.NewPagination(10, 0))
+			_, _ = db.GetMutedUserIDs(ctx, alice.ID)
+		}
+	}()
+	wg.Wait()
+
+	gotBob, _ := db.GetUser(ctx, bob.ID)
+	assert.Equal(t, 0, gotBob.FollowersCount)
+	assert.Equal(t, 50, gotBob.TweetsCount)
+}
@@ -1,99 +1,206 @@
 package in_memory_db
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
+	"sort"
 
 	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
 )
 
-func (db *InMemoryDB) CreateUser(ctx context.Context, user domain.User) (domain.User, error) {
-	id := uuid.New()
-	user.ID = id
+func (db *InMemoryDB) CreateUser(ctx context.Context, user domain.User, events ...domain.Event) (domain.User, error) {
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
+	}
 
-	userBytes, err := json.Marshal(user)
-	if err != nil {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.write(ctx, events, putUserRecord(user)); err != nil {
 		return domain.User{}, err
 	}
 
-	db.data[user.ID] = userBytes
-
-	var createdUser domain.User
-	err = json.Unmarshal(userBytes, &createdUser)
-	return createdUser, err
+	return db.user(user.ID)
 }
 
 func (db *InMemoryDB) GetUser(ctx context.Context, id uuid.UUID) (domain.User, error) {
-	userBytes, ok := db.data[id]
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.user(id)
+}
+
+// user returns the profile, with the counters taken from the lengths of the
+// follow and tweet indexes.
+func (db *InMemoryDB) user(id uuid.UUID) (domain.User, error) {
+	user, ok := db.users[id]
 	if !ok {
 		return domain.User{}, fmt.Errorf("user with id %v not found", id)
 	}
 
-	var user domain.User
-	err := json.Unmarshal(userBytes, &user)
-	if err != nil {
-		return domain.User{}, err
-	}
+	user.FollowersCount = len(db.followers[id])
+	user.FollowingCount = len(db.following[id])
+	user.TweetsCount = len(db.tweetsByAuthor[id])
 
 	return user, nil
 }
 
-func (db *InMemoryDB) FollowUser(ctx context.Context, userID uuid.UUID, followedID uuid.UUID) error {
-	user, err := db.GetUser(ctx, userID)
-	if err != nil {
-		return err
-	}
+func (db *InMemoryDB) GetUsers(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
 
-	followedUser, err := db.GetUser(ctx, followedID)
-	if err != nil {
-		return err
+	users := make([]domain.User, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := db.users[id]; !ok {
+			continue
+		}
+
+		user, err := db.user(id)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
 	}
 
-	followedUser.Followers = append(user.Followers, userID)
-	user.Follwing = append(user.Follwing, followedID)
+	return users, nil
+}
 
-	userBytes, err := json.Marshal(user)
-	if err != nil {
-		return err
-	}
+func (db *InMemoryDB) FollowUser(ctx context.Context, userID uuid.UUID, followedID uuid.UUID, events ...domain.Event) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
 
-	followedUserBytes, err := json.Marshal(followedUser)
-	if err != nil {
+	if err := db.checkUsers(userID, followedID); err != nil {
 		return err
 	}
 
-	db.data[userID] = userBytes
-	db.data[followedID] = followedUserBytes
+	// Already following: nothing changed, so there is nothing to announce.
+	if containsID(db.following[userID], followedID) {
+		return nil
+	}
 
-	return nil
+	return db.write(ctx, events, edgeRecord(opFollow, userID, followedID))
 }
 
 func (db *InMemoryDB) GetUserTimeline(ctx context.Context, userID uuid.UUID) ([]domain.Tweet, error) {
-	followedUsers, err := db.GetFollowedUsers(ctx, userID)
-	if err != nil {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.checkUsers(userID); err != nil {
 		return nil, err
 	}
 
 	var userTimeline []domain.Tweet
-	for _, followedID := range followedUsers {
-		user, err := db.GetUser(ctx, followedID)
+	for _, followedID := range db.following[userID] {
+		userTimeline = append(userTimeline, db.tweetsBy(followedID)...)
+	}
+
+	return userTimeline, nil
+}
+
+func (db *InMemoryDB) GetFollowedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return db.GetFollowedUsers(ctx, userID)
+}
+
+func (db *InMemoryDB) GetFollowedUsers(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.checkUsers(userID); err != nil {
+		return nil, err
+	}
+
+	return append([]uuid.UUID(nil), db.following[userID]...), nil
+}
+
+func (db *InMemoryDB) GetFollowers(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.getUsersByName(db.followers[userID], page)
+}
+
+func (db *InMemoryDB) GetFollowing(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.getUsersByName(db.following[userID], page)
+}
+
+func (db *InMemoryDB) IsFollowing(ctx context.Context, userID uuid.UUID, followedID uuid.UUID) (bool, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.checkUsers(userID); err != nil {
+		return false, err
+	}
+
+	return containsID(db.following[userID], followedID), nil
+}
+
+// checkUsers returns the error GetUser gives for the first of ids that does not exist.
+func (db *InMemoryDB) checkUsers(ids ...uuid.UUID) error {
+	for _, id := range ids {
+		if _, ok := db.users[id]; !ok {
+			return fmt.Errorf("user with id %v not found", id)
+		}
+	}
+
+	return nil
+}
+
+// profileOf strips user down to what the users map stores.
+func profileOf(user domain.User) domain.User {
+	return domain.User{ID: user.ID, Name: user.Name, Email: user.Email, Protected: user.Protected}
+}
+
+func (db *InMemoryDB) getUsers(ids []uuid.UUID) ([]domain.User, error) {
+	users := make([]domain.User, 0, len(ids))
+	for _, id := range ids {
+		user, err := db.user(id)
 		if err != nil {
 			return nil, err
 		}
 
-		userTimeline = append(userTimeline, user.Tweets...)
+		users = append(users, user)
 	}
 
-	return userTimeline, nil
+	return users, nil
 }
 
-func (db *InMemoryDB) GetFollowedUsers(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
-	user, err := db.GetUser(ctx, userID)
+// getUsersByName returns the page of the users of ids ordered by name, then ID, as
+// the SQL adapters list them.
+func (db *InMemoryDB) getUsersByName(ids []uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	users, err := db.getUsers(ids)
 	if err != nil {
 		return nil, err
 	}
 
-	return user.Follwing, nil
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].Name != users[j].Name {
+			return users[i].Name < users[j].Name
+		}
+		return bytes.Compare(users[i].ID[:], users[j].ID[:]) < 0
+	})
+
+	if page.Offset >= len(users) {
+		return []domain.User{}, nil
+	}
+
+	return users[page.Offset:min(page.Offset+page.Limit, len(users))], nil
+}
+
+// paginateIDs returns the window of ids selected by page, preserving insertion order.
+func paginateIDs(ids []uuid.UUID, page domain.Pagination) []uuid.UUID {
+	if page.Offset >= len(ids) {
+		return nil
+	}
+
+	end := page.Offset + page.Limit
+	if end > len(ids) {
+		end = len(ids)
+	}
+
+	return ids[page.Offset:end]
 }
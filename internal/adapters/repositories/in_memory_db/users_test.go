@@ -2,7 +2,9 @@ package in_memory_db
 
 import (
 	"context"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/repositories/repositorytest"
 	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -129,13 +131,17 @@ func TestInMemoryDB_FollowUser(t *testing.T) {
 			},
 			wantErr: false,
 			checkState: func(t *testing.T, db *InMemoryDB, followerID, followingID uuid.UUID) {
+				isFollowing, err := db.IsFollowing(context.Background(), followerID, followingID)
+				assert.NoError(t, err)
+				assert.True(t, isFollowing)
+
 				follower, err := db.GetUser(context.Background(), followerID)
 				assert.NoError(t, err)
-				assert.Contains(t, follower.Follwing, followingID)
+				assert.Equal(t, 1, follower.FollowingCount)
 
 				following, err := db.GetUser(context.Background(), followingID)
 				assert.NoError(t, err)
-				assert.Contains(t, following.Followers, followerID)
+				assert.Equal(t, 1, following.FollowersCount)
 			},
 		},
 		{
@@ -152,7 +158,7 @@ func TestInMemoryDB_FollowUser(t *testing.T) {
 			checkState: func(t *testing.T, db *InMemoryDB, followerID, followingID uuid.UUID) {
 				following, err := db.GetUser(context.Background(), followingID)
 				assert.NoError(t, err)
-				assert.NotContains(t, following.Followers, followerID)
+				assert.Zero(t, following.FollowersCount)
 			},
 		},
 	}
@@ -337,3 +343,106 @@ func TestInMemoryDB_GetFollowedUsers(t *testing.T) {
 		})
 	}
 }
+
+func TestInMemoryDB_GetFollowersAndFollowing(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+
+	target, _ := db.CreateUser(ctx, domain.User{Name: "target", Email: "target@example.com"})
+	var followerIDs []uuid.UUID
+	for i := 0; i < 3; i++ {
+		follower, _ := db.CreateUser(ctx, domain.User{Name: fmt.Sprintf("follower-%d", i), Email: "follower@example.com"})
+		_ = db.FollowUser(ctx, follower.ID, target.ID)
+		followerIDs = append(followerIDs, follower.ID)
+	}
+
+	tests := []struct {
+		name        string
+		page        domain.Pagination
+		expectedIDs []uuid.UUID
+	}{
+		{
+			name:        "First page",
+			page:        domain.NewPagination(2, 0),
+			expectedIDs: followerIDs[:2],
+		},
+		{
+			name:        "Second page",
+			page:        domain.NewPagination(2, 2),
+			expectedIDs: followerIDs[2:],
+		},
+		{
+			name:        "Offset past the end",
+			page:        domain.NewPagination(2, 10),
+			expectedIDs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			followers, err := db.GetFollowers(ctx, target.ID, tt.page)
+			assert.NoError(t, err)
+
+			var gotIDs []uuid.UUID
+			for _, follower := range followers {
+				gotIDs = append(gotIDs, follower.ID)
+			}
+			assert.Equal(t, tt.expectedIDs, gotIDs)
+		})
+	}
+
+	following, err := db.GetFollowing(ctx, followerIDs[0], domain.NewPagination(0, 0))
+	assert.NoError(t, err)
+	assert.Len(t, following, 1)
+	assert.Equal(t, target.ID, following[0].ID)
+
+	followers, err := db.GetFollowers(ctx, uuid.New(), domain.NewPagination(0, 0))
+	assert.NoError(t, err)
+	assert.Empty(t, followers)
+}
+
+func TestInMemoryDB_FollowLists(t *testing.T) {
+	repositorytest.TestFollowLists(t, NewInMemoryDB())
+}
+
+func TestInMemoryDB_FollowTwice(t *testing.T) {
+	db := NewInMemoryDB()
+	repositorytest.TestFollowTwice(t, db, db)
+}
+
+func TestInMemoryDB_ReplaysARepeatedFollowOnce(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+
+	follower, _ := db.CreateUser(ctx, domain.User{Name: "follower"})
+	followed, _ := db.CreateUser(ctx, domain.User{Name: "followed"})
+
+	// A log written before follows were idempotent may repeat one.
+	db.apply(edgeRecord(opFollow, follower.ID, followed.ID), edgeRecord(opFollow, follower.ID, followed.ID))
+
+	got, err := db.GetUser(ctx, followed.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got.FollowersCount)
+	following, _ := db.GetFollowedUserIDs(ctx, follower.ID)
+	assert.Equal(t, []uuid.UUID{followed.ID}, following)
+}
+
+func TestInMemoryDB_IsFollowing(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+
+	follower, _ := db.CreateUser(ctx, domain.User{Name: "follower", Email: "follower@example.com"})
+	followed, _ := db.CreateUser(ctx, domain.User{Name: "followed", Email: "followed@example.com"})
+	_ = db.FollowUser(ctx, follower.ID, followed.ID)
+
+	following, err := db.IsFollowing(ctx, follower.ID, followed.ID)
+	assert.NoError(t, err)
+	assert.True(t, following)
+
+	following, err = db.IsFollowing(ctx, followed.ID, follower.ID)
+	assert.NoError(t, err)
+	assert.False(t, following)
+
+	_, err = db.IsFollowing(ctx, uuid.New(), followed.ID)
+	assert.Error(t, err)
+}
@@ -0,0 +1,155 @@
+package in_memory_db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+func (db *InMemoryDB) CreateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	db.webhooksMu.Lock()
+	defer db.webhooksMu.Unlock()
+
+	webhook.ID = uuid.New()
+	webhook.CreatedAt = time.Now().UTC()
+	if err := db.write(ctx, nil, record{Op: opCreateWebhook, Webhook: &storedWebhook{Webhook: webhook, Secret: webhook.Secret}}); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	return webhook, nil
+}
+
+func (db *InMemoryDB) GetWebhook(ctx context.Context, id uuid.UUID) (domain.Webhook, error) {
+	db.webhooksMu.Lock()
+	defer db.webhooksMu.Unlock()
+
+	for _, webhook := range db.webhooks {
+		if webhook.ID == id {
+			return webhook, nil
+		}
+	}
+
+	return domain.Webhook{}, domain.ErrWebhookNotFound
+}
+
+func (db *InMemoryDB) GetWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	db.webhooksMu.Lock()
+	defer db.webhooksMu.Unlock()
+
+	return append([]domain.Webhook{}, db.webhooks...), nil
+}
+
+func (db *InMemoryDB) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	db.webhooksMu.Lock()
+	defer db.webhooksMu.Unlock()
+
+	for _, webhook := range db.webhooks {
+		if webhook.ID == id {
+			return db.write(ctx, nil, record{Op: opDeleteWebhook, WebhookID: &id})
+		}
+	}
+
+	return domain.ErrWebhookNotFound
+}
+
+// removeWebhook drops the webhook and its deliveries.
+func (db *InMemoryDB) removeWebhook(id uuid.UUID) {
+	webhooks := db.webhooks[:0]
+	for _, webhook := range db.webhooks {
+		if webhook.ID != id {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	db.webhooks = webhooks
+
+	deliveries := db.deliveries[:0]
+	for _, delivery := range db.deliveries {
+		if delivery.WebhookID != id {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	db.deliveries = deliveries
+}
+
+func (db *InMemoryDB) CreateDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	db.webhooksMu.Lock()
+	defer db.webhooksMu.Unlock()
+
+	for _, existing := range db.deliveries {
+		if existing.WebhookID == delivery.WebhookID && existing.EventID == delivery.EventID {
+			return nil
+		}
+	}
+
+	return db.write(ctx, nil, record{Op: opCreateDelivery, Delivery: &delivery})
+}
+
+func (db *InMemoryDB) UpdateDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	db.webhooksMu.Lock()
+	defer db.webhooksMu.Unlock()
+
+	for _, existing := range db.deliveries {
+		if existing.ID == delivery.ID {
+			return db.write(ctx, nil, record{Op: opUpdateDelivery, Delivery: &delivery})
+		}
+	}
+
+	return domain.ErrWebhookDeliveryNotFound
+}
+
+func (db *InMemoryDB) GetDelivery(ctx context.Context, id uuid.UUID) (domain.WebhookDelivery, error) {
+	db.webhooksMu.Lock()
+	defer db.webhooksMu.Unlock()
+
+	for _, delivery := range db.deliveries {
+		if delivery.ID == id {
+			return delivery, nil
+		}
+	}
+
+	return domain.WebhookDelivery{}, domain.ErrWebhookDeliveryNotFound
+}
+
+func (db *InMemoryDB) GetDeliveries(ctx context.Context, webhookID uuid.UUID, status domain.WebhookDeliveryStatus, page domain.Pagination) ([]domain.WebhookDelivery, error) {
+	db.webhooksMu.Lock()
+	defer db.webhooksMu.Unlock()
+
+	// Deliveries are stored oldest first; walk backwards for newest first.
+	deliveries := []domain.WebhookDelivery{}
+	skipped := 0
+	for i := len(db.deliveries) - 1; i >= 0 && len(deliveries) < page.Limit; i-- {
+		delivery := db.deliveries[i]
+		if delivery.WebhookID != webhookID || (status != "" && delivery.Status != status) {
+			continue
+		}
+
+		if skipped < page.Offset {
+			skipped++
+			continue
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (db *InMemoryDB) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	db.webhooksMu.Lock()
+	defer db.webhooksMu.Unlock()
+
+	deliveries := []domain.WebhookDelivery{}
+	for _, delivery := range db.deliveries {
+		if len(deliveries) == limit {
+			break
+		}
+
+		if delivery.Status == domain.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	return deliveries, nil
+}
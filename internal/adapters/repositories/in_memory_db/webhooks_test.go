@@ -0,0 +1,62 @@
+package in_memory_db
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestInMemoryDB_Webhooks(t *testing.T) {
+	db := NewInMemoryDB()
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	webhook, err := db.CreateWebhook(ctx, domain.Webhook{URL: "https://example.com", Events: []domain.EventType{domain.EventTypeUserCreated}, Secret: "s3cret"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, webhook.ID)
+
+	got, err := db.GetWebhook(ctx, webhook.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, webhook, got)
+
+	_, err = db.GetWebhook(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+
+	eventID := uuid.New()
+	first := domain.WebhookDelivery{ID: uuid.New(), WebhookID: webhook.ID, EventID: eventID, Status: domain.WebhookDeliveryPending, NextAttemptAt: now}
+	assert.NoError(t, db.CreateDelivery(ctx, first))
+	// Same event for the same webhook: ignored.
+	assert.NoError(t, db.CreateDelivery(ctx, domain.WebhookDelivery{ID: uuid.New(), WebhookID: webhook.ID, EventID: eventID, Status: domain.WebhookDeliveryPending}))
+
+	later := domain.WebhookDelivery{ID: uuid.New(), WebhookID: webhook.ID, EventID: uuid.New(), Status: domain.WebhookDeliveryPending, NextAttemptAt: now.Add(time.Minute)}
+	assert.NoError(t, db.CreateDelivery(ctx, later))
+
+	due, err := db.GetDueDeliveries(ctx, now, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.WebhookDelivery{first}, due)
+
+	first.Status = domain.WebhookDeliveryDead
+	assert.NoError(t, db.UpdateDelivery(ctx, first))
+	assert.ErrorIs(t, db.UpdateDelivery(ctx, domain.WebhookDelivery{ID: uuid.New()}), domain.ErrWebhookDeliveryNotFound)
+
+	deliveries, err := db.GetDeliveries(ctx, webhook.ID, "", domain.NewPagination(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.WebhookDelivery{later, first}, deliveries)
+
+	deliveries, err = db.GetDeliveries(ctx, webhook.ID, domain.WebhookDeliveryDead, domain.NewPagination(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.WebhookDelivery{first}, deliveries)
+
+	deliveries, err = db.GetDeliveries(ctx, webhook.ID, "", domain.NewPagination(1, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.WebhookDelivery{first}, deliveries)
+
+	assert.NoError(t, db.DeleteWebhook(ctx, webhook.ID))
+	assert.ErrorIs(t, db.DeleteWebhook(ctx, webhook.ID), domain.ErrWebhookNotFound)
+
+	_, err = db.GetDelivery(ctx, first.ID)
+	assert.ErrorIs(t, err, domain.ErrWebhookDeliveryNotFound)
+}
@@ -0,0 +1,150 @@
+package postgre_db
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+type BulkPGRepository struct {
+	db *DB
+}
+
+func NewBulkRepository(db *DB) *BulkPGRepository {
+	return &BulkPGRepository{
+		db: db,
+	}
+}
+
+func (br *BulkPGRepository) ExportUsers(ctx context.Context, fn func(domain.User) error) error {
+	rows, err := br.db.conn(ctx).Query(ctx, "SELECT id, name, email, protected FROM users ORDER BY id")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Protected); err != nil {
+			return err
+		}
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (br *BulkPGRepository) ExportFollows(ctx context.Context, fn func(domain.Follow) error) error {
+	rows, err := br.db.conn(ctx).Query(ctx, "SELECT follower_id, user_id FROM followers ORDER BY follower_id, user_id")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var follow domain.Follow
+		if err := rows.Scan(&follow.FollowerID, &follow.FollowedID); err != nil {
+			return err
+		}
+		if err := fn(follow); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (br *BulkPGRepository) ExportTweets(ctx context.Context, fn func(domain.Tweet) error) error {
+	rows, err := br.db.conn(ctx).Query(ctx, "SELECT id, user_id, message, created_at FROM tweets ORDER BY created_at, id")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tweet domain.Tweet
+		if err := rows.Scan(&tweet.ID, &tweet.UserID, &tweet.Message, &tweet.CreatedAt); err != nil {
+			return err
+		}
+		if err := fn(tweet); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// The imports COPY the batch into a temporary table and move the new rows over with
+// a single INSERT ... SELECT, which skips conflicts and rows referencing unknown users.
+
+func (br *BulkPGRepository) ImportUsers(ctx context.Context, users []domain.User) (int, error) {
+	rows := make([][]any, len(users))
+	for i, user := range users {
+		rows[i] = []any{user.ID, user.Name, user.Email, user.Protected}
+	}
+
+	return br.copyAndInsert(ctx, "users", []string{"id", "name", "email", "protected"}, rows,
+		`INSERT INTO users (id, name, email, protected)
+		SELECT id, name, email, protected FROM import_users
+		ON CONFLICT (id) DO NOTHING`)
+}
+
+func (br *BulkPGRepository) ImportFollows(ctx context.Context, follows []domain.Follow) (int, error) {
+	rows := make([][]any, len(follows))
+	for i, follow := range follows {
+		rows[i] = []any{follow.FollowedID, follow.FollowerID}
+	}
+
+	return br.copyAndInsert(ctx, "followers", []string{"user_id", "follower_id"}, rows,
+		`INSERT INTO followers (user_id, follower_id)
+		SELECT i.user_id, i.follower_id FROM import_followers i
+		WHERE i.user_id <> i.follower_id
+			AND EXISTS (SELECT 1 FROM users WHERE id = i.user_id)
+			AND EXISTS (SELECT 1 FROM users WHERE id = i.follower_id)
+		ON CONFLICT DO NOTHING`)
+}
+
+func (br *BulkPGRepository) ImportTweets(ctx context.Context, tweets []domain.Tweet) (int, error) {
+	rows := make([][]any, len(tweets))
+	for i, tweet := range tweets {
+		rows[i] = []any{tweet.ID, tweet.UserID, tweet.Message, tweet.CreatedAt}
+	}
+
+	return br.copyAndInsert(ctx, "tweets", []string{"id", "user_id", "message", "created_at"}, rows,
+		`INSERT INTO tweets (id, user_id, message, created_at)
+		SELECT i.id, i.user_id, i.message, i.created_at FROM import_tweets i
+		WHERE EXISTS (SELECT 1 FROM users WHERE id = i.user_id)
+		ON CONFLICT (id) DO NOTHING`)
+}
+
+// copyAndInsert loads rows into import_<table>, shaped like table and dropped at
+// commit, then runs insert to move them into table.
+func (br *BulkPGRepository) copyAndInsert(ctx context.Context, table string, columns []string, rows [][]any, insert string) (int, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	var imported int64
+	err := pgx.BeginFunc(ctx, br.db.conn(ctx), func(tx pgx.Tx) error {
+		staging := "import_" + table
+		if _, err := tx.Exec(ctx, "CREATE TEMPORARY TABLE "+staging+" (LIKE "+table+" INCLUDING DEFAULTS) ON COMMIT DROP"); err != nil {
+			return err
+		}
+
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{staging}, columns, pgx.CopyFromRows(rows)); err != nil {
+			return err
+		}
+
+		result, err := tx.Exec(ctx, insert)
+		if err != nil {
+			return err
+		}
+
+		imported = result.RowsAffected()
+		return nil
+	})
+
+	return int(imported), err
+}
@@ -0,0 +1,70 @@
+package postgre_db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+func (ur *UsersPGRepository) SetProtected(ctx context.Context, userID uuid.UUID, protected bool) error {
+	result, err := ur.db.conn(ctx).Exec(ctx, "UPDATE users SET protected = $2 WHERE id = $1", userID, protected)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func (ur *UsersPGRepository) CreateFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID) error {
+	_, err := ur.db.conn(ctx).Exec(ctx, "INSERT INTO follow_requests (user_id, follower_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", userID, followerID)
+	return err
+}
+
+func (ur *UsersPGRepository) GetFollowRequests(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	return ur.queryUsers(ctx, `SELECT u.id, u.name, u.email, u.protected, u.followers_count, u.following_count, u.tweets_count FROM follow_requests fr
+		JOIN users u ON u.id = fr.follower_id
+		WHERE fr.user_id = $1
+		ORDER BY fr.created_at, u.id
+		LIMIT $2 OFFSET $3`, userID, page.Limit, page.Offset)
+}
+
+// ApproveFollowRequest turns the pending request into a follow edge in a single transaction.
+func (ur *UsersPGRepository) ApproveFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID, events ...domain.Event) error {
+	return pgx.BeginFunc(ctx, ur.db.conn(ctx), func(tx pgx.Tx) error {
+		if err := deleteFollowRequest(ctx, tx, followerID, userID); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(ctx, "INSERT INTO followers (follower_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", followerID, userID)
+		if err != nil {
+			return err
+		}
+
+		return insertEvents(ctx, tx, events)
+	})
+}
+
+func (ur *UsersPGRepository) RejectFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID) error {
+	return pgx.BeginFunc(ctx, ur.db.conn(ctx), func(tx pgx.Tx) error {
+		return deleteFollowRequest(ctx, tx, followerID, userID)
+	})
+}
+
+func deleteFollowRequest(ctx context.Context, tx pgx.Tx, followerID uuid.UUID, userID uuid.UUID) error {
+	result, err := tx.Exec(ctx, "DELETE FROM follow_requests WHERE user_id = $1 AND follower_id = $2", userID, followerID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrFollowRequestNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package postgre_db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+// NotificationsPGRepository implements ports.NotificationsRepository on top of postgres.
+type NotificationsPGRepository struct {
+	db *DB
+}
+
+// NewNotificationRepository creates a new notification repository instance
+func NewNotificationRepository(db *DB) *NotificationsPGRepository {
+	return &NotificationsPGRepository{
+		db,
+	}
+}
+
+func (nr *NotificationsPGRepository) CreateNotification(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	notification.ID = uuid.New()
+	notification.CreatedAt = time.Now().UTC()
+
+	_, err := nr.db.conn(ctx).Exec(ctx, "INSERT INTO notifications (id, user_id, actor_id, type, tweet_id, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		notification.ID, notification.UserID, notification.ActorID, notification.Type, notification.TweetID, notification.CreatedAt)
+	if err != nil {
+		return domain.Notification{}, err
+	}
+
+	return notification, nil
+}
+
+func (nr *NotificationsPGRepository) GetNotifications(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.Notification, error) {
+	notifications := []domain.Notification{}
+
+	rows, err := nr.db.conn(ctx).Query(ctx, `SELECT id, user_id, actor_id, type, tweet_id, read, created_at FROM notifications
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3`, userID, page.Limit, page.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var notification domain.Notification
+		if err := rows.Scan(&notification.ID, &notification.UserID, &notification.ActorID, &notification.Type, &notification.TweetID, &notification.Read, &notification.CreatedAt); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (nr *NotificationsPGRepository) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error) {
+	var unread int
+
+	err := nr.db.conn(ctx).QueryRow(ctx, "SELECT count(*) FROM notifications WHERE user_id = $1 AND NOT read", userID).Scan(&unread)
+	if err != nil {
+		return 0, err
+	}
+
+	return unread, nil
+}
+
+func (nr *NotificationsPGRepository) MarkNotificationsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		_, err := nr.db.conn(ctx).Exec(ctx, "UPDATE notifications SET read = TRUE WHERE user_id = $1 AND NOT read", userID)
+		return err
+	}
+
+	_, err := nr.db.conn(ctx).Exec(ctx, "UPDATE notifications SET read = TRUE WHERE user_id = $1 AND id = ANY($2)", userID, ids)
+	return err
+}
@@ -0,0 +1,74 @@
+package postgre_db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+// OutboxPGRepository implements ports.OutboxRepository on top of postgres.
+type OutboxPGRepository struct {
+	db *DB
+}
+
+// NewOutboxRepository creates a new outbox repository instance
+func NewOutboxRepository(db *DB) *OutboxPGRepository {
+	return &OutboxPGRepository{
+		db,
+	}
+}
+
+// insertEvents stores events in the outbox as part of the caller's transaction.
+func insertEvents(ctx context.Context, tx pgx.Tx, events []domain.Event) error {
+	for _, event := range events {
+		_, err := tx.Exec(ctx, "INSERT INTO outbox_events (id, type, payload, occurred_at, next_attempt_at) VALUES ($1, $2, $3, $4, $4)",
+			event.ID, event.Type, event.Payload, event.OccurredAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ob *OutboxPGRepository) GetPendingEvents(ctx context.Context, now time.Time, limit int) ([]domain.Event, error) {
+	events := []domain.Event{}
+
+	rows, err := ob.db.conn(ctx).Query(ctx, `SELECT id, type, payload, occurred_at, attempts, next_attempt_at FROM outbox_events
+		WHERE dispatched_at IS NULL AND dead_at IS NULL AND next_attempt_at <= $1
+		ORDER BY next_attempt_at, id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED`, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event domain.Event
+		if err := rows.Scan(&event.ID, &event.Type, &event.Payload, &event.OccurredAt, &event.Attempts, &event.NextAttemptAt); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (ob *OutboxPGRepository) MarkEventsDispatched(ctx context.Context, ids []uuid.UUID) error {
+	_, err := ob.db.conn(ctx).Exec(ctx, "UPDATE outbox_events SET dispatched_at = now() WHERE id = ANY($1)", ids)
+	return err
+}
+
+func (ob *OutboxPGRepository) MarkEventFailed(ctx context.Context, event domain.Event) error {
+	_, err := ob.db.conn(ctx).Exec(ctx, "UPDATE outbox_events SET attempts = $2, next_attempt_at = $3, dead_at = $4 WHERE id = $1",
+		event.ID, event.Attempts, event.NextAttemptAt, event.DeadAt)
+	return err
+}
@@ -14,11 +14,13 @@ type DB struct {
 // It takes a context and a connection string as parameters.
 // It returns a pointer to a DB instance and an error.
 // The error is returned if there was an issue parsing the connection string or creating the connection pool.
+// Every query the pool runs is traced.
 func NewDB(ctx context.Context, connString string) (*DB, error) {
 	poolConfig, err := pgxpool.ParseConfig(connString)
 	if err != nil {
 		return nil, err
 	}
+	poolConfig.ConnConfig.Tracer = queryTracer{}
 
 	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
@@ -27,3 +29,13 @@ func NewDB(ctx context.Context, connString string) (*DB, error) {
 
 	return &DB{connPool: pool}, nil
 }
+
+// Stat returns the current statistics of the connection pool.
+func (db *DB) Stat() *pgxpool.Stat {
+	return db.connPool.Stat()
+}
+
+// Ping checks that the pool can reach the database, acquiring a connection if it has none idle.
+func (db *DB) Ping(ctx context.Context) error {
+	return db.connPool.Ping(ctx)
+}
@@ -0,0 +1,79 @@
+package postgre_db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// BlockUser records the block and drops any follow edge between the two users, in both directions.
+func (ur *UsersPGRepository) BlockUser(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error {
+	return pgx.BeginFunc(ctx, ur.db.conn(ctx), func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, "INSERT INTO blocks (blocker_id, blocked_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", userID, blockedID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, "DELETE FROM followers WHERE (follower_id = $1 AND user_id = $2) OR (follower_id = $2 AND user_id = $1)", userID, blockedID)
+		return err
+	})
+}
+
+func (ur *UsersPGRepository) UnblockUser(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error {
+	_, err := ur.db.conn(ctx).Exec(ctx, "DELETE FROM blocks WHERE blocker_id = $1 AND blocked_id = $2", userID, blockedID)
+	return err
+}
+
+func (ur *UsersPGRepository) IsBlocked(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) (bool, error) {
+	var blocked bool
+
+	err := ur.db.conn(ctx).QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM blocks WHERE blocker_id = $1 AND blocked_id = $2)", userID, blockedID).Scan(&blocked)
+	if err != nil {
+		return false, err
+	}
+
+	return blocked, nil
+}
+
+func (ur *UsersPGRepository) GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return ur.queryIDs(ctx, "SELECT blocked_id FROM blocks WHERE blocker_id = $1", userID)
+}
+
+func (ur *UsersPGRepository) MuteUser(ctx context.Context, userID uuid.UUID, mutedID uuid.UUID) error {
+	_, err := ur.db.conn(ctx).Exec(ctx, "INSERT INTO mutes (muter_id, muted_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", userID, mutedID)
+	return err
+}
+
+func (ur *UsersPGRepository) UnmuteUser(ctx context.Context, userID uuid.UUID, mutedID uuid.UUID) error {
+	_, err := ur.db.conn(ctx).Exec(ctx, "DELETE FROM mutes WHERE muter_id = $1 AND muted_id = $2", userID, mutedID)
+	return err
+}
+
+func (ur *UsersPGRepository) GetMutedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return ur.queryIDs(ctx, "SELECT muted_id FROM mutes WHERE muter_id = $1", userID)
+}
+
+func (ur *UsersPGRepository) queryIDs(ctx context.Context, query string, args ...any) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+
+	rows, err := ur.db.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -2,10 +2,13 @@ package postgre_db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
-	"log"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/logging"
+	"time"
 )
 
 type TweetsPGRepository struct {
@@ -18,23 +21,71 @@ func NewTweetRepository(db *DB) *TweetsPGRepository {
 	}
 }
 
-func (tr *TweetsPGRepository) CreateTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, error) {
-	tweet.ID = uuid.New()
+func (tr *TweetsPGRepository) CreateTweet(ctx context.Context, tweet domain.Tweet, events ...domain.Event) (domain.Tweet, error) {
+	if tweet.ID == uuid.Nil {
+		tweet.ID = uuid.New()
+	}
+	if tweet.CreatedAt.IsZero() {
+		tweet.CreatedAt = time.Now().UTC()
+	}
+
+	err := pgx.BeginFunc(ctx, tr.db.conn(ctx), func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, "INSERT INTO tweets (id, user_id, message, created_at) VALUES ($1, $2, $3, $4)", tweet.ID, tweet.UserID, tweet.Message, tweet.CreatedAt)
+		if err != nil {
+			return err
+		}
 
-	result, err := tr.db.connPool.Exec(ctx, "INSERT INTO tweets (id, user_id, message) VALUES ($1, $2, $3)", tweet.ID, tweet.UserID, tweet.Message)
+		if rowsAffected := result.RowsAffected(); rowsAffected != 1 {
+			logging.FromContext(ctx).Warn("Unexpected number of rows affected", "expected", 1, "affected", rowsAffected)
+			return fmt.Errorf("expected to affect 1 row, affected %d", rowsAffected)
+		}
+
+		return insertEvents(ctx, tx, events)
+	})
 	if err != nil {
 		return domain.Tweet{}, err
 	}
 
-	rowsAffected := result.RowsAffected()
+	return tweet, nil
+}
+
+func (tr *TweetsPGRepository) GetTweet(ctx context.Context, id uuid.UUID) (domain.Tweet, error) {
+	var tweet domain.Tweet
+
+	err := tr.db.conn(ctx).QueryRow(ctx, "SELECT id, user_id, message, created_at FROM tweets WHERE id = $1", id).Scan(&tweet.ID, &tweet.UserID, &tweet.Message, &tweet.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Tweet{}, domain.ErrTweetNotFound
+	}
 	if err != nil {
 		return domain.Tweet{}, err
 	}
 
-	if rowsAffected != 1 {
-		log.Printf("Expected to affect 1 row, affected %d", rowsAffected)
-		return domain.Tweet{}, fmt.Errorf("expected to affect 1 row, affected %d", rowsAffected)
+	return tweet, nil
+}
+
+func (tr *TweetsPGRepository) GetUserTweets(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.Tweet, error) {
+	tweets := []domain.Tweet{}
+
+	rows, err := tr.db.conn(ctx).Query(ctx, `SELECT id, user_id, message, created_at FROM tweets
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3`, userID, page.Limit, page.Offset)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return tweet, nil
+	for rows.Next() {
+		var tweet domain.Tweet
+		if err := rows.Scan(&tweet.ID, &tweet.UserID, &tweet.Message, &tweet.CreatedAt); err != nil {
+			return nil, err
+		}
+		tweets = append(tweets, tweet)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tweets, nil
 }
@@ -0,0 +1,43 @@
+package postgre_db
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type txKey struct{}
+
+// querier is implemented by both the connection pool and a transaction, so the
+// repositories run their statements the same way inside and outside a unit of work.
+type querier interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// conn returns the transaction a unit of work bound to ctx, or the pool when there is none.
+// Beginning a transaction on it inside a unit of work creates a savepoint.
+func (db *DB) conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return db.connPool
+}
+
+type UnitOfWorkPG struct {
+	db *DB
+}
+
+func NewUnitOfWork(db *DB) *UnitOfWorkPG {
+	return &UnitOfWorkPG{
+		db: db,
+	}
+}
+
+func (uow *UnitOfWorkPG) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return pgx.BeginFunc(ctx, uow.db.conn(ctx), func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
@@ -2,9 +2,11 @@ package postgre_db
 
 import (
 	"context"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
-	"log"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/logging"
 )
 
 /**
@@ -22,21 +24,25 @@ func NewUserRepository(db *DB) *UsersPGRepository {
 	}
 }
 
-func (ur *UsersPGRepository) CreateUser(ctx context.Context, user domain.User) (domain.User, error) {
-	user.ID = uuid.New()
-
-	result, err := ur.db.connPool.Exec(ctx, "INSERT INTO users (id, name, email) VALUES ($1, $2, $3)", user.ID, user.Name, user.Email)
-	if err != nil {
-		return domain.User{}, err
+func (ur *UsersPGRepository) CreateUser(ctx context.Context, user domain.User, events ...domain.Event) (domain.User, error) {
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
 	}
 
-	rowsAffected := result.RowsAffected()
-	if err != nil {
-		return domain.User{}, err
-	}
+	err := pgx.BeginFunc(ctx, ur.db.conn(ctx), func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, "INSERT INTO users (id, name, email, protected) VALUES ($1, $2, $3, $4)", user.ID, user.Name, user.Email, user.Protected)
+		if err != nil {
+			return err
+		}
 
-	if rowsAffected != 1 {
-		log.Printf("Expected to affect 1 row, affected %d", rowsAffected)
+		if rowsAffected := result.RowsAffected(); rowsAffected != 1 {
+			logging.FromContext(ctx).Warn("Unexpected number of rows affected", "expected", 1, "affected", rowsAffected)
+			return fmt.Errorf("expected to affect 1 row, affected %d", rowsAffected)
+		}
+
+		return insertEvents(ctx, tx, events)
+	})
+	if err != nil {
 		return domain.User{}, err
 	}
 
@@ -46,42 +52,32 @@ func (ur *UsersPGRepository) CreateUser(ctx context.Context, user domain.User) (
 func (ur *UsersPGRepository) GetUser(ctx context.Context, id uuid.UUID) (domain.User, error) {
 	var user domain.User
 
-	err := ur.db.connPool.QueryRow(ctx, "SELECT id, name, email FROM users WHERE id = $1", id).Scan(&user.ID, &user.Name, &user.Email)
+	err := ur.db.conn(ctx).QueryRow(ctx, "SELECT id, name, email, protected, followers_count, following_count, tweets_count FROM users WHERE id = $1", id).
+		Scan(&user.ID, &user.Name, &user.Email, &user.Protected, &user.FollowersCount, &user.FollowingCount, &user.TweetsCount)
 	if err != nil {
 		return domain.User{}, err
 	}
 
-	// Get followers (people who follow this user)
-	followers, err := ur.GetFollowerIDs(ctx, id)
-	if err != nil {
-		return domain.User{}, err
-	}
-	user.Followers = followers
-
-	// Get following (people this user follows)
-	following, err := ur.GetFollowedUserIDs(ctx, id)
-	if err != nil {
-		return domain.User{}, err
-	}
-	user.Follwing = following
-
-	// Get user's tweets
-	tweets, err := ur.GetUserTweets(ctx, id)
-	if err != nil {
-		return domain.User{}, err
-	}
-	user.Tweets = tweets
-
 	return user, nil
 }
 
-func (ur *UsersPGRepository) FollowUser(ctx context.Context, userID uuid.UUID, followedID uuid.UUID) error {
-	_, err := ur.db.connPool.Exec(ctx, "INSERT INTO followers (follower_id, user_id) VALUES ($1, $2)", userID, followedID)
-	if err != nil {
-		return err
-	}
+func (ur *UsersPGRepository) GetUsers(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	return ur.queryUsers(ctx, "SELECT id, name, email, protected, followers_count, following_count, tweets_count FROM users WHERE id = ANY($1)", ids)
+}
 
-	return nil
+func (ur *UsersPGRepository) FollowUser(ctx context.Context, userID uuid.UUID, followedID uuid.UUID, events ...domain.Event) error {
+	return pgx.BeginFunc(ctx, ur.db.conn(ctx), func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, "INSERT INTO followers (follower_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", userID, followedID)
+		if err != nil {
+			return err
+		}
+
+		// Already following: nothing changed, so there is nothing to announce.
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+		return insertEvents(ctx, tx, events)
+	})
 }
 
 func (ur *UsersPGRepository) GetUserTimeline(ctx context.Context, userID uuid.UUID) ([]domain.Tweet, error) {
@@ -93,7 +89,7 @@ func (ur *UsersPGRepository) GetUserTimeline(ctx context.Context, userID uuid.UU
 	}
 
 	for _, followedUser := range followedUsers {
-		rows, err := ur.db.connPool.Query(ctx, "SELECT id, user_id, message, created_at FROM tweets WHERE user_id = $1", followedUser)
+		rows, err := ur.db.conn(ctx).Query(ctx, "SELECT id, user_id, message, created_at FROM tweets WHERE user_id = $1", followedUser)
 		if err != nil {
 			return nil, err
 		}
@@ -101,7 +97,7 @@ func (ur *UsersPGRepository) GetUserTimeline(ctx context.Context, userID uuid.UU
 
 		for rows.Next() {
 			var tweet domain.Tweet
-			if err := rows.Scan(&tweet.ID, &tweet.UserID, &tweet.Message); err != nil {
+			if err := rows.Scan(&tweet.ID, &tweet.UserID, &tweet.Message, &tweet.CreatedAt); err != nil {
 				return nil, err
 			}
 			userTimeLine = append(userTimeLine, tweet)
@@ -118,7 +114,7 @@ func (ur *UsersPGRepository) GetUserTimeline(ctx context.Context, userID uuid.UU
 func (ur *UsersPGRepository) GetFollowedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
 	var followedUserIDs []uuid.UUID
 
-	rows, err := ur.db.connPool.Query(ctx, "SELECT user_id FROM followers WHERE follower_id = $1", userID)
+	rows, err := ur.db.conn(ctx).Query(ctx, "SELECT user_id FROM followers WHERE follower_id = $1", userID)
 	if err != nil {
 		return nil, err
 	}
@@ -139,50 +135,53 @@ func (ur *UsersPGRepository) GetFollowedUserIDs(ctx context.Context, userID uuid
 	return followedUserIDs, nil
 }
 
-func (ur *UsersPGRepository) GetFollowerIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
-	var followerIDs []uuid.UUID
+func (ur *UsersPGRepository) GetFollowers(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	return ur.queryUsers(ctx, `SELECT u.id, u.name, u.email, u.protected, u.followers_count, u.following_count, u.tweets_count FROM followers f
+		JOIN users u ON u.id = f.follower_id
+		WHERE f.user_id = $1
+		ORDER BY u.name, u.id
+		LIMIT $2 OFFSET $3`, userID, page.Limit, page.Offset)
+}
 
-	rows, err := ur.db.connPool.Query(ctx, "SELECT follower_id FROM followers WHERE user_id = $1", userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+func (ur *UsersPGRepository) GetFollowing(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	return ur.queryUsers(ctx, `SELECT u.id, u.name, u.email, u.protected, u.followers_count, u.following_count, u.tweets_count FROM followers f
+		JOIN users u ON u.id = f.user_id
+		WHERE f.follower_id = $1
+		ORDER BY u.name, u.id
+		LIMIT $2 OFFSET $3`, userID, page.Limit, page.Offset)
+}
 
-	for rows.Next() {
-		var followerID uuid.UUID
-		if err := rows.Scan(&followerID); err != nil {
-			return nil, err
-		}
-		followerIDs = append(followerIDs, followerID)
-	}
+func (ur *UsersPGRepository) IsFollowing(ctx context.Context, userID uuid.UUID, followedID uuid.UUID) (bool, error) {
+	var following bool
 
-	if err := rows.Err(); err != nil {
-		return nil, err
+	err := ur.db.conn(ctx).QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM followers WHERE follower_id = $1 AND user_id = $2)", userID, followedID).Scan(&following)
+	if err != nil {
+		return false, err
 	}
 
-	return followerIDs, nil
+	return following, nil
 }
 
-func (ur *UsersPGRepository) GetUserTweets(ctx context.Context, userID uuid.UUID) ([]domain.Tweet, error) {
-	var tweets []domain.Tweet
+func (ur *UsersPGRepository) queryUsers(ctx context.Context, query string, args ...any) ([]domain.User, error) {
+	users := []domain.User{}
 
-	rows, err := ur.db.connPool.Query(ctx, "SELECT id, user_id, message FROM tweets WHERE user_id = $1", userID)
+	rows, err := ur.db.conn(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var tweet domain.Tweet
-		if err := rows.Scan(&tweet.ID, &tweet.UserID, &tweet.Message); err != nil {
+		var user domain.User
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Protected, &user.FollowersCount, &user.FollowingCount, &user.TweetsCount); err != nil {
 			return nil, err
 		}
-		tweets = append(tweets, tweet)
+		users = append(users, user)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return tweets, nil
+	return users, nil
 }
@@ -0,0 +1,45 @@
+package postgre_db
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/repositories/repositorytest"
+	"github.com/juanignaciorc/microbloggin-pltf/migrations"
+	"github.com/stretchr/testify/assert"
+)
+
+// openTestDB returns the migrated database TEST_DATABASE_URL points to, skipping
+// the test when it is not set. Tests share it, so they create their own users.
+func openTestDB(t *testing.T) *DB {
+	connString := os.Getenv("TEST_DATABASE_URL")
+	if connString == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	db, err := NewDB(ctx, connString)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(db.connPool.Close)
+
+	loaded, err := migrations.Load()
+	assert.NoError(t, err)
+	_, err = NewMigrator(db, loaded).Up(ctx)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	return db
+}
+
+func TestUsersPGRepository_FollowLists(t *testing.T) {
+	repositorytest.TestFollowLists(t, NewUserRepository(openTestDB(t)))
+}
+
+func TestUsersPGRepository_FollowTwice(t *testing.T) {
+	db := openTestDB(t)
+	repositorytest.TestFollowTwice(t, NewUserRepository(db), NewTweetRepository(db))
+}
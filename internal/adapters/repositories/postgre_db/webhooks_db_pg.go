@@ -0,0 +1,196 @@
+package postgre_db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+const webhookColumns = "id, url, events, include_protected, secret, created_at"
+
+const deliveryColumns = "id, webhook_id, event_id, event_type, payload, status, attempts, last_status_code, last_error, next_attempt_at, created_at, updated_at"
+
+// WebhooksPGRepository implements ports.WebhooksRepository on top of postgres.
+type WebhooksPGRepository struct {
+	db *DB
+}
+
+// NewWebhookRepository creates a new webhook repository instance
+func NewWebhookRepository(db *DB) *WebhooksPGRepository {
+	return &WebhooksPGRepository{
+		db,
+	}
+}
+
+func (wr *WebhooksPGRepository) CreateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	webhook.ID = uuid.New()
+	webhook.CreatedAt = time.Now().UTC()
+
+	_, err := wr.db.conn(ctx).Exec(ctx, "INSERT INTO webhooks ("+webhookColumns+") VALUES ($1, $2, $3, $4, $5, $6)",
+		webhook.ID, webhook.URL, eventTypesToStrings(webhook.Events), webhook.IncludeProtected, webhook.Secret, webhook.CreatedAt)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	return webhook, nil
+}
+
+func (wr *WebhooksPGRepository) GetWebhook(ctx context.Context, id uuid.UUID) (domain.Webhook, error) {
+	webhook, err := scanWebhook(wr.db.conn(ctx).QueryRow(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE id = $1", id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Webhook{}, domain.ErrWebhookNotFound
+	}
+
+	return webhook, err
+}
+
+func (wr *WebhooksPGRepository) GetWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	webhooks := []domain.Webhook{}
+
+	rows, err := wr.db.conn(ctx).Query(ctx, "SELECT "+webhookColumns+" FROM webhooks ORDER BY created_at, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (wr *WebhooksPGRepository) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	// Deliveries go away through ON DELETE CASCADE.
+	result, err := wr.db.conn(ctx).Exec(ctx, "DELETE FROM webhooks WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (wr *WebhooksPGRepository) CreateDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	_, err := wr.db.conn(ctx).Exec(ctx, `INSERT INTO webhook_deliveries (`+deliveryColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING`,
+		delivery.ID, delivery.WebhookID, delivery.EventID, delivery.EventType, string(delivery.Payload), delivery.Status,
+		delivery.Attempts, delivery.LastStatusCode, delivery.LastError, delivery.NextAttemptAt, delivery.CreatedAt, delivery.UpdatedAt)
+	return err
+}
+
+func (wr *WebhooksPGRepository) UpdateDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	result, err := wr.db.conn(ctx).Exec(ctx, `UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, last_status_code = $4, last_error = $5, next_attempt_at = $6, updated_at = $7
+		WHERE id = $1`,
+		delivery.ID, delivery.Status, delivery.Attempts, delivery.LastStatusCode, delivery.LastError, delivery.NextAttemptAt, delivery.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrWebhookDeliveryNotFound
+	}
+
+	return nil
+}
+
+func (wr *WebhooksPGRepository) GetDelivery(ctx context.Context, id uuid.UUID) (domain.WebhookDelivery, error) {
+	delivery, err := scanDelivery(wr.db.conn(ctx).QueryRow(ctx, "SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE id = $1", id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.WebhookDelivery{}, domain.ErrWebhookDeliveryNotFound
+	}
+
+	return delivery, err
+}
+
+func (wr *WebhooksPGRepository) GetDeliveries(ctx context.Context, webhookID uuid.UUID, status domain.WebhookDeliveryStatus, page domain.Pagination) ([]domain.WebhookDelivery, error) {
+	return wr.queryDeliveries(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries
+		WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id
+		LIMIT $3 OFFSET $4`, webhookID, string(status), page.Limit, page.Offset)
+}
+
+func (wr *WebhooksPGRepository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	return wr.queryDeliveries(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries
+		WHERE status = $1 AND next_attempt_at <= $2
+		ORDER BY next_attempt_at, id
+		LIMIT $3`, domain.WebhookDeliveryPending, now, limit)
+}
+
+func (wr *WebhooksPGRepository) queryDeliveries(ctx context.Context, query string, args ...any) ([]domain.WebhookDelivery, error) {
+	deliveries := []domain.WebhookDelivery{}
+
+	rows, err := wr.db.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func scanWebhook(row pgx.Row) (domain.Webhook, error) {
+	var webhook domain.Webhook
+	var events []string
+
+	if err := row.Scan(&webhook.ID, &webhook.URL, &events, &webhook.IncludeProtected, &webhook.Secret, &webhook.CreatedAt); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	for _, event := range events {
+		webhook.Events = append(webhook.Events, domain.EventType(event))
+	}
+
+	return webhook, nil
+}
+
+func scanDelivery(row pgx.Row) (domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	var payload string
+
+	err := row.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType, &payload, &delivery.Status,
+		&delivery.Attempts, &delivery.LastStatusCode, &delivery.LastError, &delivery.NextAttemptAt, &delivery.CreatedAt, &delivery.UpdatedAt)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	delivery.Payload = []byte(payload)
+	return delivery, nil
+}
+
+func eventTypesToStrings(eventTypes []domain.EventType) []string {
+	values := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		values[i] = string(eventType)
+	}
+	return values
+}
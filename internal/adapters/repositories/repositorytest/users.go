@@ -0,0 +1,111 @@
+// Package repositorytest holds the tests every storage adapter must pass, so the
+// API behaves the same whichever one DATABASE_URL selects. Each adapter runs them
+// from its own tests against a fresh store.
+package repositorytest
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	ports "github.com/juanignaciorc/microbloggin-pltf/internal/ports/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFollowLists checks that GetFollowers and GetFollowing order users by name,
+// then ID, and give an empty list for an unknown user.
+func TestFollowLists(t *testing.T, users ports.UsersRepository) {
+	ctx := context.Background()
+
+	create := func(name string) domain.User {
+		id := uuid.New()
+		user, err := users.CreateUser(ctx, domain.User{ID: id, Name: name, Email: id.String() + "@example.com"})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return user
+	}
+
+	target := create("target")
+	var others []domain.User
+	for _, name := range []string{"carol", "alice", "bob", "alice"} {
+		other := create(name)
+		assert.NoError(t, users.FollowUser(ctx, other.ID, target.ID))
+		assert.NoError(t, users.FollowUser(ctx, target.ID, other.ID))
+		others = append(others, other)
+	}
+
+	sort.Slice(others, func(i, j int) bool {
+		if others[i].Name != others[j].Name {
+			return others[i].Name < others[j].Name
+		}
+		return bytes.Compare(others[i].ID[:], others[j].ID[:]) < 0
+	})
+	expectedIDs := idsOf(others)
+
+	lists := map[string]func(context.Context, uuid.UUID, domain.Pagination) ([]domain.User, error){
+		"Followers": users.GetFollowers,
+		"Following": users.GetFollowing,
+	}
+	for name, list := range lists {
+		t.Run(name, func(t *testing.T) {
+			got, err := list(ctx, target.ID, domain.NewPagination(10, 0))
+			assert.NoError(t, err)
+			assert.Equal(t, expectedIDs, idsOf(got))
+
+			got, err = list(ctx, target.ID, domain.NewPagination(2, 1))
+			assert.NoError(t, err)
+			assert.Equal(t, expectedIDs[1:3], idsOf(got))
+
+			got, err = list(ctx, target.ID, domain.NewPagination(2, 10))
+			assert.NoError(t, err)
+			assert.Empty(t, got)
+
+			got, err = list(ctx, uuid.New(), domain.NewPagination(10, 0))
+			assert.NoError(t, err)
+			assert.NotNil(t, got)
+			assert.Empty(t, got)
+		})
+	}
+}
+
+// TestFollowTwice checks that following an account already followed changes
+// nothing: the edge, the counters and the timeline stay as after the first follow.
+func TestFollowTwice(t *testing.T, users ports.UsersRepository, tweets ports.TweetRepository) {
+	ctx := context.Background()
+
+	follower, err := users.CreateUser(ctx, domain.User{ID: uuid.New(), Name: "follower", Email: "follower@example.com"})
+	assert.NoError(t, err)
+	followed, err := users.CreateUser(ctx, domain.User{ID: uuid.New(), Name: "followed", Email: "followed@example.com"})
+	assert.NoError(t, err)
+	tweet, err := tweets.CreateTweet(ctx, domain.Tweet{ID: uuid.New(), UserID: followed.ID, Message: "hello"})
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, users.FollowUser(ctx, follower.ID, followed.ID, domain.NewUserFollowedEvent(follower.ID, followed.ID)))
+	}
+
+	got, err := users.GetUser(ctx, followed.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got.FollowersCount)
+
+	followers, err := users.GetFollowers(ctx, followed.ID, domain.NewPagination(10, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{follower.ID}, idsOf(followers))
+
+	timeline, err := users.GetUserTimeline(ctx, follower.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{tweet.ID}, []uuid.UUID{timeline[0].ID})
+	assert.Len(t, timeline, 1)
+}
+
+func idsOf(users []domain.User) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	return ids
+}
@@ -0,0 +1,155 @@
+package sqlite_db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+// BulkSQLiteRepository implements ports.BulkRepository on top of SQLite.
+type BulkSQLiteRepository struct {
+	db *DB
+}
+
+func NewBulkRepository(db *DB) *BulkSQLiteRepository {
+	return &BulkSQLiteRepository{
+		db: db,
+	}
+}
+
+func (br *BulkSQLiteRepository) ExportUsers(ctx context.Context, fn func(domain.User) error) error {
+	rows, err := br.db.conn(ctx).QueryContext(ctx, "SELECT id, name, email, protected FROM users ORDER BY id")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Protected); err != nil {
+			return err
+		}
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (br *BulkSQLiteRepository) ExportFollows(ctx context.Context, fn func(domain.Follow) error) error {
+	rows, err := br.db.conn(ctx).QueryContext(ctx, "SELECT follower_id, user_id FROM followers ORDER BY follower_id, user_id")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var follow domain.Follow
+		if err := rows.Scan(&follow.FollowerID, &follow.FollowedID); err != nil {
+			return err
+		}
+		if err := fn(follow); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (br *BulkSQLiteRepository) ExportTweets(ctx context.Context, fn func(domain.Tweet) error) error {
+	rows, err := br.db.conn(ctx).QueryContext(ctx, "SELECT id, user_id, message, created_at FROM tweets ORDER BY created_at, id")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tweet domain.Tweet
+		if err := rows.Scan(&tweet.ID, &tweet.UserID, &tweet.Message, timestamp{&tweet.CreatedAt}); err != nil {
+			return err
+		}
+		if err := fn(tweet); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// The imports insert the batch row by row in one transaction. Each INSERT skips
+// conflicts and rows referencing unknown users, and only counts the rows it wrote.
+
+func (br *BulkSQLiteRepository) ImportUsers(ctx context.Context, users []domain.User) (int, error) {
+	rows := make([][]any, len(users))
+	for i, user := range users {
+		rows[i] = []any{user.ID, user.Name, user.Email, user.Protected}
+	}
+
+	return br.insertEach(ctx, rows, `INSERT INTO users (id, name, email, protected) VALUES (?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`)
+}
+
+func (br *BulkSQLiteRepository) ImportFollows(ctx context.Context, follows []domain.Follow) (int, error) {
+	rows := make([][]any, len(follows))
+	for i, follow := range follows {
+		rows[i] = []any{follow.FollowedID, follow.FollowerID}
+	}
+
+	return br.insertEach(ctx, rows, `INSERT INTO followers (user_id, follower_id)
+		SELECT ?1, ?2
+		WHERE ?1 <> ?2
+			AND EXISTS (SELECT 1 FROM users WHERE id = ?1)
+			AND EXISTS (SELECT 1 FROM users WHERE id = ?2)
+		ON CONFLICT DO NOTHING`)
+}
+
+func (br *BulkSQLiteRepository) ImportTweets(ctx context.Context, tweets []domain.Tweet) (int, error) {
+	rows := make([][]any, len(tweets))
+	for i, tweet := range tweets {
+		rows[i] = []any{tweet.ID, tweet.UserID, tweet.Message, formatTime(tweet.CreatedAt)}
+	}
+
+	return br.insertEach(ctx, rows, `INSERT INTO tweets (id, user_id, message, created_at)
+		SELECT ?1, ?2, ?3, ?4
+		WHERE EXISTS (SELECT 1 FROM users WHERE id = ?2)
+		ON CONFLICT (id) DO NOTHING`)
+}
+
+// insertEach runs insert once per row, with a single prepared statement, and
+// returns how many rows were written.
+func (br *BulkSQLiteRepository) insertEach(ctx context.Context, rows [][]any, insert string) (int, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	var imported int64
+	err := br.db.inTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, insert)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, row := range rows {
+			result, err := stmt.ExecContext(ctx, row...)
+			if err != nil {
+				return err
+			}
+
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			imported += rowsAffected
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(imported), nil
+}
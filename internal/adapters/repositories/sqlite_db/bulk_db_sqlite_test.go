@@ -0,0 +1,88 @@
+package sqlite_db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkSQLiteRepository_ImportIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	bulk := NewBulkRepository(db)
+	users := NewUserRepository(db)
+
+	alice := domain.User{ID: uuid.New(), Name: "alice", Email: "alice@example.com", Protected: true}
+	bob := domain.User{ID: uuid.New(), Name: "bob", Email: "bob@example.com"}
+	unknown := uuid.New()
+	tweet := domain.Tweet{ID: uuid.New(), UserID: alice.ID, Message: "hello", CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	for run := 0; run < 2; run++ {
+		want := 1
+		if run == 1 {
+			want = 0
+		}
+
+		imported, err := bulk.ImportUsers(ctx, []domain.User{alice, bob})
+		assert.NoError(t, err)
+		assert.Equal(t, 2*want, imported)
+
+		imported, err = bulk.ImportFollows(ctx, []domain.Follow{
+			{FollowerID: bob.ID, FollowedID: alice.ID},
+			{FollowerID: bob.ID, FollowedID: bob.ID},
+			{FollowerID: bob.ID, FollowedID: unknown},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, want, imported)
+
+		imported, err = bulk.ImportTweets(ctx, []domain.Tweet{tweet, {ID: uuid.New(), UserID: unknown, Message: "orphan"}})
+		assert.NoError(t, err)
+		assert.Equal(t, want, imported)
+	}
+
+	gotAlice, err := users.GetUser(ctx, alice.ID)
+	assert.NoError(t, err)
+	assert.True(t, gotAlice.Protected)
+	assert.Equal(t, 1, gotAlice.FollowersCount)
+	tweets, err := NewTweetRepository(db).GetUserTweets(ctx, alice.ID, domain.NewPagination(10, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Tweet{tweet}, tweets)
+}
+
+func TestBulkSQLiteRepository_Export(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	bulk := NewBulkRepository(db)
+	users := NewUserRepository(db)
+	tweets := NewTweetRepository(db)
+
+	alice := createUser(t, users, "alice")
+	bob := createUser(t, users, "bob")
+	assert.NoError(t, users.FollowUser(ctx, alice.ID, bob.ID))
+	later, _ := tweets.CreateTweet(ctx, domain.Tweet{UserID: bob.ID, Message: "second", CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)})
+	earlier, _ := tweets.CreateTweet(ctx, domain.Tweet{UserID: alice.ID, Message: "first", CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)})
+
+	var exportedUsers []domain.User
+	assert.NoError(t, bulk.ExportUsers(ctx, func(user domain.User) error {
+		exportedUsers = append(exportedUsers, user)
+		return nil
+	}))
+	var follows []domain.Follow
+	assert.NoError(t, bulk.ExportFollows(ctx, func(follow domain.Follow) error {
+		follows = append(follows, follow)
+		return nil
+	}))
+	var exportedTweets []domain.Tweet
+	assert.NoError(t, bulk.ExportTweets(ctx, func(tweet domain.Tweet) error {
+		exportedTweets = append(exportedTweets, tweet)
+		return nil
+	}))
+
+	assert.ElementsMatch(t, []domain.User{alice, bob}, exportedUsers)
+	assert.Equal(t, []domain.Follow{{FollowerID: alice.ID, FollowedID: bob.ID}}, follows)
+	assert.Equal(t, []domain.Tweet{earlier, later}, exportedTweets)
+}
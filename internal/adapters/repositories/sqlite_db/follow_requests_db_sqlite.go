@@ -0,0 +1,75 @@
+package sqlite_db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+func (ur *UsersSQLiteRepository) SetProtected(ctx context.Context, userID uuid.UUID, protected bool) error {
+	result, err := ur.db.conn(ctx).ExecContext(ctx, "UPDATE users SET protected = ? WHERE id = ?", protected, userID)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, err := result.RowsAffected(); err != nil || rowsAffected != 1 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (ur *UsersSQLiteRepository) CreateFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID) error {
+	_, err := ur.db.conn(ctx).ExecContext(ctx, "INSERT INTO follow_requests (user_id, follower_id, created_at) VALUES (?, ?, ?) ON CONFLICT DO NOTHING",
+		userID, followerID, formatTime(time.Now()))
+	return err
+}
+
+func (ur *UsersSQLiteRepository) GetFollowRequests(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	return ur.queryUsers(ctx, `SELECT u.id, u.name, u.email, u.protected, u.followers_count, u.following_count, u.tweets_count FROM follow_requests fr
+		JOIN users u ON u.id = fr.follower_id
+		WHERE fr.user_id = ?
+		ORDER BY fr.created_at, u.id
+		LIMIT ? OFFSET ?`, userID, page.Limit, page.Offset)
+}
+
+// ApproveFollowRequest turns the pending request into a follow edge in a single transaction.
+func (ur *UsersSQLiteRepository) ApproveFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID, events ...domain.Event) error {
+	return ur.db.inTx(ctx, func(tx *sql.Tx) error {
+		if err := deleteFollowRequest(ctx, tx, followerID, userID); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, "INSERT INTO followers (follower_id, user_id) VALUES (?, ?) ON CONFLICT DO NOTHING", followerID, userID)
+		if err != nil {
+			return err
+		}
+
+		return insertEvents(ctx, tx, events)
+	})
+}
+
+func (ur *UsersSQLiteRepository) RejectFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID) error {
+	return deleteFollowRequest(ctx, ur.db.conn(ctx), followerID, userID)
+}
+
+func deleteFollowRequest(ctx context.Context, q querier, followerID uuid.UUID, userID uuid.UUID) error {
+	result, err := q.ExecContext(ctx, "DELETE FROM follow_requests WHERE user_id = ? AND follower_id = ?", userID, followerID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrFollowRequestNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package sqlite_db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/juanignaciorc/microbloggin-pltf/migrations"
+)
+
+// Migrator applies the versioned migrations, recording them in the schema_migrations table.
+// Each migration runs in a transaction holding the database write lock, and checks
+// again inside it that it is still pending, so processes migrating the same file
+// at the same time apply each migration only once.
+type Migrator struct {
+	db         *DB
+	migrations []migrations.Migration
+}
+
+func NewMigrator(db *DB, migrations []migrations.Migration) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}
+}
+
+// Up applies every pending migration in version order, each in its own transaction,
+// and returns the ones it applied.
+func (m *Migrator) Up(ctx context.Context) ([]migrations.Migration, error) {
+	if err := m.createTable(ctx); err != nil {
+		return nil, err
+	}
+
+	var applied []migrations.Migration
+	for _, migration := range m.migrations {
+		ran := false
+		err := m.db.inTx(ctx, func(tx *sql.Tx) error {
+			if done, err := isApplied(ctx, tx, migration.Version); err != nil || done {
+				return err
+			}
+
+			if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+				migration.Version, migration.Name, formatTime(time.Now()))
+			ran = err == nil
+			return err
+		})
+		if err != nil {
+			return applied, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		if ran {
+			applied = append(applied, migration)
+		}
+	}
+
+	return applied, nil
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns the ones it reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]migrations.Migration, error) {
+	if err := m.createTable(ctx); err != nil {
+		return nil, err
+	}
+
+	var reverted []migrations.Migration
+	for len(reverted) < steps {
+		var migration migrations.Migration
+		err := m.db.inTx(ctx, func(tx *sql.Tx) error {
+			var version int64
+			err := tx.QueryRowContext(ctx, "SELECT version FROM schema_migrations ORDER BY version DESC LIMIT 1").Scan(&version)
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			var ok bool
+			if migration, ok = m.find(version); !ok {
+				return fmt.Errorf("applied migration %d is unknown to this build", version)
+			}
+
+			if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			_, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", migration.Version)
+			return err
+		})
+		if err != nil {
+			return reverted, err
+		}
+
+		// Nothing left to revert.
+		if migration.Version == 0 {
+			break
+		}
+		reverted = append(reverted, migration)
+	}
+
+	return reverted, nil
+}
+
+// Status lists every known migration in version order.
+func (m *Migrator) Status(ctx context.Context) ([]migrations.Status, error) {
+	if err := m.createTable(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.conn(ctx).QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, timestamp{&appliedAt}); err != nil {
+			return nil, err
+		}
+		done[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var statuses []migrations.Status
+	for _, migration := range m.migrations {
+		status := migrations.Status{Version: migration.Version, Name: migration.Name}
+		if appliedAt, ok := done[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Version returns the newest applied migration, or 0 when none is. Unlike Status
+// it does not create the schema_migrations table.
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	var exists bool
+	if err := m.db.conn(ctx).QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations')").Scan(&exists); err != nil || !exists {
+		return 0, err
+	}
+
+	var version int64
+	err := m.db.conn(ctx).QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
+	return version, err
+}
+
+// Latest returns the newest migration the migrator knows, or 0 when it knows none.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Force records the migrations up to version as applied and the later ones as not
+// applied, without running any SQL. It adopts a schema that already matches version.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	if _, ok := m.find(version); !ok && version != 0 {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+
+	if err := m.createTable(ctx); err != nil {
+		return err
+	}
+
+	return m.db.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version > ?", version); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?) ON CONFLICT DO NOTHING",
+				migration.Version, migration.Name, formatTime(time.Now()))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (m *Migrator) find(version int64) (migrations.Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return migrations.Migration{}, false
+}
+
+func (m *Migrator) createTable(ctx context.Context) error {
+	_, err := m.db.conn(ctx).ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TEXT NOT NULL
+	)`)
+	return err
+}
+
+func isApplied(ctx context.Context, tx *sql.Tx, version int64) (bool, error) {
+	var applied bool
+	err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = ?)", version).Scan(&applied)
+	return applied, err
+}
@@ -0,0 +1,87 @@
+package sqlite_db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+// NotificationsSQLiteRepository implements ports.NotificationsRepository on top of SQLite.
+type NotificationsSQLiteRepository struct {
+	db *DB
+}
+
+// NewNotificationRepository creates a new notification repository instance
+func NewNotificationRepository(db *DB) *NotificationsSQLiteRepository {
+	return &NotificationsSQLiteRepository{
+		db,
+	}
+}
+
+func (nr *NotificationsSQLiteRepository) CreateNotification(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	notification.ID = uuid.New()
+	notification.CreatedAt = time.Now().UTC()
+
+	_, err := nr.db.conn(ctx).ExecContext(ctx, "INSERT INTO notifications (id, user_id, actor_id, type, tweet_id, created_at) VALUES (?, ?, ?, ?, ?, ?)",
+		notification.ID, notification.UserID, notification.ActorID, notification.Type, notification.TweetID, formatTime(notification.CreatedAt))
+	if err != nil {
+		return domain.Notification{}, err
+	}
+
+	return notification, nil
+}
+
+func (nr *NotificationsSQLiteRepository) GetNotifications(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.Notification, error) {
+	notifications := []domain.Notification{}
+
+	rows, err := nr.db.conn(ctx).QueryContext(ctx, `SELECT id, user_id, actor_id, type, tweet_id, read, created_at FROM notifications
+		WHERE user_id = ?
+		ORDER BY created_at DESC, id
+		LIMIT ? OFFSET ?`, userID, page.Limit, page.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var notification domain.Notification
+		if err := rows.Scan(&notification.ID, &notification.UserID, &notification.ActorID, &notification.Type, &notification.TweetID, &notification.Read, timestamp{&notification.CreatedAt}); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (nr *NotificationsSQLiteRepository) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error) {
+	var unread int
+
+	err := nr.db.conn(ctx).QueryRowContext(ctx, "SELECT count(*) FROM notifications WHERE user_id = ? AND NOT read", userID).Scan(&unread)
+	if err != nil {
+		return 0, err
+	}
+
+	return unread, nil
+}
+
+func (nr *NotificationsSQLiteRepository) MarkNotificationsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		_, err := nr.db.conn(ctx).ExecContext(ctx, "UPDATE notifications SET read = TRUE WHERE user_id = ? AND NOT read", userID)
+		return err
+	}
+
+	args := []any{userID}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	_, err := nr.db.conn(ctx).ExecContext(ctx, "UPDATE notifications SET read = TRUE WHERE user_id = ? AND id IN ("+placeholders(len(ids))+")", args...)
+	return err
+}
@@ -0,0 +1,53 @@
+package sqlite_db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationsSQLiteRepository(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	users := NewUserRepository(db)
+	notifications := NewNotificationRepository(db)
+
+	alice := createUser(t, users, "alice")
+	bob := createUser(t, users, "bob")
+	tweet, err := NewTweetRepository(db).CreateTweet(ctx, domain.Tweet{UserID: bob.ID, Message: "hi @alice"})
+	assert.NoError(t, err)
+
+	follow, err := notifications.CreateNotification(ctx, domain.Notification{UserID: alice.ID, ActorID: bob.ID, Type: domain.NotificationTypeFollow})
+	assert.NoError(t, err)
+	mention, err := notifications.CreateNotification(ctx, domain.Notification{UserID: alice.ID, ActorID: bob.ID, Type: domain.NotificationTypeMention, TweetID: &tweet.ID})
+	assert.NoError(t, err)
+
+	// Newest first.
+	got, err := notifications.GetNotifications(ctx, alice.ID, domain.NewPagination(10, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Notification{mention, follow}, got)
+
+	unread, err := notifications.CountUnreadNotifications(ctx, alice.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, unread)
+
+	assert.NoError(t, notifications.MarkNotificationsRead(ctx, alice.ID, []uuid.UUID{follow.ID}))
+	unread, _ = notifications.CountUnreadNotifications(ctx, alice.ID)
+	assert.Equal(t, 1, unread)
+
+	// Only the owner's notifications are marked.
+	assert.NoError(t, notifications.MarkNotificationsRead(ctx, bob.ID, nil))
+	unread, _ = notifications.CountUnreadNotifications(ctx, alice.ID)
+	assert.Equal(t, 1, unread)
+
+	assert.NoError(t, notifications.MarkNotificationsRead(ctx, alice.ID, nil))
+	unread, _ = notifications.CountUnreadNotifications(ctx, alice.ID)
+	assert.Zero(t, unread)
+
+	got, err = notifications.GetNotifications(ctx, bob.ID, domain.NewPagination(10, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Notification{}, got)
+}
@@ -0,0 +1,92 @@
+package sqlite_db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+// OutboxSQLiteRepository implements ports.OutboxRepository on top of SQLite.
+type OutboxSQLiteRepository struct {
+	db *DB
+}
+
+// NewOutboxRepository creates a new outbox repository instance
+func NewOutboxRepository(db *DB) *OutboxSQLiteRepository {
+	return &OutboxSQLiteRepository{
+		db,
+	}
+}
+
+// insertEvents stores events in the outbox as part of the caller's transaction.
+func insertEvents(ctx context.Context, tx *sql.Tx, events []domain.Event) error {
+	for _, event := range events {
+		occurredAt := formatTime(event.OccurredAt)
+		_, err := tx.ExecContext(ctx, "INSERT INTO outbox_events (id, type, payload, occurred_at, next_attempt_at) VALUES (?, ?, ?, ?, ?)",
+			event.ID, event.Type, string(event.Payload), occurredAt, occurredAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetPendingEvents needs no row locks: units of work take the write lock when they
+// begin, so a second dispatcher waits for the first one to finish.
+func (ob *OutboxSQLiteRepository) GetPendingEvents(ctx context.Context, now time.Time, limit int) ([]domain.Event, error) {
+	events := []domain.Event{}
+
+	rows, err := ob.db.conn(ctx).QueryContext(ctx, `SELECT id, type, payload, occurred_at, attempts, next_attempt_at FROM outbox_events
+		WHERE dispatched_at IS NULL AND dead_at IS NULL AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, id
+		LIMIT ?`, formatTime(now), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event domain.Event
+		var payload string
+		if err := rows.Scan(&event.ID, &event.Type, &payload, timestamp{&event.OccurredAt}, &event.Attempts, timestamp{&event.NextAttemptAt}); err != nil {
+			return nil, err
+		}
+		event.Payload = []byte(payload)
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (ob *OutboxSQLiteRepository) MarkEventsDispatched(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	args := []any{formatTime(time.Now())}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	_, err := ob.db.conn(ctx).ExecContext(ctx, "UPDATE outbox_events SET dispatched_at = ? WHERE id IN ("+placeholders(len(ids))+")", args...)
+	return err
+}
+
+func (ob *OutboxSQLiteRepository) MarkEventFailed(ctx context.Context, event domain.Event) error {
+	var deadAt any
+	if event.DeadAt != nil {
+		deadAt = formatTime(*event.DeadAt)
+	}
+
+	_, err := ob.db.conn(ctx).ExecContext(ctx, "UPDATE outbox_events SET attempts = ?, next_attempt_at = ?, dead_at = ? WHERE id = ?",
+		event.Attempts, formatTime(event.NextAttemptAt), deadAt, event.ID)
+	return err
+}
@@ -0,0 +1,49 @@
+package sqlite_db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxSQLiteRepository_MarkEventFailed(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	users := NewUserRepository(db)
+	outbox := NewOutboxRepository(db)
+
+	var events []domain.Event
+	for _, name := range []string{"first", "second", "third"} {
+		user := domain.User{ID: uuid.New(), Name: name, Email: name + "@example.com"}
+		event := domain.NewUserCreatedEvent(user)
+		_, err := users.CreateUser(ctx, user, event)
+		assert.NoError(t, err)
+		events = append(events, event)
+	}
+	now := events[2].OccurredAt
+
+	retried := events[0]
+	retried.Attempts = 1
+	retried.NextAttemptAt = now.Add(time.Minute)
+	assert.NoError(t, outbox.MarkEventFailed(ctx, retried))
+
+	dead := events[1]
+	dead.Attempts = 3
+	dead.DeadAt = &now
+	assert.NoError(t, outbox.MarkEventFailed(ctx, dead))
+
+	pending, err := outbox.GetPendingEvents(ctx, now, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Event{events[2]}, pending)
+
+	// Once due, the retried event comes after the ones that were due before it.
+	pending, err = outbox.GetPendingEvents(ctx, now.Add(time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{events[2].ID, retried.ID}, []uuid.UUID{pending[0].ID, pending[1].ID})
+	assert.Equal(t, 1, pending[1].Attempts)
+	assert.True(t, retried.NextAttemptAt.Equal(pending[1].NextAttemptAt))
+}
@@ -0,0 +1,55 @@
+package sqlite_db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// BlockUser records the block and drops any follow edge between the two users, in both directions.
+func (ur *UsersSQLiteRepository) BlockUser(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error {
+	return ur.db.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO blocks (blocker_id, blocked_id) VALUES (?, ?) ON CONFLICT DO NOTHING", userID, blockedID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM followers WHERE (follower_id = ?1 AND user_id = ?2) OR (follower_id = ?2 AND user_id = ?1)", userID, blockedID)
+		return err
+	})
+}
+
+func (ur *UsersSQLiteRepository) UnblockUser(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error {
+	_, err := ur.db.conn(ctx).ExecContext(ctx, "DELETE FROM blocks WHERE blocker_id = ? AND blocked_id = ?", userID, blockedID)
+	return err
+}
+
+func (ur *UsersSQLiteRepository) IsBlocked(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) (bool, error) {
+	var blocked bool
+
+	err := ur.db.conn(ctx).QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM blocks WHERE blocker_id = ? AND blocked_id = ?)", userID, blockedID).Scan(&blocked)
+	if err != nil {
+		return false, err
+	}
+
+	return blocked, nil
+}
+
+func (ur *UsersSQLiteRepository) GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return ur.queryIDs(ctx, "SELECT blocked_id FROM blocks WHERE blocker_id = ?", userID)
+}
+
+func (ur *UsersSQLiteRepository) MuteUser(ctx context.Context, userID uuid.UUID, mutedID uuid.UUID) error {
+	_, err := ur.db.conn(ctx).ExecContext(ctx, "INSERT INTO mutes (muter_id, muted_id) VALUES (?, ?) ON CONFLICT DO NOTHING", userID, mutedID)
+	return err
+}
+
+func (ur *UsersSQLiteRepository) UnmuteUser(ctx context.Context, userID uuid.UUID, mutedID uuid.UUID) error {
+	_, err := ur.db.conn(ctx).ExecContext(ctx, "DELETE FROM mutes WHERE muter_id = ? AND muted_id = ?", userID, mutedID)
+	return err
+}
+
+func (ur *UsersSQLiteRepository) GetMutedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return ur.queryIDs(ctx, "SELECT muted_id FROM mutes WHERE muter_id = ?", userID)
+}
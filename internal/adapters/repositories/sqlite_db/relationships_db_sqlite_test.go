@@ -0,0 +1,96 @@
+package sqlite_db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsersSQLiteRepository_BlockAndMute(t *testing.T) {
+	ctx := context.Background()
+	users := NewUserRepository(openTestDB(t))
+
+	alice := createUser(t, users, "alice")
+	bob := createUser(t, users, "bob")
+	assert.NoError(t, users.FollowUser(ctx, alice.ID, bob.ID))
+	assert.NoError(t, users.FollowUser(ctx, bob.ID, alice.ID))
+
+	// Blocking drops the follows both ways and is idempotent.
+	assert.NoError(t, users.BlockUser(ctx, alice.ID, bob.ID))
+	assert.NoError(t, users.BlockUser(ctx, alice.ID, bob.ID))
+	blocked, err := users.IsBlocked(ctx, alice.ID, bob.ID)
+	assert.NoError(t, err)
+	assert.True(t, blocked)
+	blockedIDs, err := users.GetBlockedUserIDs(ctx, alice.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{bob.ID}, blockedIDs)
+	following, _ := users.GetFollowedUserIDs(ctx, alice.ID)
+	assert.Empty(t, following)
+	following, _ = users.GetFollowedUserIDs(ctx, bob.ID)
+	assert.Empty(t, following)
+
+	assert.NoError(t, users.UnblockUser(ctx, alice.ID, bob.ID))
+	blocked, err = users.IsBlocked(ctx, alice.ID, bob.ID)
+	assert.NoError(t, err)
+	assert.False(t, blocked)
+
+	assert.NoError(t, users.MuteUser(ctx, alice.ID, bob.ID))
+	assert.NoError(t, users.MuteUser(ctx, alice.ID, bob.ID))
+	mutedIDs, err := users.GetMutedUserIDs(ctx, alice.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{bob.ID}, mutedIDs)
+
+	assert.NoError(t, users.UnmuteUser(ctx, alice.ID, bob.ID))
+	mutedIDs, err = users.GetMutedUserIDs(ctx, alice.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, mutedIDs)
+}
+
+func TestUsersSQLiteRepository_FollowRequests(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	users := NewUserRepository(db)
+
+	alice := createUser(t, users, "alice")
+	bob := createUser(t, users, "bob")
+	carol := createUser(t, users, "carol")
+
+	assert.NoError(t, users.SetProtected(ctx, alice.ID, true))
+	assert.ErrorIs(t, users.SetProtected(ctx, uuid.New(), true), sql.ErrNoRows)
+	gotAlice, _ := users.GetUser(ctx, alice.ID)
+	assert.True(t, gotAlice.Protected)
+
+	// Requests are listed oldest first, and asking twice keeps a single request.
+	assert.NoError(t, users.CreateFollowRequest(ctx, carol.ID, alice.ID))
+	assert.NoError(t, users.CreateFollowRequest(ctx, bob.ID, alice.ID))
+	assert.NoError(t, users.CreateFollowRequest(ctx, carol.ID, alice.ID))
+	requests, err := users.GetFollowRequests(ctx, alice.ID, domain.NewPagination(10, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{carol.ID, bob.ID}, []uuid.UUID{requests[0].ID, requests[1].ID})
+	assert.Len(t, requests, 2)
+
+	followed := domain.NewUserFollowedEvent(carol.ID, alice.ID)
+	assert.NoError(t, users.ApproveFollowRequest(ctx, carol.ID, alice.ID, followed))
+	following, err := users.IsFollowing(ctx, carol.ID, alice.ID)
+	assert.NoError(t, err)
+	assert.True(t, following)
+	assert.ErrorIs(t, users.ApproveFollowRequest(ctx, carol.ID, alice.ID), domain.ErrFollowRequestNotFound)
+
+	assert.NoError(t, users.RejectFollowRequest(ctx, bob.ID, alice.ID))
+	assert.ErrorIs(t, users.RejectFollowRequest(ctx, bob.ID, alice.ID), domain.ErrFollowRequestNotFound)
+	following, err = users.IsFollowing(ctx, bob.ID, alice.ID)
+	assert.NoError(t, err)
+	assert.False(t, following)
+
+	requests, err = users.GetFollowRequests(ctx, alice.ID, domain.NewPagination(10, 0))
+	assert.NoError(t, err)
+	assert.Empty(t, requests)
+
+	events, _ := NewOutboxRepository(db).GetPendingEvents(ctx, time.Now(), 10)
+	assert.Equal(t, []domain.Event{followed}, events)
+}
@@ -0,0 +1,90 @@
+package sqlite_db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// timeLayout is how times are stored: UTC text with a fixed number of digits, so
+// comparing and ordering the text gives the same result as comparing the times.
+const timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// DB represents a SQLite database file opened in WAL mode.
+type DB struct {
+	connPool *sql.DB
+}
+
+// NewDB opens the SQLite database at path, creating the file if it does not exist.
+// Every connection has foreign keys on and waits for the write lock instead of
+// failing, and transactions take the write lock when they begin, so two of them
+// cannot deadlock upgrading from a read to a write.
+func NewDB(ctx context.Context, path string) (*DB, error) {
+	params := url.Values{}
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "synchronous(NORMAL)")
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Set("_txlock", "immediate")
+
+	conn, err := sql.Open("sqlite", "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.PingContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &DB{connPool: conn}, nil
+}
+
+// Close closes every connection to the database.
+func (db *DB) Close() error {
+	return db.connPool.Close()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// timestamp scans a time stored with formatTime into t.
+type timestamp struct {
+	t *time.Time
+}
+
+func (ts timestamp) Scan(src any) error {
+	var text string
+	switch value := src.(type) {
+	case string:
+		text = value
+	case []byte:
+		text = string(value)
+	default:
+		return fmt.Errorf("cannot scan %T into a time", src)
+	}
+
+	parsed, err := time.Parse(timeLayout, text)
+	if err != nil {
+		return err
+	}
+
+	*ts.t = parsed
+	return nil
+}
+
+// placeholders returns n comma-separated parameters, for an IN list.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// Ping checks that the database file can still be reached.
+func (db *DB) Ping(ctx context.Context) error {
+	return db.connPool.PingContext(ctx)
+}
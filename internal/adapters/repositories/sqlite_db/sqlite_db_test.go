@@ -0,0 +1,80 @@
+package sqlite_db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/juanignaciorc/microbloggin-pltf/migrations"
+	"github.com/stretchr/testify/assert"
+)
+
+// openTestDB returns a migrated database in a file removed after the test.
+func openTestDB(t *testing.T) *DB {
+	ctx := context.Background()
+
+	db, err := NewDB(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() { db.Close() })
+
+	loaded, err := migrations.LoadSQLite()
+	assert.NoError(t, err)
+	_, err = NewMigrator(db, loaded).Up(ctx)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	return db
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewDB(ctx, filepath.Join(t.TempDir(), "test.db"))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	loaded, err := migrations.LoadSQLite()
+	assert.NoError(t, err)
+	migrator := NewMigrator(db, loaded)
+
+	version, err := migrator.Version(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, version)
+
+	applied, err := migrator.Up(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, loaded, applied)
+
+	version, err = migrator.Version(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, migrator.Latest(), version)
+	assert.Equal(t, loaded[len(loaded)-1].Version, version)
+
+	applied, err = migrator.Up(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, applied)
+
+	// Every down migration undoes its up migration, down to an empty schema.
+	reverted, err := migrator.Down(ctx, len(loaded)+1)
+	assert.NoError(t, err)
+	assert.Len(t, reverted, len(loaded))
+	assert.Equal(t, loaded[len(loaded)-1].Version, reverted[0].Version)
+
+	var tables int
+	assert.NoError(t, db.connPool.QueryRowContext(ctx, "SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name <> 'schema_migrations'").Scan(&tables))
+	assert.Zero(t, tables)
+
+	// Force records versions without running them.
+	assert.NoError(t, migrator.Force(ctx, 2))
+	statuses, err := migrator.Status(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, len(loaded))
+	assert.NotNil(t, statuses[1].AppliedAt)
+	assert.Nil(t, statuses[2].AppliedAt)
+	version, err = migrator.Version(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+	assert.EqualError(t, migrator.Force(ctx, 999), "unknown migration version 999")
+}
@@ -0,0 +1,91 @@
+package sqlite_db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+// TweetsSQLiteRepository implements ports.TweetRepository on top of SQLite.
+type TweetsSQLiteRepository struct {
+	db *DB
+}
+
+// NewTweetRepository creates a new tweet repository instance
+func NewTweetRepository(db *DB) *TweetsSQLiteRepository {
+	return &TweetsSQLiteRepository{
+		db,
+	}
+}
+
+func (tr *TweetsSQLiteRepository) CreateTweet(ctx context.Context, tweet domain.Tweet, events ...domain.Event) (domain.Tweet, error) {
+	if tweet.ID == uuid.Nil {
+		tweet.ID = uuid.New()
+	}
+	if tweet.CreatedAt.IsZero() {
+		tweet.CreatedAt = time.Now().UTC()
+	}
+
+	err := tr.db.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO tweets (id, user_id, message, created_at) VALUES (?, ?, ?, ?)", tweet.ID, tweet.UserID, tweet.Message, formatTime(tweet.CreatedAt))
+		if err != nil {
+			return err
+		}
+
+		return insertEvents(ctx, tx, events)
+	})
+	if err != nil {
+		return domain.Tweet{}, err
+	}
+
+	return tweet, nil
+}
+
+func (tr *TweetsSQLiteRepository) GetTweet(ctx context.Context, id uuid.UUID) (domain.Tweet, error) {
+	var tweet domain.Tweet
+
+	err := tr.db.conn(ctx).QueryRowContext(ctx, "SELECT id, user_id, message, created_at FROM tweets WHERE id = ?", id).Scan(&tweet.ID, &tweet.UserID, &tweet.Message, timestamp{&tweet.CreatedAt})
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Tweet{}, domain.ErrTweetNotFound
+	}
+	if err != nil {
+		return domain.Tweet{}, err
+	}
+
+	return tweet, nil
+}
+
+func (tr *TweetsSQLiteRepository) GetUserTweets(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.Tweet, error) {
+	return queryTweets(ctx, tr.db.conn(ctx), `SELECT id, user_id, message, created_at FROM tweets
+		WHERE user_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?`, userID, page.Limit, page.Offset)
+}
+
+func queryTweets(ctx context.Context, q querier, query string, args ...any) ([]domain.Tweet, error) {
+	var tweets []domain.Tweet
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tweet domain.Tweet
+		if err := rows.Scan(&tweet.ID, &tweet.UserID, &tweet.Message, timestamp{&tweet.CreatedAt}); err != nil {
+			return nil, err
+		}
+		tweets = append(tweets, tweet)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tweets, nil
+}
@@ -0,0 +1,78 @@
+package sqlite_db
+
+import (
+	"context"
+	"database/sql"
+)
+
+type txKey struct{}
+
+// querier is implemented by both the connection pool and a transaction, so the
+// repositories run their statements the same way inside and outside a unit of work.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn returns the transaction a unit of work bound to ctx, or the pool when there is none.
+func (db *DB) conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db.connPool
+}
+
+// inTx runs fn in a new transaction, committed when fn returns nil and rolled back
+// otherwise. Inside a unit of work it uses a savepoint of its transaction instead.
+func (db *DB) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return savepoint(ctx, tx, fn)
+	}
+
+	tx, err := db.connPool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// savepoint runs fn inside a savepoint of tx. Savepoints with the same name nest,
+// so one name serves every level.
+func savepoint(ctx context.Context, tx *sql.Tx, fn func(tx *sql.Tx) error) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT nested"); err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		// ROLLBACK TO leaves the savepoint open; RELEASE closes it.
+		tx.ExecContext(ctx, "ROLLBACK TO nested")
+		tx.ExecContext(ctx, "RELEASE nested")
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, "RELEASE nested")
+	return err
+}
+
+type UnitOfWorkSQLite struct {
+	db *DB
+}
+
+func NewUnitOfWork(db *DB) *UnitOfWorkSQLite {
+	return &UnitOfWorkSQLite{
+		db: db,
+	}
+}
+
+func (uow *UnitOfWorkSQLite) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return uow.db.inTx(ctx, func(tx *sql.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
@@ -0,0 +1,74 @@
+package sqlite_db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitOfWorkSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	users := NewUserRepository(db)
+	outbox := NewOutboxRepository(db)
+	uow := NewUnitOfWork(db)
+	errFailed := errors.New("failed")
+
+	user := domain.User{ID: uuid.New(), Name: "user", Email: "user@example.com"}
+	other := domain.User{ID: uuid.New(), Name: "other", Email: "other@example.com"}
+
+	// A failed unit of work leaves neither its writes nor its events behind.
+	err := uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := users.CreateUser(ctx, user, domain.NewUserCreatedEvent(user)); err != nil {
+			return err
+		}
+		if _, err := users.CreateUser(ctx, other, domain.NewUserCreatedEvent(other)); err != nil {
+			return err
+		}
+		if err := users.BlockUser(ctx, user.ID, other.ID); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+
+	_, err = users.GetUser(ctx, user.ID)
+	assert.Error(t, err)
+	events, _ := outbox.GetPendingEvents(ctx, time.Now(), 10)
+	assert.Empty(t, events)
+
+	// A nested unit of work rolls back on its own; the outer one still commits.
+	userCreated := domain.NewUserCreatedEvent(user)
+	err = uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := users.CreateUser(ctx, user, userCreated); err != nil {
+			return err
+		}
+
+		nestedErr := uow.Do(ctx, func(ctx context.Context) error {
+			if _, err := users.CreateUser(ctx, other, domain.NewUserCreatedEvent(other)); err != nil {
+				return err
+			}
+			return errFailed
+		})
+		assert.ErrorIs(t, nestedErr, errFailed)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	_, err = users.GetUser(ctx, user.ID)
+	assert.NoError(t, err)
+	_, err = users.GetUser(ctx, other.ID)
+	assert.Error(t, err)
+	events, _ = outbox.GetPendingEvents(ctx, time.Now(), 10)
+	assert.Equal(t, []domain.Event{userCreated}, events)
+
+	// Dispatched events are no longer pending.
+	assert.NoError(t, outbox.MarkEventsDispatched(ctx, []uuid.UUID{userCreated.ID}))
+	events, _ = outbox.GetPendingEvents(ctx, time.Now(), 10)
+	assert.Empty(t, events)
+}
@@ -0,0 +1,167 @@
+package sqlite_db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+// UsersSQLiteRepository implements ports.UsersRepository on top of SQLite.
+type UsersSQLiteRepository struct {
+	db *DB
+}
+
+// NewUserRepository creates a new user repository instance
+func NewUserRepository(db *DB) *UsersSQLiteRepository {
+	return &UsersSQLiteRepository{
+		db,
+	}
+}
+
+func (ur *UsersSQLiteRepository) CreateUser(ctx context.Context, user domain.User, events ...domain.Event) (domain.User, error) {
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
+	}
+
+	err := ur.db.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO users (id, name, email, protected) VALUES (?, ?, ?, ?)", user.ID, user.Name, user.Email, user.Protected)
+		if err != nil {
+			return err
+		}
+
+		return insertEvents(ctx, tx, events)
+	})
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	return user, nil
+}
+
+func (ur *UsersSQLiteRepository) GetUser(ctx context.Context, id uuid.UUID) (domain.User, error) {
+	var user domain.User
+
+	err := ur.db.conn(ctx).QueryRowContext(ctx, "SELECT id, name, email, protected, followers_count, following_count, tweets_count FROM users WHERE id = ?", id).
+		Scan(&user.ID, &user.Name, &user.Email, &user.Protected, &user.FollowersCount, &user.FollowingCount, &user.TweetsCount)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	return user, nil
+}
+
+func (ur *UsersSQLiteRepository) GetUsers(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	if len(ids) == 0 {
+		return []domain.User{}, nil
+	}
+
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	return ur.queryUsers(ctx, "SELECT id, name, email, protected, followers_count, following_count, tweets_count FROM users WHERE id IN ("+placeholders(len(ids))+")", args...)
+}
+
+func (ur *UsersSQLiteRepository) FollowUser(ctx context.Context, userID uuid.UUID, followedID uuid.UUID, events ...domain.Event) error {
+	return ur.db.inTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, "INSERT INTO followers (follower_id, user_id) VALUES (?, ?) ON CONFLICT DO NOTHING", userID, followedID)
+		if err != nil {
+			return err
+		}
+
+		// Already following: nothing changed, so there is nothing to announce.
+		if inserted, err := result.RowsAffected(); err != nil || inserted == 0 {
+			return err
+		}
+		return insertEvents(ctx, tx, events)
+	})
+}
+
+// GetUserTimeline returns the tweets of the users userID follows, in a single query.
+func (ur *UsersSQLiteRepository) GetUserTimeline(ctx context.Context, userID uuid.UUID) ([]domain.Tweet, error) {
+	return queryTweets(ctx, ur.db.conn(ctx), `SELECT t.id, t.user_id, t.message, t.created_at FROM tweets t
+		JOIN followers f ON f.user_id = t.user_id
+		WHERE f.follower_id = ?`, userID)
+}
+
+func (ur *UsersSQLiteRepository) GetFollowedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return ur.queryIDs(ctx, "SELECT user_id FROM followers WHERE follower_id = ?", userID)
+}
+
+func (ur *UsersSQLiteRepository) GetFollowers(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	return ur.queryUsers(ctx, `SELECT u.id, u.name, u.email, u.protected, u.followers_count, u.following_count, u.tweets_count FROM followers f
+		JOIN users u ON u.id = f.follower_id
+		WHERE f.user_id = ?
+		ORDER BY u.name, u.id
+		LIMIT ? OFFSET ?`, userID, page.Limit, page.Offset)
+}
+
+func (ur *UsersSQLiteRepository) GetFollowing(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	return ur.queryUsers(ctx, `SELECT u.id, u.name, u.email, u.protected, u.followers_count, u.following_count, u.tweets_count FROM followers f
+		JOIN users u ON u.id = f.user_id
+		WHERE f.follower_id = ?
+		ORDER BY u.name, u.id
+		LIMIT ? OFFSET ?`, userID, page.Limit, page.Offset)
+}
+
+func (ur *UsersSQLiteRepository) IsFollowing(ctx context.Context, userID uuid.UUID, followedID uuid.UUID) (bool, error) {
+	var following bool
+
+	err := ur.db.conn(ctx).QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM followers WHERE follower_id = ? AND user_id = ?)", userID, followedID).Scan(&following)
+	if err != nil {
+		return false, err
+	}
+
+	return following, nil
+}
+
+func (ur *UsersSQLiteRepository) queryUsers(ctx context.Context, query string, args ...any) ([]domain.User, error) {
+	users := []domain.User{}
+
+	rows, err := ur.db.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Protected, &user.FollowersCount, &user.FollowingCount, &user.TweetsCount); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (ur *UsersSQLiteRepository) queryIDs(ctx context.Context, query string, args ...any) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+
+	rows, err := ur.db.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,185 @@
+package sqlite_db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/repositories/repositorytest"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func createUser(t *testing.T, repo *UsersSQLiteRepository, name string) domain.User {
+	user, err := repo.CreateUser(context.Background(), domain.User{Name: name, Email: name + "@example.com"})
+	assert.NoError(t, err)
+	return user
+}
+
+func TestUsersSQLiteRepository_CreateAndGetUser(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	users := NewUserRepository(db)
+
+	created, err := users.CreateUser(ctx, domain.User{Name: "alice", Email: "alice@example.com", Protected: true})
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, created.ID)
+
+	got, err := users.GetUser(ctx, created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, created, got)
+
+	_, err = users.GetUser(ctx, uuid.New())
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Batched lookups leave unknown IDs out.
+	bob := createUser(t, users, "bob")
+	batch, err := users.GetUsers(ctx, []uuid.UUID{created.ID, uuid.New(), bob.ID})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domain.User{created, bob}, batch)
+	batch, err = users.GetUsers(ctx, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, batch)
+
+	// A duplicate ID is rejected and leaves no event behind.
+	_, err = users.CreateUser(ctx, created, domain.NewUserCreatedEvent(created))
+	assert.Error(t, err)
+	events, _ := NewOutboxRepository(db).GetPendingEvents(ctx, time.Now(), 10)
+	assert.Empty(t, events)
+}
+
+func TestUsersSQLiteRepository_FollowAndTimeline(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	users := NewUserRepository(db)
+	tweets := NewTweetRepository(db)
+
+	alice := createUser(t, users, "alice")
+	bob := createUser(t, users, "bob")
+	carol := createUser(t, users, "carol")
+
+	followed := domain.NewUserFollowedEvent(alice.ID, bob.ID)
+	assert.NoError(t, users.FollowUser(ctx, alice.ID, bob.ID, followed))
+	assert.NoError(t, users.FollowUser(ctx, alice.ID, carol.ID))
+	// Following twice changes nothing and records no second event.
+	assert.NoError(t, users.FollowUser(ctx, alice.ID, bob.ID, domain.NewUserFollowedEvent(alice.ID, bob.ID)), "following twice")
+	assert.Error(t, users.FollowUser(ctx, alice.ID, uuid.New()), "following an unknown user")
+
+	bobTweet, err := tweets.CreateTweet(ctx, domain.Tweet{UserID: bob.ID, Message: "from bob", CreatedAt: time.Date(2024, 1, 1, 12, 0, 0, 500, time.UTC)})
+	assert.NoError(t, err)
+	carolTweet, err := tweets.CreateTweet(ctx, domain.Tweet{UserID: carol.ID, Message: "from carol"})
+	assert.NoError(t, err)
+	_, err = tweets.CreateTweet(ctx, domain.Tweet{UserID: alice.ID, Message: "from alice"})
+	assert.NoError(t, err)
+
+	timeline, err := users.GetUserTimeline(ctx, alice.ID)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domain.Tweet{bobTweet, carolTweet}, timeline)
+
+	timeline, err = users.GetUserTimeline(ctx, bob.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, timeline)
+
+	gotBob, err := users.GetUser(ctx, bob.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.User{ID: bob.ID, Name: "bob", Email: "bob@example.com", FollowersCount: 1, TweetsCount: 1}, gotBob)
+
+	following, err := users.IsFollowing(ctx, alice.ID, bob.ID)
+	assert.NoError(t, err)
+	assert.True(t, following)
+	following, err = users.IsFollowing(ctx, bob.ID, alice.ID)
+	assert.NoError(t, err)
+	assert.False(t, following)
+
+	// Followers and following are ordered by name and paginated.
+	page, err := users.GetFollowing(ctx, alice.ID, domain.NewPagination(1, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.User{{ID: carol.ID, Name: "carol", Email: "carol@example.com", FollowersCount: 1, TweetsCount: 1}}, page)
+	page, err = users.GetFollowers(ctx, carol.ID, domain.NewPagination(10, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.User{{ID: alice.ID, Name: "alice", Email: "alice@example.com", FollowingCount: 2, TweetsCount: 1}}, page)
+	page, err = users.GetFollowers(ctx, alice.ID, domain.NewPagination(10, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.User{}, page)
+
+	events, err := NewOutboxRepository(db).GetPendingEvents(ctx, time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Event{followed}, events)
+}
+
+func TestUsersSQLiteRepository_FollowLists(t *testing.T) {
+	repositorytest.TestFollowLists(t, NewUserRepository(openTestDB(t)))
+}
+
+func TestUsersSQLiteRepository_FollowTwice(t *testing.T) {
+	db := openTestDB(t)
+	repositorytest.TestFollowTwice(t, NewUserRepository(db), NewTweetRepository(db))
+}
+
+func TestTweetsSQLiteRepository_GetTweet(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	users := NewUserRepository(db)
+	tweets := NewTweetRepository(db)
+
+	alice := createUser(t, users, "alice")
+	tweet, err := tweets.CreateTweet(ctx, domain.Tweet{UserID: alice.ID, Message: "hello"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, tweet.ID)
+	assert.False(t, tweet.CreatedAt.IsZero())
+
+	got, err := tweets.GetTweet(ctx, tweet.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, tweet, got)
+
+	_, err = tweets.GetTweet(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrTweetNotFound)
+
+	_, err = tweets.CreateTweet(ctx, domain.Tweet{UserID: uuid.New(), Message: "from nobody"})
+	assert.Error(t, err)
+}
+
+func TestTweetsSQLiteRepository_GetUserTweets(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	users := NewUserRepository(db)
+	tweets := NewTweetRepository(db)
+
+	alice := createUser(t, users, "alice")
+	first, _ := tweets.CreateTweet(ctx, domain.Tweet{UserID: alice.ID, Message: "first", CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)})
+	second, _ := tweets.CreateTweet(ctx, domain.Tweet{UserID: alice.ID, Message: "second", CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)})
+	third, _ := tweets.CreateTweet(ctx, domain.Tweet{UserID: alice.ID, Message: "third", CreatedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)})
+
+	// Newest first, paginated.
+	page, err := tweets.GetUserTweets(ctx, alice.ID, domain.NewPagination(2, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Tweet{third, second}, page)
+	page, err = tweets.GetUserTweets(ctx, alice.ID, domain.NewPagination(2, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Tweet{first}, page)
+}
+
+func TestUsersSQLiteRepository_Counters(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	users := NewUserRepository(db)
+
+	alice := createUser(t, users, "alice")
+	bob := createUser(t, users, "bob")
+	assert.NoError(t, users.FollowUser(ctx, alice.ID, bob.ID))
+	assert.NoError(t, users.FollowUser(ctx, bob.ID, alice.ID))
+	_, err := NewTweetRepository(db).CreateTweet(ctx, domain.Tweet{UserID: alice.ID, Message: "hello"})
+	assert.NoError(t, err)
+
+	gotAlice, _ := users.GetUser(ctx, alice.ID)
+	assert.Equal(t, []int{1, 1, 1}, []int{gotAlice.FollowersCount, gotAlice.FollowingCount, gotAlice.TweetsCount})
+
+	// Blocking drops the follows both ways, and the counters with them.
+	assert.NoError(t, users.BlockUser(ctx, alice.ID, bob.ID))
+	gotAlice, _ = users.GetUser(ctx, alice.ID)
+	assert.Equal(t, []int{0, 0, 1}, []int{gotAlice.FollowersCount, gotAlice.FollowingCount, gotAlice.TweetsCount})
+	gotBob, _ := users.GetUser(ctx, bob.ID)
+	assert.Equal(t, []int{0, 0, 0}, []int{gotBob.FollowersCount, gotBob.FollowingCount, gotBob.TweetsCount})
+}
@@ -0,0 +1,210 @@
+package sqlite_db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+const webhookColumns = "id, url, events, include_protected, secret, created_at"
+
+const deliveryColumns = "id, webhook_id, event_id, event_type, payload, status, attempts, last_status_code, last_error, next_attempt_at, created_at, updated_at"
+
+// WebhooksSQLiteRepository implements ports.WebhooksRepository on top of SQLite.
+type WebhooksSQLiteRepository struct {
+	db *DB
+}
+
+// NewWebhookRepository creates a new webhook repository instance
+func NewWebhookRepository(db *DB) *WebhooksSQLiteRepository {
+	return &WebhooksSQLiteRepository{
+		db,
+	}
+}
+
+func (wr *WebhooksSQLiteRepository) CreateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	webhook.ID = uuid.New()
+	webhook.CreatedAt = time.Now().UTC()
+
+	// The event types are stored as a JSON array, as SQLite has no array columns.
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	_, err = wr.db.conn(ctx).ExecContext(ctx, "INSERT INTO webhooks ("+webhookColumns+") VALUES (?, ?, ?, ?, ?, ?)",
+		webhook.ID, webhook.URL, string(events), webhook.IncludeProtected, webhook.Secret, formatTime(webhook.CreatedAt))
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	return webhook, nil
+}
+
+func (wr *WebhooksSQLiteRepository) GetWebhook(ctx context.Context, id uuid.UUID) (domain.Webhook, error) {
+	webhook, err := scanWebhook(wr.db.conn(ctx).QueryRowContext(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Webhook{}, domain.ErrWebhookNotFound
+	}
+
+	return webhook, err
+}
+
+func (wr *WebhooksSQLiteRepository) GetWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	webhooks := []domain.Webhook{}
+
+	rows, err := wr.db.conn(ctx).QueryContext(ctx, "SELECT "+webhookColumns+" FROM webhooks ORDER BY created_at, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (wr *WebhooksSQLiteRepository) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	// Deliveries go away through ON DELETE CASCADE.
+	result, err := wr.db.conn(ctx).ExecContext(ctx, "DELETE FROM webhooks WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (wr *WebhooksSQLiteRepository) CreateDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	_, err := wr.db.conn(ctx).ExecContext(ctx, `INSERT INTO webhook_deliveries (`+deliveryColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING`,
+		delivery.ID, delivery.WebhookID, delivery.EventID, delivery.EventType, string(delivery.Payload), delivery.Status,
+		delivery.Attempts, delivery.LastStatusCode, delivery.LastError, formatTime(delivery.NextAttemptAt), formatTime(delivery.CreatedAt), formatTime(delivery.UpdatedAt))
+	return err
+}
+
+func (wr *WebhooksSQLiteRepository) UpdateDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	result, err := wr.db.conn(ctx).ExecContext(ctx, `UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, last_status_code = ?, last_error = ?, next_attempt_at = ?, updated_at = ?
+		WHERE id = ?`,
+		delivery.Status, delivery.Attempts, delivery.LastStatusCode, delivery.LastError, formatTime(delivery.NextAttemptAt), formatTime(delivery.UpdatedAt), delivery.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrWebhookDeliveryNotFound
+	}
+
+	return nil
+}
+
+func (wr *WebhooksSQLiteRepository) GetDelivery(ctx context.Context, id uuid.UUID) (domain.WebhookDelivery, error) {
+	delivery, err := scanDelivery(wr.db.conn(ctx).QueryRowContext(ctx, "SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.WebhookDelivery{}, domain.ErrWebhookDeliveryNotFound
+	}
+
+	return delivery, err
+}
+
+func (wr *WebhooksSQLiteRepository) GetDeliveries(ctx context.Context, webhookID uuid.UUID, status domain.WebhookDeliveryStatus, page domain.Pagination) ([]domain.WebhookDelivery, error) {
+	return wr.queryDeliveries(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries
+		WHERE webhook_id = ?1 AND (?2 = '' OR status = ?2)
+		ORDER BY created_at DESC, id
+		LIMIT ?3 OFFSET ?4`, webhookID, string(status), page.Limit, page.Offset)
+}
+
+func (wr *WebhooksSQLiteRepository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	return wr.queryDeliveries(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, id
+		LIMIT ?`, domain.WebhookDeliveryPending, formatTime(now), limit)
+}
+
+func (wr *WebhooksSQLiteRepository) queryDeliveries(ctx context.Context, query string, args ...any) ([]domain.WebhookDelivery, error) {
+	deliveries := []domain.WebhookDelivery{}
+
+	rows, err := wr.db.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// row is implemented by both *sql.Row and *sql.Rows.
+type row interface {
+	Scan(dest ...any) error
+}
+
+func scanWebhook(r row) (domain.Webhook, error) {
+	var webhook domain.Webhook
+	var events string
+
+	if err := r.Scan(&webhook.ID, &webhook.URL, &events, &webhook.IncludeProtected, &webhook.Secret, timestamp{&webhook.CreatedAt}); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	if err := json.Unmarshal([]byte(events), &webhook.Events); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	return webhook, nil
+}
+
+func scanDelivery(r row) (domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	var payload string
+
+	err := r.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType, &payload, &delivery.Status,
+		&delivery.Attempts, &delivery.LastStatusCode, &delivery.LastError, timestamp{&delivery.NextAttemptAt}, timestamp{&delivery.CreatedAt}, timestamp{&delivery.UpdatedAt})
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	delivery.Payload = []byte(payload)
+	return delivery, nil
+}
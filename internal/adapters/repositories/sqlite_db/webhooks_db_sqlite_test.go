@@ -0,0 +1,65 @@
+package sqlite_db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhooksSQLiteRepository(t *testing.T) {
+	ctx := context.Background()
+	webhooks := NewWebhookRepository(openTestDB(t))
+
+	webhook, err := webhooks.CreateWebhook(ctx, domain.Webhook{URL: "https://example.com/hook", Events: []domain.EventType{domain.EventTypeTweetCreated}, Secret: "s3cret"})
+	assert.NoError(t, err)
+
+	got, err := webhooks.GetWebhook(ctx, webhook.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, webhook, got)
+	all, err := webhooks.GetWebhooks(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Webhook{webhook}, all)
+	_, err = webhooks.GetWebhook(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	due := domain.WebhookDelivery{ID: uuid.New(), WebhookID: webhook.ID, EventID: uuid.New(), EventType: domain.EventTypeTweetCreated,
+		Payload: []byte(`{"id":1}`), Status: domain.WebhookDeliveryPending, NextAttemptAt: now, CreatedAt: now, UpdatedAt: now}
+	later := due
+	later.ID, later.EventID, later.NextAttemptAt, later.CreatedAt = uuid.New(), uuid.New(), now.Add(time.Hour), now.Add(time.Second)
+	assert.NoError(t, webhooks.CreateDelivery(ctx, due))
+	assert.NoError(t, webhooks.CreateDelivery(ctx, later))
+
+	// Queuing the same event again is a no-op.
+	duplicate := due
+	duplicate.ID = uuid.New()
+	assert.NoError(t, webhooks.CreateDelivery(ctx, duplicate))
+
+	dueDeliveries, err := webhooks.GetDueDeliveries(ctx, now, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.WebhookDelivery{due}, dueDeliveries)
+
+	due.Status, due.Attempts, due.LastStatusCode, due.LastError = domain.WebhookDeliverySucceeded, 1, 200, ""
+	assert.NoError(t, webhooks.UpdateDelivery(ctx, due))
+	gotDelivery, err := webhooks.GetDelivery(ctx, due.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, due, gotDelivery)
+	assert.ErrorIs(t, webhooks.UpdateDelivery(ctx, duplicate), domain.ErrWebhookDeliveryNotFound)
+
+	deliveries, err := webhooks.GetDeliveries(ctx, webhook.ID, "", domain.NewPagination(10, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.WebhookDelivery{later, due}, deliveries)
+	deliveries, err = webhooks.GetDeliveries(ctx, webhook.ID, domain.WebhookDeliveryPending, domain.NewPagination(10, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.WebhookDelivery{later}, deliveries)
+
+	// Deleting the webhook deletes its deliveries.
+	assert.NoError(t, webhooks.DeleteWebhook(ctx, webhook.ID))
+	assert.ErrorIs(t, webhooks.DeleteWebhook(ctx, webhook.ID), domain.ErrWebhookNotFound)
+	_, err = webhooks.GetDelivery(ctx, due.ID)
+	assert.ErrorIs(t, err, domain.ErrWebhookDeliveryNotFound)
+}
@@ -0,0 +1,146 @@
+package streaming
+
+import (
+	"context"
+	"sync"
+
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+const (
+	DefaultBufferSize  = 64
+	DefaultHistorySize = 1024
+)
+
+// Event is a published tweet tagged with a monotonically increasing ID that
+// clients can hand back (e.g. as SSE Last-Event-ID) to resume after a disconnect.
+type Event struct {
+	ID    uint64
+	Tweet domain.Tweet
+}
+
+// Filter decides whether a subscription is interested in a tweet. It runs while
+// the hub is publishing, so it must be cheap and must not call back into the hub.
+type Filter func(domain.Tweet) bool
+
+// Hub is an in-process pub/sub hub for tweets. Every subscription gets its own
+// buffered channel; a subscriber that falls a full buffer behind is dropped so
+// publishers never block, and can reconnect and resume from the hub history.
+type Hub struct {
+	mu            sync.Mutex
+	lastID        uint64
+	history       []Event
+	historySize   int
+	bufferSize    int
+	subscriptions map[*Subscription]struct{}
+}
+
+// NewHub creates a hub that buffers bufferSize events per subscriber and keeps the
+// last historySize events for resumption.
+func NewHub(bufferSize, historySize int) *Hub {
+	return &Hub{
+		bufferSize:    bufferSize,
+		historySize:   historySize,
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+// PublishTweet implements ports.TweetPublisher.
+func (h *Hub) PublishTweet(ctx context.Context, tweet domain.Tweet) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastID++
+	event := Event{ID: h.lastID, Tweet: tweet}
+
+	h.history = append(h.history, event)
+	if len(h.history) > h.historySize {
+		h.history = h.history[len(h.history)-h.historySize:]
+	}
+
+	for subscription := range h.subscriptions {
+		if !subscription.filter(tweet) {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			// The subscriber is a full buffer behind: drop it rather than block.
+			h.removeLocked(subscription)
+		}
+	}
+}
+
+// Subscribe registers a subscription for the tweets accepted by filter. When
+// lastEventID is non-zero, the matching events published after it that are still
+// in the history are queued first, so no event is lost between replay and live delivery.
+func (h *Hub) Subscribe(filter Filter, lastEventID uint64) *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var replay []Event
+	if lastEventID > 0 && lastEventID < h.lastID {
+		for _, event := range h.history {
+			if event.ID > lastEventID && filter(event.Tweet) {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	bufferSize := h.bufferSize
+	if len(replay) > bufferSize {
+		bufferSize = len(replay)
+	}
+
+	subscription := &Subscription{
+		hub:    h,
+		filter: filter,
+		events: make(chan Event, bufferSize),
+	}
+	for _, event := range replay {
+		subscription.events <- event
+	}
+
+	h.subscriptions[subscription] = struct{}{}
+
+	return subscription
+}
+
+// SubscriberCount returns the number of live subscriptions.
+func (h *Hub) SubscriberCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.subscriptions)
+}
+
+func (h *Hub) removeLocked(subscription *Subscription) {
+	if _, ok := h.subscriptions[subscription]; !ok {
+		return
+	}
+
+	delete(h.subscriptions, subscription)
+	close(subscription.events)
+}
+
+// Subscription is a single consumer of the hub.
+type Subscription struct {
+	hub    *Hub
+	filter Filter
+	events chan Event
+}
+
+// Events returns the channel events are delivered on. It is closed when the
+// subscription is closed or dropped for falling behind.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close unsubscribes. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.removeLocked(s)
+}
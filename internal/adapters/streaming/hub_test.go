@@ -0,0 +1,117 @@
+package streaming
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func acceptAll(domain.Tweet) bool { return true }
+
+func drain(subscription *Subscription) []Event {
+	var events []Event
+	for {
+		select {
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestHub_PublishTweet(t *testing.T) {
+	followedID := uuid.New()
+	otherID := uuid.New()
+
+	tests := []struct {
+		name           string
+		filter         Filter
+		tweets         []domain.Tweet
+		expectedEvents []Event
+	}{
+		{
+			name:   "Delivers every tweet in order",
+			filter: acceptAll,
+			tweets: []domain.Tweet{
+				{UserID: followedID, Message: "first"},
+				{UserID: otherID, Message: "second"},
+			},
+			expectedEvents: []Event{
+				{ID: 1, Tweet: domain.Tweet{UserID: followedID, Message: "first"}},
+				{ID: 2, Tweet: domain.Tweet{UserID: otherID, Message: "second"}},
+			},
+		},
+		{
+			name:   "Skips tweets rejected by the filter",
+			filter: func(tweet domain.Tweet) bool { return tweet.UserID == followedID },
+			tweets: []domain.Tweet{
+				{UserID: otherID, Message: "ignored"},
+				{UserID: followedID, Message: "kept"},
+			},
+			expectedEvents: []Event{
+				{ID: 2, Tweet: domain.Tweet{UserID: followedID, Message: "kept"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := NewHub(DefaultBufferSize, DefaultHistorySize)
+			subscription := hub.Subscribe(tt.filter, 0)
+			defer subscription.Close()
+
+			for _, tweet := range tt.tweets {
+				hub.PublishTweet(context.Background(), tweet)
+			}
+
+			assert.Equal(t, tt.expectedEvents, drain(subscription))
+		})
+	}
+}
+
+func TestHub_SubscribeResumesFromLastEventID(t *testing.T) {
+	hub := NewHub(DefaultBufferSize, 2)
+
+	for _, message := range []string{"one", "two", "three"} {
+		hub.PublishTweet(context.Background(), domain.Tweet{Message: message})
+	}
+
+	// Event 1 already fell out of the two-event history
+	subscription := hub.Subscribe(acceptAll, 1)
+	defer subscription.Close()
+
+	hub.PublishTweet(context.Background(), domain.Tweet{Message: "four"})
+
+	events := drain(subscription)
+	assert.Len(t, events, 3)
+	assert.Equal(t, []uint64{2, 3, 4}, []uint64{events[0].ID, events[1].ID, events[2].ID})
+}
+
+func TestHub_DropsSlowSubscribers(t *testing.T) {
+	hub := NewHub(1, DefaultHistorySize)
+	slow := hub.Subscribe(acceptAll, 0)
+	fast := hub.Subscribe(acceptAll, 0)
+
+	hub.PublishTweet(context.Background(), domain.Tweet{Message: "one"})
+	assert.Len(t, drain(fast), 1)
+	hub.PublishTweet(context.Background(), domain.Tweet{Message: "two"})
+
+	assert.Equal(t, 1, hub.SubscriberCount())
+
+	// The buffered event is still readable before the channel reports closed
+	event, ok := <-slow.Events()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), event.ID)
+	_, ok = <-slow.Events()
+	assert.False(t, ok)
+
+	fast.Close()
+	fast.Close()
+	assert.Equal(t, 0, hub.SubscriberCount())
+}
@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/handlers"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	mock_ports "github.com/juanignaciorc/microbloggin-pltf/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	traceparent = "00-" + traceID + "-00f067aa0ba902b7-01"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	ctrl := gomock.NewController(t)
+	service := mock_ports.NewMockUserService(ctrl)
+	router := gin.New()
+	router.ContextWithFallback = true
+	router.Use(Middleware())
+	router.GET("/users/:id", handlers.NewUserHandler(service).Get)
+
+	userID := uuid.New()
+	service.EXPECT().GetUserAsViewer(gomock.Any(), uuid.Nil, userID).DoAndReturn(func(ctx context.Context, viewerID, id uuid.UUID) (domain.User, error) {
+		// The services get the request's span through the gin context.
+		assert.Equal(t, traceID, trace.SpanContextFromContext(ctx).TraceID().String())
+		return domain.User{}, assert.AnError
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/users/"+userID.String(), nil)
+	request.Header.Set("traceparent", traceparent)
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	// The error response carries the trace ID of the caller.
+	var body handlers.ErrorResponse
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.Equal(t, traceID, body.TraceID)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GET /users/:id", span.Name())
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+	assert.Equal(t, traceID, span.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	attributes := make(map[string]string)
+	for _, attribute := range span.Attributes() {
+		attributes[string(attribute.Key)] = attribute.Value.Emit()
+	}
+	assert.Equal(t, "/users/:id", attributes["http.route"])
+	assert.Equal(t, "500", attributes["http.response.status_code"])
+}
@@ -0,0 +1,45 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+var errNonPublicAddress = errors.New("refusing to connect to a non-public address")
+
+// NewClient returns the HTTP client deliveries are sent with. It only connects to
+// public addresses, and checks the address it is about to dial rather than the
+// name: a name that resolved to a public address when the webhook was registered
+// may resolve to one in the API's own network by the time it is delivered to, and
+// redirects go through the same check.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: checkAddress}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Through a proxy the address dialed would be the proxy's, not the receiver's.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// checkAddress is a net.Dialer Control function, run with the resolved address
+// right before connecting to it.
+func checkAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	if !domain.IsPublicAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w %s", errNonPublicAddress, addrPort.Addr())
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/logging"
+	ports "github.com/juanignaciorc/microbloggin-pltf/internal/ports/repositories"
+)
+
+// Headers sent with every delivery. The signature is the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the webhook secret, prefixed with "sha256=".
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+const (
+	DefaultTimeout      = 10 * time.Second
+	DefaultPollInterval = time.Second
+	// DefaultConcurrency is how many deliveries of one webhook are sent at a time.
+	DefaultConcurrency = 4
+	deliveryBatchSize  = 50
+	// maxErrorLength bounds the receiver response kept in the delivery log.
+	maxErrorLength = 512
+)
+
+// RetryPolicy controls the exponential backoff between attempts. After
+// MaxAttempts failed attempts a delivery is moved to the dead-letter list.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 8,
+	BaseDelay:   10 * time.Second,
+	MaxDelay:    time.Hour,
+}
+
+// Backoff returns how long to wait after the given failed attempt (1-based):
+// BaseDelay, then doubling each time, capped at MaxDelay.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	return delay
+}
+
+// payload is the JSON body POSTed to webhooks.
+type payload struct {
+	ID         uuid.UUID        `json:"id"`
+	Type       domain.EventType `json:"type"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Data       json.RawMessage  `json:"data"`
+}
+
+// Deliverer turns domain events into webhook deliveries and sends them, retrying
+// failures with exponential backoff. Up to concurrency deliveries of each webhook
+// are sent at a time, so a slow receiver doesn't hold up the others.
+type Deliverer struct {
+	repository   ports.WebhooksRepository
+	users        ports.UsersRepository
+	client       *http.Client
+	policy       RetryPolicy
+	concurrency  int
+	pollInterval time.Duration
+	now          func() time.Time
+}
+
+func NewDeliverer(repository ports.WebhooksRepository, users ports.UsersRepository, client *http.Client, policy RetryPolicy, concurrency int, pollInterval time.Duration) *Deliverer {
+	return &Deliverer{
+		repository:   repository,
+		users:        users,
+		client:       client,
+		policy:       policy,
+		concurrency:  concurrency,
+		pollInterval: pollInterval,
+		now:          func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// HandleEvent is an eventbus.Handler that queues a delivery of the event for every
+// webhook subscribed to its type. Sending happens in Run, so a slow receiver never
+// holds up the event dispatcher.
+func (d *Deliverer) HandleEvent(ctx context.Context, event domain.Event) error {
+	webhooks, err := d.repository.GetWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	protected, err := d.fromProtectedAccount(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(payload{ID: event.ID, Type: event.Type, OccurredAt: event.OccurredAt, Data: event.Payload})
+	if err != nil {
+		return err
+	}
+
+	now := d.now()
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event.Type) || (protected && !webhook.IncludeProtected) {
+			continue
+		}
+
+		delivery := domain.WebhookDelivery{
+			ID:            uuid.New(),
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       body,
+			Status:        domain.WebhookDeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		if err := d.repository.CreateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fromProtectedAccount reports whether the event is a tweet of a protected account,
+// which only the webhooks created with IncludeProtected get.
+func (d *Deliverer) fromProtectedAccount(ctx context.Context, event domain.Event) (bool, error) {
+	if event.Type != domain.EventTypeTweetCreated {
+		return false, nil
+	}
+
+	var tweet domain.TweetCreated
+	if err := event.DecodePayload(&tweet); err != nil {
+		return false, err
+	}
+
+	author, err := d.users.GetUser(ctx, tweet.UserID)
+	if err != nil {
+		return false, err
+	}
+	return author.Protected, nil
+}
+
+// Run sends due deliveries every pollInterval until ctx is cancelled.
+func (d *Deliverer) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			attempted, err := d.DeliverDue(ctx)
+			if err != nil {
+				logging.FromContext(ctx).Error("Failed to deliver webhooks", "error", err)
+				break
+			}
+			if attempted < deliveryBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue makes one attempt for each delivery whose next attempt is due and
+// returns how many were attempted. The deliveries of different webhooks are sent
+// in parallel, and up to concurrency at a time for each webhook.
+func (d *Deliverer) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := d.repository.GetDueDeliveries(ctx, d.now(), deliveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		errs  []error
+		slots = make(map[uuid.UUID]chan struct{})
+	)
+	for _, delivery := range deliveries {
+		slot, ok := slots[delivery.WebhookID]
+		if !ok {
+			slot = make(chan struct{}, d.concurrency)
+			slots[delivery.WebhookID] = slot
+		}
+
+		wg.Add(1)
+		go func(delivery domain.WebhookDelivery) {
+			defer wg.Done()
+
+			slot <- struct{}{}
+			defer func() { <-slot }()
+
+			if err := d.deliver(ctx, delivery); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(delivery)
+	}
+	wg.Wait()
+
+	return len(deliveries), errors.Join(errs...)
+}
+
+// deliver attempts the delivery and stores the outcome.
+func (d *Deliverer) deliver(ctx context.Context, delivery domain.WebhookDelivery) error {
+	webhook, err := d.repository.GetWebhook(ctx, delivery.WebhookID)
+	if errors.Is(err, domain.ErrWebhookNotFound) {
+		// Deleted while the delivery was pending; its deliveries are gone with it.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return d.repository.UpdateDelivery(ctx, d.attempt(ctx, webhook, delivery))
+}
+
+// attempt sends the delivery once and returns it updated with the outcome.
+func (d *Deliverer) attempt(ctx context.Context, webhook domain.Webhook, delivery domain.WebhookDelivery) domain.WebhookDelivery {
+	statusCode, err := d.send(ctx, webhook, delivery)
+
+	now := d.now()
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	delivery.UpdatedAt = now
+
+	switch {
+	case err == nil:
+		delivery.Status = domain.WebhookDeliverySucceeded
+		delivery.LastError = ""
+	case delivery.Attempts >= d.policy.MaxAttempts:
+		delivery.Status = domain.WebhookDeliveryDead
+		delivery.LastError = err.Error()
+	default:
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(d.policy.Backoff(delivery.Attempts))
+	}
+
+	return delivery
+}
+
+func (d *Deliverer) send(ctx context.Context, webhook domain.Webhook, delivery domain.WebhookDelivery) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := d.now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, delivery.Payload))
+	request.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(EventHeader, string(delivery.EventType))
+	request.Header.Set(DeliveryHeader, delivery.ID.String())
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorLength))
+		return response.StatusCode, fmt.Errorf("receiver answered %d: %s", response.StatusCode, body)
+	}
+
+	return response.StatusCode, nil
+}
+
+// Sign computes the signature header value for a delivery. Receivers recompute it
+// with their copy of the secret and compare using hmac.Equal.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
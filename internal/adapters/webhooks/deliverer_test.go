@@ -0,0 +1,233 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/repositories/in_memory_db"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "s3cret"
+
+// receiver is a local webhook endpoint that verifies signatures and answers with
+// the queued status codes, then 200.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedRequest
+}
+
+type receivedRequest struct {
+	body        []byte
+	validSig    bool
+	eventType   string
+	deliveryID  string
+	contentType string
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	timestamp, _ := strconv.ParseInt(req.Header.Get(TimestampHeader), 10, 64)
+	expected := Sign(testSecret, timestamp, body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests = append(r.requests, receivedRequest{
+		body:        body,
+		validSig:    hmac.Equal([]byte(expected), []byte(req.Header.Get(SignatureHeader))),
+		eventType:   req.Header.Get(EventHeader),
+		deliveryID:  req.Header.Get(DeliveryHeader),
+		contentType: req.Header.Get("Content-Type"),
+	})
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (r *receiver) received() []receivedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]receivedRequest(nil), r.requests...)
+}
+
+// setup creates a webhook pointing at a local receiver and a deliverer with a
+// controllable clock.
+func setup(t *testing.T, rcv http.Handler, events []domain.EventType) (*in_memory_db.InMemoryDB, domain.Webhook, *Deliverer, *time.Time) {
+	server := httptest.NewServer(rcv)
+	t.Cleanup(server.Close)
+
+	db := in_memory_db.NewInMemoryDB()
+	webhook, err := db.CreateWebhook(context.Background(), domain.Webhook{URL: server.URL, Events: events, Secret: testSecret})
+	assert.NoError(t, err)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	deliverer := NewDeliverer(db, db, server.Client(), RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}, 2, DefaultPollInterval)
+	deliverer.now = func() time.Time { return now }
+
+	return db, webhook, deliverer, &now
+}
+
+func TestDeliverer_DeliversSignedPayload(t *testing.T) {
+	ctx := context.Background()
+	rcv := &receiver{}
+	db, webhook, deliverer, _ := setup(t, rcv, []domain.EventType{domain.EventTypeUserFollowed})
+
+	followed := domain.NewUserFollowedEvent(uuid.New(), uuid.New())
+	assert.NoError(t, deliverer.HandleEvent(ctx, followed))
+	// Not subscribed to this type, and a redelivered event is only queued once.
+	assert.NoError(t, deliverer.HandleEvent(ctx, domain.NewUserCreatedEvent(domain.User{ID: uuid.New()})))
+	assert.NoError(t, deliverer.HandleEvent(ctx, followed))
+
+	attempted, err := deliverer.DeliverDue(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+
+	requests := rcv.received()
+	assert.Len(t, requests, 1)
+	assert.True(t, requests[0].validSig)
+	assert.Equal(t, string(domain.EventTypeUserFollowed), requests[0].eventType)
+	assert.Equal(t, "application/json", requests[0].contentType)
+
+	var body payload
+	assert.NoError(t, json.Unmarshal(requests[0].body, &body))
+	assert.Equal(t, followed.ID, body.ID)
+	assert.JSONEq(t, string(followed.Payload), string(body.Data))
+
+	deliveries, _ := db.GetDeliveries(ctx, webhook.ID, "", domain.NewPagination(0, 0))
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, deliveries[0].ID.String(), requests[0].deliveryID)
+	assert.Equal(t, domain.WebhookDeliverySucceeded, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusOK, deliveries[0].LastStatusCode)
+}
+
+func TestDeliverer_RetriesWithBackoffThenDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	rcv := &receiver{statuses: []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable}}
+	db, webhook, deliverer, now := setup(t, rcv, []domain.EventType{domain.EventTypeTweetCreated})
+
+	author, _ := db.CreateUser(ctx, domain.User{Name: "author"})
+	assert.NoError(t, deliverer.HandleEvent(ctx, domain.NewTweetCreatedEvent(domain.Tweet{ID: uuid.New(), UserID: author.ID})))
+
+	// First attempt fails and is scheduled one BaseDelay later.
+	attempted, _ := deliverer.DeliverDue(ctx)
+	assert.Equal(t, 1, attempted)
+	deliveries, _ := db.GetDeliveries(ctx, webhook.ID, domain.WebhookDeliveryPending, domain.NewPagination(0, 0))
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, now.Add(time.Minute), deliveries[0].NextAttemptAt)
+	assert.Equal(t, http.StatusInternalServerError, deliveries[0].LastStatusCode)
+
+	// Nothing is due before the backoff elapses.
+	attempted, _ = deliverer.DeliverDue(ctx)
+	assert.Equal(t, 0, attempted)
+
+	// The second failure doubles the delay.
+	*now = now.Add(time.Minute)
+	attempted, _ = deliverer.DeliverDue(ctx)
+	assert.Equal(t, 1, attempted)
+	deliveries, _ = db.GetDeliveries(ctx, webhook.ID, domain.WebhookDeliveryPending, domain.NewPagination(0, 0))
+	assert.Equal(t, now.Add(2*time.Minute), deliveries[0].NextAttemptAt)
+
+	// The third failure exhausts MaxAttempts and dead-letters the delivery.
+	*now = now.Add(2 * time.Minute)
+	attempted, _ = deliverer.DeliverDue(ctx)
+	assert.Equal(t, 1, attempted)
+
+	dead, _ := db.GetDeliveries(ctx, webhook.ID, domain.WebhookDeliveryDead, domain.NewPagination(0, 0))
+	assert.Len(t, dead, 1)
+	assert.Equal(t, 3, dead[0].Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, dead[0].LastStatusCode)
+	assert.Equal(t, "receiver answered 503: ", dead[0].LastError)
+	assert.Len(t, rcv.received(), 3)
+
+	*now = now.Add(time.Hour)
+	attempted, _ = deliverer.DeliverDue(ctx)
+	assert.Equal(t, 0, attempted)
+}
+
+func TestDeliverer_SkipsProtectedTweetsUnlessIncluded(t *testing.T) {
+	ctx := context.Background()
+	rcv := &receiver{}
+	db, public, deliverer, _ := setup(t, rcv, []domain.EventType{domain.EventTypeTweetCreated})
+	authorized, err := db.CreateWebhook(ctx, domain.Webhook{URL: public.URL, Events: public.Events, IncludeProtected: true, Secret: testSecret})
+	assert.NoError(t, err)
+
+	author, _ := db.CreateUser(ctx, domain.User{Name: "author", Protected: true})
+	assert.NoError(t, deliverer.HandleEvent(ctx, domain.NewTweetCreatedEvent(domain.Tweet{ID: uuid.New(), UserID: author.ID})))
+
+	deliveries, _ := db.GetDeliveries(ctx, public.ID, "", domain.NewPagination(0, 0))
+	assert.Empty(t, deliveries)
+	deliveries, _ = db.GetDeliveries(ctx, authorized.ID, "", domain.NewPagination(0, 0))
+	assert.Len(t, deliveries, 1)
+}
+
+func TestDeliverer_BoundsConcurrencyPerWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		mu       sync.Mutex
+		inFlight int
+		peak     int
+	)
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	})
+	db, webhook, deliverer, _ := setup(t, slow, []domain.EventType{domain.EventTypeUserFollowed})
+
+	for i := 0; i < 6; i++ {
+		assert.NoError(t, deliverer.HandleEvent(ctx, domain.NewUserFollowedEvent(uuid.New(), uuid.New())))
+	}
+
+	attempted, err := deliverer.DeliverDue(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, attempted)
+
+	// setup allows two deliveries of a webhook at a time.
+	assert.Equal(t, 2, peak)
+	deliveries, _ := db.GetDeliveries(ctx, webhook.ID, domain.WebhookDeliverySucceeded, domain.NewPagination(0, 0))
+	assert.Len(t, deliveries, 6)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{attempt: 1, expected: time.Second},
+		{attempt: 2, expected: 2 * time.Second},
+		{attempt: 4, expected: 8 * time.Second},
+		{attempt: 5, expected: 10 * time.Second},
+		{attempt: 50, expected: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, policy.Backoff(tt.attempt), "attempt %d", tt.attempt)
+	}
+}
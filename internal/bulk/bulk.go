@@ -0,0 +1,512 @@
+// Package bulk moves users, follows and tweets in and out of a repository as JSON
+// lines or CSV, streaming the records so data sets larger than memory can be copied
+// between backends.
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	ports "github.com/juanignaciorc/microbloggin-pltf/internal/ports/repositories"
+)
+
+type Kind string
+
+const (
+	KindUsers   Kind = "users"
+	KindFollows Kind = "follows"
+	KindTweets  Kind = "tweets"
+)
+
+// Kinds lists every kind in dependency order: follows and tweets reference users,
+// so importing in this order never skips a record whose user comes later.
+var Kinds = []Kind{KindUsers, KindFollows, KindTweets}
+
+type Format string
+
+const (
+	FormatJSONL Format = "jsonl"
+	FormatCSV   Format = "csv"
+)
+
+const (
+	DefaultBatchSize = 1000
+	// maxReportedErrors bounds Report.Errors; Failed keeps counting past it.
+	maxReportedErrors = 100
+	maxMessageLength  = 280
+	maxLineLength     = 1024 * 1024
+)
+
+func ParseKind(s string) (Kind, error) {
+	for _, kind := range Kinds {
+		if string(kind) == s {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown kind %q, want users, follows or tweets", s)
+}
+
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case FormatJSONL, FormatCSV:
+		return Format(s), nil
+	}
+	return "", fmt.Errorf("unknown format %q, want jsonl or csv", s)
+}
+
+// FormatFromPath picks the format from a file extension: .csv is CSV, anything
+// else JSON lines.
+func FormatFromPath(path string) Format {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return FormatCSV
+	}
+	return FormatJSONL
+}
+
+// LineError is a record that could not be imported because it was malformed or
+// invalid.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Report is the progress of an import. Every record read is either imported,
+// skipped because it already exists or references an unknown user, or failed.
+type Report struct {
+	Kind     Kind
+	Read     int
+	Imported int
+	Skipped  int
+	Failed   int
+	// Errors holds the first failures, in input order.
+	Errors []LineError
+}
+
+func (r *Report) fail(err LineError) {
+	r.Failed++
+	if len(r.Errors) < maxReportedErrors {
+		r.Errors = append(r.Errors, err)
+	}
+}
+
+type Options struct {
+	// BatchSize is how many records are written per repository call; 0 means
+	// DefaultBatchSize.
+	BatchSize int
+	// Progress, if set, is called after every batch with the report so far.
+	Progress func(Report)
+}
+
+// Export writes every record of the kind to w and returns how many were written.
+// CSV output starts with a header row.
+func Export(ctx context.Context, repository ports.BulkRepository, kind Kind, format Format, w io.Writer) (int, error) {
+	switch kind {
+	case KindUsers:
+		return export(ctx, repository, userSchema, format, w)
+	case KindFollows:
+		return export(ctx, repository, followSchema, format, w)
+	case KindTweets:
+		return export(ctx, repository, tweetSchema, format, w)
+	}
+	return 0, fmt.Errorf("unknown kind %q", kind)
+}
+
+// Import reads records of the kind from r and writes them in batches, keeping their
+// IDs. Malformed and invalid records are reported and do not stop the import; the
+// returned error is for failures reading r or writing to the repository.
+func Import(ctx context.Context, repository ports.BulkRepository, kind Kind, format Format, r io.Reader, options Options) (Report, error) {
+	switch kind {
+	case KindUsers:
+		return importRecords(ctx, repository, userSchema, kind, format, r, options)
+	case KindFollows:
+		return importRecords(ctx, repository, followSchema, kind, format, r, options)
+	case KindTweets:
+		return importRecords(ctx, repository, tweetSchema, kind, format, r, options)
+	}
+	return Report{Kind: kind}, fmt.Errorf("unknown kind %q", kind)
+}
+
+// schema describes how a kind of record is stored, encoded and checked.
+type schema[T any] struct {
+	header   []string
+	fields   func(T) []string
+	parse    func(fields []string) (T, error)
+	validate func(T) error
+	export   func(ctx context.Context, repository ports.BulkRepository, fn func(T) error) error
+	write    func(ctx context.Context, repository ports.BulkRepository, records []T) (int, error)
+}
+
+// user is the exported form of a user: relationships and tweets are exported as
+// their own kinds.
+type user struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Protected bool      `json:"protected"`
+}
+
+var userSchema = schema[user]{
+	header: []string{"id", "name", "email", "protected"},
+	fields: func(u user) []string {
+		return []string{u.ID.String(), u.Name, u.Email, strconv.FormatBool(u.Protected)}
+	},
+	parse: func(fields []string) (user, error) {
+		id, err := uuid.Parse(fields[0])
+		if err != nil {
+			return user{}, fmt.Errorf("id: %w", err)
+		}
+		protected := false
+		if fields[3] != "" {
+			if protected, err = strconv.ParseBool(fields[3]); err != nil {
+				return user{}, fmt.Errorf("protected: %w", err)
+			}
+		}
+		return user{ID: id, Name: fields[1], Email: fields[2], Protected: protected}, nil
+	},
+	validate: func(u user) error {
+		switch {
+		case u.ID == uuid.Nil:
+			return errors.New("id is required")
+		case u.Name == "":
+			return errors.New("name is required")
+		case u.Email == "":
+			return errors.New("email is required")
+		}
+		return nil
+	},
+	export: func(ctx context.Context, repository ports.BulkRepository, fn func(user) error) error {
+		return repository.ExportUsers(ctx, func(u domain.User) error {
+			return fn(user{ID: u.ID, Name: u.Name, Email: u.Email, Protected: u.Protected})
+		})
+	},
+	write: func(ctx context.Context, repository ports.BulkRepository, records []user) (int, error) {
+		users := make([]domain.User, len(records))
+		for i, u := range records {
+			users[i] = domain.User{ID: u.ID, Name: u.Name, Email: u.Email, Protected: u.Protected}
+		}
+		return repository.ImportUsers(ctx, users)
+	},
+}
+
+var followSchema = schema[domain.Follow]{
+	header: []string{"follower_id", "followed_id"},
+	fields: func(f domain.Follow) []string {
+		return []string{f.FollowerID.String(), f.FollowedID.String()}
+	},
+	parse: func(fields []string) (domain.Follow, error) {
+		followerID, err := uuid.Parse(fields[0])
+		if err != nil {
+			return domain.Follow{}, fmt.Errorf("follower_id: %w", err)
+		}
+		followedID, err := uuid.Parse(fields[1])
+		if err != nil {
+			return domain.Follow{}, fmt.Errorf("followed_id: %w", err)
+		}
+		return domain.Follow{FollowerID: followerID, FollowedID: followedID}, nil
+	},
+	validate: func(f domain.Follow) error {
+		switch {
+		case f.FollowerID == uuid.Nil:
+			return errors.New("follower_id is required")
+		case f.FollowedID == uuid.Nil:
+			return errors.New("followed_id is required")
+		case f.FollowerID == f.FollowedID:
+			return errors.New("users cannot follow themselves")
+		}
+		return nil
+	},
+	export: func(ctx context.Context, repository ports.BulkRepository, fn func(domain.Follow) error) error {
+		return repository.ExportFollows(ctx, fn)
+	},
+	write: func(ctx context.Context, repository ports.BulkRepository, records []domain.Follow) (int, error) {
+		return repository.ImportFollows(ctx, records)
+	},
+}
+
+var tweetSchema = schema[domain.Tweet]{
+	header: []string{"id", "user_id", "message", "created_at"},
+	fields: func(t domain.Tweet) []string {
+		return []string{t.ID.String(), t.UserID.String(), t.Message, t.CreatedAt.UTC().Format(time.RFC3339Nano)}
+	},
+	parse: func(fields []string) (domain.Tweet, error) {
+		id, err := uuid.Parse(fields[0])
+		if err != nil {
+			return domain.Tweet{}, fmt.Errorf("id: %w", err)
+		}
+		userID, err := uuid.Parse(fields[1])
+		if err != nil {
+			return domain.Tweet{}, fmt.Errorf("user_id: %w", err)
+		}
+		var createdAt time.Time
+		if fields[3] != "" {
+			if createdAt, err = time.Parse(time.RFC3339Nano, fields[3]); err != nil {
+				return domain.Tweet{}, fmt.Errorf("created_at: %w", err)
+			}
+		}
+		return domain.Tweet{ID: id, UserID: userID, Message: fields[2], CreatedAt: createdAt}, nil
+	},
+	validate: func(t domain.Tweet) error {
+		switch {
+		case t.ID == uuid.Nil:
+			return errors.New("id is required")
+		case t.UserID == uuid.Nil:
+			return errors.New("user_id is required")
+		case t.Message == "":
+			return errors.New("message is required")
+		case utf8.RuneCountInString(t.Message) > maxMessageLength:
+			return fmt.Errorf("message is longer than %d characters", maxMessageLength)
+		case t.CreatedAt.IsZero():
+			return errors.New("created_at is required")
+		}
+		return nil
+	},
+	export: func(ctx context.Context, repository ports.BulkRepository, fn func(domain.Tweet) error) error {
+		return repository.ExportTweets(ctx, fn)
+	},
+	write: func(ctx context.Context, repository ports.BulkRepository, records []domain.Tweet) (int, error) {
+		return repository.ImportTweets(ctx, records)
+	},
+}
+
+func export[T any](ctx context.Context, repository ports.BulkRepository, s schema[T], format Format, w io.Writer) (int, error) {
+	var encode func(T) error
+	var flush func() error
+
+	switch format {
+	case FormatJSONL:
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		encode = func(record T) error { return encoder.Encode(record) }
+		flush = func() error { return nil }
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(s.header); err != nil {
+			return 0, err
+		}
+		encode = func(record T) error { return writer.Write(s.fields(record)) }
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	default:
+		return 0, fmt.Errorf("unknown format %q", format)
+	}
+
+	count := 0
+	err := s.export(ctx, repository, func(record T) error {
+		if err := encode(record); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+
+	return count, flush()
+}
+
+func importRecords[T any](ctx context.Context, repository ports.BulkRepository, s schema[T], kind Kind, format Format, r io.Reader, options Options) (Report, error) {
+	report := Report{Kind: kind}
+
+	next, err := newDecoder(s, format, r)
+	if err != nil {
+		return report, err
+	}
+
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	batch := make([]T, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		imported, err := s.write(ctx, repository, batch)
+		if err != nil {
+			return err
+		}
+
+		report.Imported += imported
+		report.Skipped += len(batch) - imported
+		batch = batch[:0]
+		if options.Progress != nil {
+			options.Progress(report)
+		}
+		return nil
+	}
+
+	for {
+		record, line, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var lineErr LineError
+		if errors.As(err, &lineErr) {
+			report.Read++
+			report.fail(lineErr)
+			continue
+		}
+		if err != nil {
+			return report, err
+		}
+
+		report.Read++
+		if err := s.validate(record); err != nil {
+			report.fail(LineError{Line: line, Err: err})
+			continue
+		}
+
+		batch = append(batch, record)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return report, err
+			}
+		}
+	}
+
+	return report, flush()
+}
+
+// newDecoder returns a function yielding the records of r with their line numbers.
+// It returns a LineError for a record that cannot be parsed, which does not stop the
+// decoding, and io.EOF after the last record.
+func newDecoder[T any](s schema[T], format Format, r io.Reader) (func() (T, int, error), error) {
+	switch format {
+	case FormatJSONL:
+		return newJSONLDecoder[T](r), nil
+	case FormatCSV:
+		return newCSVDecoder(s, r)
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+func newJSONLDecoder[T any](r io.Reader) func() (T, int, error) {
+	reader := newLineReader(r)
+
+	return func() (T, int, error) {
+		var record T
+
+		line, text, err := reader.next()
+		if err != nil {
+			return record, line, err
+		}
+
+		if err := json.Unmarshal(text, &record); err != nil {
+			return record, line, LineError{Line: line, Err: err}
+		}
+		return record, line, nil
+	}
+}
+
+// newCSVDecoder reads the header row first and maps columns by name, so they may
+// come in any order. Missing optional columns read as empty.
+func newCSVDecoder[T any](s schema[T], r io.Reader) (func() (T, int, error), error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return func() (T, int, error) {
+			var record T
+			return record, 0, io.EOF
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+
+	columns := make([]int, len(s.header))
+	for i, name := range s.header {
+		columns[i] = -1
+		for j, column := range header {
+			if strings.EqualFold(strings.TrimSpace(column), name) {
+				columns[i] = j
+			}
+		}
+		if columns[i] < 0 && !optionalColumn(name) {
+			return nil, fmt.Errorf("CSV header has no %q column", name)
+		}
+	}
+
+	fields := make([]string, len(s.header))
+	return func() (T, int, error) {
+		var record T
+
+		row, err := reader.Read()
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return record, parseErr.StartLine, LineError{Line: parseErr.StartLine, Err: parseErr.Err}
+		}
+		if err != nil {
+			return record, 0, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		for i, column := range columns {
+			fields[i] = ""
+			if column >= 0 && column < len(row) {
+				fields[i] = row[column]
+			}
+		}
+
+		record, err = s.parse(fields)
+		if err != nil {
+			return record, line, LineError{Line: line, Err: err}
+		}
+		return record, line, nil
+	}, nil
+}
+
+// optionalColumn reports whether a CSV column may be left out of the header.
+func optionalColumn(name string) bool {
+	return name == "protected"
+}
+
+// lineReader yields the non-blank lines of r with their 1-based numbers.
+type lineReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+	return &lineReader{scanner: scanner}
+}
+
+func (lr *lineReader) next() (int, []byte, error) {
+	for lr.scanner.Scan() {
+		lr.line++
+		if text := bytes.TrimSpace(lr.scanner.Bytes()); len(text) > 0 {
+			return lr.line, text, nil
+		}
+	}
+	if err := lr.scanner.Err(); err != nil {
+		return lr.line + 1, nil, fmt.Errorf("line %d: %w", lr.line+1, err)
+	}
+	return lr.line, nil, io.EOF
+}
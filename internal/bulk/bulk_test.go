@@ -0,0 +1,186 @@
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/repositories/in_memory_db"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSource(t *testing.T) (*in_memory_db.InMemoryDB, []uuid.UUID) {
+	ctx := context.Background()
+	db := in_memory_db.NewInMemoryDB()
+
+	ann, err := db.CreateUser(ctx, domain.User{Name: "Ann", Email: "ann@example.com", Protected: true})
+	assert.NoError(t, err)
+	bob, err := db.CreateUser(ctx, domain.User{Name: "Bob, Jr.", Email: "bob@example.com"})
+	assert.NoError(t, err)
+	assert.NoError(t, db.FollowUser(ctx, ann.ID, bob.ID))
+	assert.NoError(t, db.FollowUser(ctx, bob.ID, ann.ID))
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)
+	_, err = db.CreateTweet(ctx, domain.Tweet{UserID: ann.ID, Message: "hello \"world\"\nsecond line", CreatedAt: createdAt})
+	assert.NoError(t, err)
+	_, err = db.CreateTweet(ctx, domain.Tweet{UserID: bob.ID, Message: "<b>hi</b>", CreatedAt: createdAt.Add(time.Minute)})
+	assert.NoError(t, err)
+
+	return db, []uuid.UUID{ann.ID, bob.ID}
+}
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatJSONL, FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			ctx := context.Background()
+			source, ids := newSource(t)
+			target := in_memory_db.NewInMemoryDB()
+
+			for _, kind := range Kinds {
+				var buf bytes.Buffer
+				exported, err := Export(ctx, source, kind, format, &buf)
+				assert.NoError(t, err)
+				assert.Equal(t, 2, exported)
+				data := buf.String()
+
+				report, err := Import(ctx, target, kind, format, strings.NewReader(data), Options{})
+				assert.NoError(t, err)
+				assert.Equal(t, Report{Kind: kind, Read: 2, Imported: 2}, report)
+
+				// A second run finds everything in place.
+				report, err = Import(ctx, target, kind, format, strings.NewReader(data), Options{})
+				assert.NoError(t, err)
+				assert.Equal(t, Report{Kind: kind, Read: 2, Skipped: 2}, report)
+			}
+
+			for _, id := range ids {
+				want, _ := source.GetUser(ctx, id)
+				got, err := target.GetUser(ctx, id)
+				assert.NoError(t, err)
+				assert.Equal(t, want, got)
+			}
+		})
+	}
+}
+
+func TestExport_CSV(t *testing.T) {
+	ctx := context.Background()
+	db := in_memory_db.NewInMemoryDB()
+	id := uuid.MustParse("3f2d9c1a-5b4e-4c1d-9f8a-7e6d5c4b3a21")
+	_, err := db.ImportUsers(ctx, []domain.User{{ID: id, Name: "Bob, Jr.", Email: "bob@example.com"}})
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = Export(ctx, db, KindUsers, FormatCSV, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "id,name,email,protected\n"+id.String()+",\"Bob, Jr.\",bob@example.com,false\n", buf.String())
+
+	buf.Reset()
+	_, err = Export(ctx, db, KindUsers, FormatJSONL, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":"`+id.String()+`","name":"Bob, Jr.","email":"bob@example.com","protected":false}`+"\n", buf.String())
+}
+
+func TestImport_ReportsBadRecords(t *testing.T) {
+	ctx := context.Background()
+	db := in_memory_db.NewInMemoryDB()
+	ann := uuid.New()
+	_, err := db.ImportUsers(ctx, []domain.User{{ID: ann, Name: "Ann", Email: "ann@example.com"}})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		errors []string
+	}{
+		{
+			name:   "JSON lines",
+			format: FormatJSONL,
+			input: `{"id":"` + uuid.NewString() + `","user_id":"` + ann.String() + `","message":"ok","created_at":"2024-01-02T03:04:05Z"}
+
+not json
+{"id":"` + uuid.NewString() + `","user_id":"` + ann.String() + `","message":"","created_at":"2024-01-02T03:04:05Z"}
+{"id":"` + uuid.NewString() + `","user_id":"` + uuid.NewString() + `","message":"unknown user","created_at":"2024-01-02T03:04:05Z"}
+`,
+			errors: []string{
+				"line 3: invalid character 'o' in literal null (expecting 'u')",
+				"line 4: message is required",
+			},
+		},
+		{
+			name:   "CSV with reordered columns",
+			format: FormatCSV,
+			input: "user_id,id,created_at,message\n" +
+				ann.String() + "," + uuid.NewString() + ",2024-01-02T03:04:05Z,ok\n" +
+				ann.String() + ",not-a-uuid,2024-01-02T03:04:05Z,bad id\n" +
+				ann.String() + "," + uuid.NewString() + ",yesterday,bad date\n" +
+				uuid.NewString() + "," + uuid.NewString() + ",2024-01-02T03:04:05Z,unknown user\n",
+			errors: []string{
+				"line 3: id: invalid UUID length: 10",
+				`line 4: created_at: parsing time "yesterday" as "2006-01-02T15:04:05.999999999Z07:00": cannot parse "yesterday" as "2006"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Import(ctx, db, KindTweets, tt.format, strings.NewReader(tt.input), Options{})
+			assert.NoError(t, err)
+
+			assert.Equal(t, 4, report.Read)
+			assert.Equal(t, 1, report.Imported)
+			assert.Equal(t, 1, report.Skipped)
+			assert.Equal(t, 2, report.Failed)
+
+			var got []string
+			for _, lineErr := range report.Errors {
+				got = append(got, lineErr.Error())
+			}
+			assert.Equal(t, tt.errors, got)
+		})
+	}
+}
+
+func TestImport_CSVMissingColumn(t *testing.T) {
+	_, err := Import(context.Background(), in_memory_db.NewInMemoryDB(), KindFollows, FormatCSV, strings.NewReader("follower_id\n"), Options{})
+	assert.EqualError(t, err, `CSV header has no "followed_id" column`)
+}
+
+func TestImport_ProgressPerBatch(t *testing.T) {
+	ctx := context.Background()
+	var input strings.Builder
+	for i := 0; i < 5; i++ {
+		input.WriteString(`{"id":"` + uuid.NewString() + `","name":"user","email":"user@example.com"}` + "\n")
+	}
+
+	var progress []int
+	report, err := Import(ctx, in_memory_db.NewInMemoryDB(), KindUsers, FormatJSONL, strings.NewReader(input.String()), Options{
+		BatchSize: 2,
+		Progress:  func(report Report) { progress = append(progress, report.Imported) },
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, report.Imported)
+	assert.Equal(t, []int{2, 4, 5}, progress)
+}
+
+type failingRepository struct {
+	*in_memory_db.InMemoryDB
+}
+
+func (failingRepository) ImportUsers(ctx context.Context, users []domain.User) (int, error) {
+	return 0, errors.New("connection lost")
+}
+
+func TestImport_StopsOnRepositoryError(t *testing.T) {
+	input := `{"id":"` + uuid.NewString() + `","name":"user","email":"user@example.com"}` + "\n"
+	report, err := Import(context.Background(), failingRepository{in_memory_db.NewInMemoryDB()}, KindUsers, FormatJSONL, strings.NewReader(input), Options{})
+	assert.EqualError(t, err, "connection lost")
+	assert.Equal(t, 1, report.Read)
+	assert.Equal(t, 0, report.Imported)
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrUserBlocked is returned when an interaction is rejected because one of the users blocked the other.
+	ErrUserBlocked = errors.New("user is blocked")
+	// ErrSelfRelationship is returned when a user tries to block or mute themselves.
+	ErrSelfRelationship = errors.New("users cannot block or mute themselves")
+	// ErrFollowRequestNotFound is returned when approving or rejecting a follow request that is not pending.
+	ErrFollowRequestNotFound = errors.New("follow request not found")
+	// ErrTweetNotFound is returned when a tweet does not exist or is not visible to the viewer.
+	ErrTweetNotFound = errors.New("tweet not found")
+	// ErrTweetsProtected is returned when listing the tweets of a protected account the viewer may not see.
+	ErrTweetsProtected = errors.New("tweets are protected")
+	// ErrWebhookNotFound is returned when a webhook subscription does not exist.
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrWebhookDeliveryNotFound is returned when a webhook delivery does not exist.
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrInvalidWebhook is returned when a webhook subscription has a bad URL or event list.
+	ErrInvalidWebhook = errors.New("invalid webhook")
+)
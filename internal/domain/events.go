@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventTypeUserCreated  EventType = "user.created"
+	EventTypeTweetCreated EventType = "tweet.created"
+	EventTypeUserFollowed EventType = "user.followed"
+)
+
+// EventTypes lists every domain event type.
+var EventTypes = []EventType{EventTypeUserCreated, EventTypeTweetCreated, EventTypeUserFollowed}
+
+// IsKnownEventType reports whether eventType is one of EventTypes.
+func IsKnownEventType(eventType EventType) bool {
+	for _, t := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is a domain event recorded in the outbox together with the write that
+// produced it. Payload holds the JSON encoding of the type-specific struct below.
+// Attempts counts the dispatches that failed so far; the next one is due at
+// NextAttemptAt, and DeadAt is set once the dispatcher gives up on the event.
+type Event struct {
+	ID            uuid.UUID       `json:"id"`
+	Type          EventType       `json:"type"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Attempts      int             `json:"attempts,omitempty"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	DeadAt        *time.Time      `json:"dead_at,omitempty"`
+}
+
+// UserCreated leaves the email out: events reach webhooks, outside the API.
+type UserCreated struct {
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+}
+
+type TweetCreated struct {
+	TweetID uuid.UUID `json:"tweet_id"`
+	UserID  uuid.UUID `json:"user_id"`
+	Message string    `json:"message"`
+}
+
+type UserFollowed struct {
+	FollowerID uuid.UUID `json:"follower_id"`
+	FollowedID uuid.UUID `json:"followed_id"`
+}
+
+func NewUserCreatedEvent(user User) Event {
+	return newEvent(EventTypeUserCreated, UserCreated{UserID: user.ID, Name: user.Name})
+}
+
+func NewTweetCreatedEvent(tweet Tweet) Event {
+	return newEvent(EventTypeTweetCreated, TweetCreated{TweetID: tweet.ID, UserID: tweet.UserID, Message: tweet.Message})
+}
+
+func NewUserFollowedEvent(followerID, followedID uuid.UUID) Event {
+	return newEvent(EventTypeUserFollowed, UserFollowed{FollowerID: followerID, FollowedID: followedID})
+}
+
+// DecodePayload unmarshals the payload into the struct matching the event type.
+func (e Event) DecodePayload(v any) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+func newEvent(eventType EventType, payload any) Event {
+	// The payload structs only hold strings and UUIDs, so encoding cannot fail.
+	data, _ := json.Marshal(payload)
+
+	now := time.Now().UTC()
+	return Event{
+		ID:            uuid.New(),
+		Type:          eventType,
+		Payload:       data,
+		OccurredAt:    now,
+		NextAttemptAt: now,
+	}
+}
@@ -0,0 +1,9 @@
+package domain
+
+// FollowStatus tells whether a follow took effect immediately or is waiting for approval.
+type FollowStatus string
+
+const (
+	FollowStatusFollowing FollowStatus = "following"
+	FollowStatusPending   FollowStatus = "pending"
+)
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationType string
+
+const (
+	NotificationTypeFollow        NotificationType = "follow"
+	NotificationTypeFollowRequest NotificationType = "follow_request"
+	NotificationTypeMention       NotificationType = "mention"
+	NotificationTypeReply         NotificationType = "reply"
+	NotificationTypeLike          NotificationType = "like"
+)
+
+// Notification tells UserID that ActorID did something involving them.
+// TweetID is set for tweet-related events (mentions, replies, likes).
+type Notification struct {
+	ID        uuid.UUID        `json:"id"`
+	UserID    uuid.UUID        `json:"user_id"`
+	ActorID   uuid.UUID        `json:"actor_id"`
+	Type      NotificationType `json:"type"`
+	TweetID   *uuid.UUID       `json:"tweet_id,omitempty"`
+	Read      bool             `json:"read"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// NotificationGroup folds similar notifications together, e.g. "3 people followed you".
+type NotificationGroup struct {
+	Type            NotificationType
+	TweetID         *uuid.UUID
+	ActorIDs        []uuid.UUID
+	NotificationIDs []uuid.UUID
+	Unread          bool
+	LatestAt        time.Time
+}
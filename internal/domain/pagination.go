@@ -0,0 +1,28 @@
+package domain
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// Pagination describes a window over an ordered collection.
+type Pagination struct {
+	Limit  int
+	Offset int
+}
+
+// NewPagination returns a Pagination with the limit clamped to [1, MaxPageLimit]
+// and a non-negative offset.
+func NewPagination(limit, offset int) Pagination {
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return Pagination{Limit: limit, Offset: offset}
+}
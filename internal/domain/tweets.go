@@ -1,9 +1,35 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Tweet struct {
-	ID      uuid.UUID `json:"id"`
-	UserID  uuid.UUID `json:"user_id"`
-	Message string    `json:"message" validate:"max=280"`
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Message   string    `json:"message" validate:"max=280"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// mentionPattern matches mentions written as "@<user id>".
+var mentionPattern = regexp.MustCompile(`@([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})`)
+
+// MentionedUserIDs returns the distinct users mentioned in the tweet, in order of appearance.
+func (t Tweet) MentionedUserIDs() []uuid.UUID {
+	var ids []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(t.Message, -1) {
+		id, err := uuid.Parse(match[1])
+		if err != nil || seen[id] {
+			continue
+		}
+
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	return ids
 }
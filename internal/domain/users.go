@@ -2,11 +2,21 @@ package domain
 
 import "github.com/google/uuid"
 
+// User is a profile. Its followers, followings and tweets are read page by page
+// through their own repository methods; the counters are kept up to date on write
+// so reading a profile does not load them.
 type User struct {
-	ID        uuid.UUID   `json:"id"`
-	Name      string      `json:"name"`
-	Email     string      `json:"email"`
-	Followers []uuid.UUID `json:"followers"`
-	Follwing  []uuid.UUID `json:"following"`
-	Tweets    []Tweet     `json:"tweets"`
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	Email          string    `json:"email"`
+	Protected      bool      `json:"protected"`
+	FollowersCount int       `json:"followers_count"`
+	FollowingCount int       `json:"following_count"`
+	TweetsCount    int       `json:"tweets_count"`
+}
+
+// Follow is an edge of the follow graph: FollowerID follows FollowedID.
+type Follow struct {
+	FollowerID uuid.UUID `json:"follower_id"`
+	FollowedID uuid.UUID `json:"followed_id"`
 }
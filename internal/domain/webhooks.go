@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"encoding/json"
+	"net/netip"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook is an external endpoint subscribed to some event types. Deliveries are
+// signed with Secret so the receiver can check they come from us. The tweets of
+// protected accounts are only sent to webhooks with IncludeProtected set.
+type Webhook struct {
+	ID               uuid.UUID   `json:"id"`
+	URL              string      `json:"url"`
+	Events           []EventType `json:"events"`
+	IncludeProtected bool        `json:"include_protected"`
+	Secret           string      `json:"-"`
+	CreatedAt        time.Time   `json:"created_at"`
+}
+
+// Subscribes reports whether the webhook wants events of the given type.
+func (w Webhook) Subscribes(eventType EventType) bool {
+	for _, t := range w.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryDead marks a delivery that ran out of retries: the dead-letter list.
+	WebhookDeliveryDead WebhookDeliveryStatus = "dead"
+)
+
+// WebhookDelivery is one event sent to one webhook, with the outcome of its last attempt.
+// Payload is the exact request body, so every retry is signed over the same bytes.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	WebhookID      uuid.UUID             `json:"webhook_id"`
+	EventID        uuid.UUID             `json:"event_id"`
+	EventType      EventType             `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	LastStatusCode int                   `json:"last_status_code"`
+	LastError      string                `json:"last_error"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// IsPublicAddress reports whether webhooks may be delivered to addr. Loopback,
+// link-local, private and other non-global addresses belong to the network the API
+// runs in, which a webhook URL must not reach.
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate()
+}
@@ -0,0 +1,323 @@
+// Package loadgen drives mixed read/write traffic against the HTTP API and reports
+// latency percentiles per operation.
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Operation string
+
+const (
+	OperationReadTimeline Operation = "read_timeline"
+	OperationReadUser     Operation = "read_user"
+	OperationPostTweet    Operation = "post_tweet"
+	OperationFollow       Operation = "follow"
+)
+
+// Operations lists every operation in report order.
+var Operations = []Operation{OperationReadTimeline, OperationReadUser, OperationPostTweet, OperationFollow}
+
+// Mix weights how often each operation is picked. Operations left out never run.
+type Mix map[Operation]int
+
+var DefaultMix = Mix{
+	OperationReadTimeline: 60,
+	OperationReadUser:     20,
+	OperationPostTweet:    15,
+	OperationFollow:       5,
+}
+
+// ParseMix reads a mix written as "read_timeline=60,post_tweet=40".
+func ParseMix(s string) (Mix, error) {
+	mix := Mix{}
+	for _, part := range strings.Split(s, ",") {
+		name, weight, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("mix entry %q must be operation=weight", part)
+		}
+
+		var w int
+		if _, err := fmt.Sscan(weight, &w); err != nil || w < 0 {
+			return nil, fmt.Errorf("weight of %s must be a non-negative number, got %q", name, weight)
+		}
+
+		known := false
+		for _, operation := range Operations {
+			known = known || operation == Operation(name)
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown operation %q", name)
+		}
+
+		mix[Operation(name)] = w
+	}
+
+	return mix, nil
+}
+
+// Config describes a load run.
+type Config struct {
+	// BaseURL is where the API is served, e.g. http://localhost:8080.
+	BaseURL     string
+	Duration    time.Duration
+	Concurrency int
+	Mix         Mix
+	// UserIDs are the accounts traffic is generated for, e.g. the ones written by the
+	// seeder. When empty, Users accounts are created through the API before the run.
+	UserIDs []uuid.UUID
+	Users   int
+	Seed    int64
+}
+
+// Stats are the latencies of one operation. Requests answered with a status of 400
+// or above count as errors and are left out of the percentiles.
+type Stats struct {
+	Operation Operation
+	Count     int
+	Errors    int
+	P50       time.Duration
+	P90       time.Duration
+	P99       time.Duration
+	Max       time.Duration
+}
+
+type Report struct {
+	Duration   time.Duration
+	Operations []Stats
+}
+
+// Write prints the report as a table.
+func (r Report) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "operation\tcount\terrors\trps\tp50\tp90\tp99\tmax\t\n")
+	for _, stats := range r.Operations {
+		rps := float64(stats.Count) / r.Duration.Seconds()
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%v\t%v\t%v\t%v\t\n", stats.Operation, stats.Count, stats.Errors, rps,
+			stats.P50.Round(time.Microsecond), stats.P90.Round(time.Microsecond), stats.P99.Round(time.Microsecond), stats.Max.Round(time.Microsecond))
+	}
+	return tw.Flush()
+}
+
+// sample is the outcome of one request.
+type sample struct {
+	operation Operation
+	latency   time.Duration
+	failed    bool
+}
+
+type runner struct {
+	client  *http.Client
+	baseURL string
+	userIDs []uuid.UUID
+}
+
+// Run sends requests from Concurrency workers until Duration elapses or ctx is cancelled.
+func Run(ctx context.Context, client *http.Client, config Config) (Report, error) {
+	total := 0
+	for _, weight := range config.Mix {
+		total += weight
+	}
+	if total == 0 {
+		return Report{}, fmt.Errorf("the mix needs at least one operation with a positive weight")
+	}
+	if config.Concurrency < 1 {
+		return Report{}, fmt.Errorf("concurrency must be at least 1, got %d", config.Concurrency)
+	}
+
+	rn := &runner{client: client, baseURL: strings.TrimSuffix(config.BaseURL, "/"), userIDs: config.UserIDs}
+	if len(rn.userIDs) == 0 {
+		if err := rn.createUsers(ctx, config.Users); err != nil {
+			return Report{}, err
+		}
+	}
+	if len(rn.userIDs) < 2 {
+		return Report{}, fmt.Errorf("at least 2 users are needed, got %d", len(rn.userIDs))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.Duration)
+	defer cancel()
+
+	samples := make([][]sample, config.Concurrency)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := range samples {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			samples[i] = rn.work(ctx, rand.New(rand.NewSource(config.Seed+int64(i))), config.Mix, total)
+		}(i)
+	}
+	wg.Wait()
+
+	return report(time.Since(start), samples), nil
+}
+
+func (rn *runner) createUsers(ctx context.Context, n int) error {
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("load%06d", i)
+		var created struct {
+			Data struct {
+				ID uuid.UUID `json:"id"`
+			} `json:"data"`
+		}
+
+		status, err := rn.do(ctx, http.MethodPost, "/api/v1/users", uuid.Nil, map[string]string{"name": name, "email": name + "@load.example"}, &created)
+		if err != nil {
+			return err
+		}
+		if status >= http.StatusBadRequest {
+			return fmt.Errorf("creating user %s: status %d", name, status)
+		}
+
+		rn.userIDs = append(rn.userIDs, created.Data.ID)
+	}
+	return nil
+}
+
+// work sends requests until ctx is done. Requests cut short by the end of the run
+// are not recorded.
+func (rn *runner) work(ctx context.Context, r *rand.Rand, mix Mix, total int) []sample {
+	var samples []sample
+	for ctx.Err() == nil {
+		operation := pick(r, mix, total)
+		userID := rn.userIDs[r.Intn(len(rn.userIDs))]
+
+		var method, path string
+		var body any
+		switch operation {
+		case OperationReadTimeline:
+			method, path = http.MethodGet, fmt.Sprintf("/api/v1/users/%s/timeline", userID)
+		case OperationReadUser:
+			method, path = http.MethodGet, fmt.Sprintf("/api/v1/users/%s", rn.userIDs[r.Intn(len(rn.userIDs))])
+		case OperationPostTweet:
+			method, path = http.MethodPost, fmt.Sprintf("/api/v1/users/%s/tweet", userID)
+			body = map[string]string{"message": fmt.Sprintf("load test tweet %d", r.Int63())}
+		case OperationFollow:
+			followedID := rn.userIDs[r.Intn(len(rn.userIDs))]
+			for followedID == userID {
+				followedID = rn.userIDs[r.Intn(len(rn.userIDs))]
+			}
+			method, path = http.MethodPost, fmt.Sprintf("/api/v1/users/%s/follow/%s", userID, followedID)
+		}
+
+		start := time.Now()
+		status, err := rn.do(ctx, method, path, userID, body, nil)
+		latency := time.Since(start)
+		if ctx.Err() != nil {
+			break
+		}
+
+		samples = append(samples, sample{operation: operation, latency: latency, failed: err != nil || status >= http.StatusBadRequest})
+	}
+	return samples
+}
+
+// do sends the request as viewerID (unless it is uuid.Nil) and decodes the response
+// into out when it is not nil.
+func (rn *runner) do(ctx context.Context, method, path string, viewerID uuid.UUID, body any, out any) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, rn.baseURL+path, reader)
+	if err != nil {
+		return 0, err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if viewerID != uuid.Nil {
+		request.Header.Set("X-User-ID", viewerID.String())
+	}
+
+	response, err := rn.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if out != nil {
+		return response.StatusCode, json.NewDecoder(response.Body).Decode(out)
+	}
+
+	// Read the body so the connection is reused and the latency covers it.
+	_, err = io.Copy(io.Discard, response.Body)
+	return response.StatusCode, err
+}
+
+func pick(r *rand.Rand, mix Mix, total int) Operation {
+	n := r.Intn(total)
+	for _, operation := range Operations {
+		if n < mix[operation] {
+			return operation
+		}
+		n -= mix[operation]
+	}
+	// Unreachable while total is the sum of the weights.
+	return Operations[0]
+}
+
+func report(elapsed time.Duration, samples [][]sample) Report {
+	latencies := make(map[Operation][]time.Duration)
+	errors := make(map[Operation]int)
+	counts := make(map[Operation]int)
+	for _, worker := range samples {
+		for _, s := range worker {
+			counts[s.operation]++
+			if s.failed {
+				errors[s.operation]++
+				continue
+			}
+			latencies[s.operation] = append(latencies[s.operation], s.latency)
+		}
+	}
+
+	r := Report{Duration: elapsed}
+	for _, operation := range Operations {
+		if counts[operation] == 0 {
+			continue
+		}
+
+		sorted := latencies[operation]
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		stats := Stats{Operation: operation, Count: counts[operation], Errors: errors[operation]}
+		if len(sorted) > 0 {
+			stats.P50 = percentile(sorted, 50)
+			stats.P90 = percentile(sorted, 90)
+			stats.P99 = percentile(sorted, 99)
+			stats.Max = sorted[len(sorted)-1]
+		}
+		r.Operations = append(r.Operations, stats)
+	}
+
+	return r
+}
+
+// percentile returns the nearest-rank percentile p of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
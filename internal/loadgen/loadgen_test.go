@@ -0,0 +1,88 @@
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAPI creates users and answers every follow with 500.
+type fakeAPI struct {
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.requests[r.Method+" "+r.URL.Path]++
+	f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/users":
+		fmt.Fprintf(w, `{"message":"User created successfully","data":{"id":"%s"}}`, uuid.New())
+	case strings.Contains(r.URL.Path, "/follow/"):
+		w.WriteHeader(http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func TestRun(t *testing.T) {
+	api := &fakeAPI{requests: make(map[string]int)}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	report, err := Run(context.Background(), server.Client(), Config{
+		BaseURL:     server.URL,
+		Duration:    200 * time.Millisecond,
+		Concurrency: 4,
+		Mix:         Mix{OperationReadTimeline: 3, OperationFollow: 1},
+		Users:       5,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, api.requests["POST /api/v1/users"])
+
+	assert.Len(t, report.Operations, 2)
+	timeline, follow := report.Operations[0], report.Operations[1]
+
+	assert.Equal(t, OperationReadTimeline, timeline.Operation)
+	assert.Greater(t, timeline.Count, 0)
+	assert.Equal(t, 0, timeline.Errors)
+	assert.True(t, timeline.P50 > 0 && timeline.P50 <= timeline.P99 && timeline.P99 <= timeline.Max)
+
+	assert.Equal(t, OperationFollow, follow.Operation)
+	assert.Equal(t, follow.Count, follow.Errors)
+	assert.Greater(t, timeline.Count, follow.Count)
+}
+
+func TestParseMix(t *testing.T) {
+	mix, err := ParseMix("read_timeline=60, post_tweet=40")
+	assert.NoError(t, err)
+	assert.Equal(t, Mix{OperationReadTimeline: 60, OperationPostTweet: 40}, mix)
+
+	_, err = ParseMix("read_timeline")
+	assert.EqualError(t, err, `mix entry "read_timeline" must be operation=weight`)
+
+	_, err = ParseMix("delete_tweet=1")
+	assert.EqualError(t, err, `unknown operation "delete_tweet"`)
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	assert.Equal(t, 50*time.Millisecond, percentile(sorted, 50))
+	assert.Equal(t, 99*time.Millisecond, percentile(sorted, 99))
+	assert.Equal(t, 100*time.Millisecond, percentile(sorted, 100))
+	assert.Equal(t, 7*time.Millisecond, percentile([]time.Duration{7 * time.Millisecond}, 50))
+}
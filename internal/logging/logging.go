@@ -0,0 +1,83 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// RedactedEmail replaces the email addresses found in logged values.
+const RedactedEmail = "[redacted email]"
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+type loggerKey struct{}
+
+// New returns a logger writing JSON lines of level or above to w, with the email
+// addresses in the logged values redacted.
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level, ReplaceAttr: redact}))
+}
+
+// ParseLevel parses a level name: debug, info, warn or error.
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q, expected debug, info, warn or error", name)
+	}
+
+	return level, nil
+}
+
+// WithLogger returns a copy of ctx carrying logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, which holds the attributes of
+// the request being served, or the default logger when there is none.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+// redact replaces the email addresses in the value of attr. Values that are not
+// strings or errors, such as structs and maps, are logged as their JSON encoding
+// so the addresses in their fields are found too.
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	switch value := attr.Value.Resolve(); value.Kind() {
+	case slog.KindString:
+		attr.Value = slog.StringValue(redactEmails(value.String()))
+	case slog.KindAny:
+		switch v := value.Any().(type) {
+		case error:
+			attr.Value = slog.StringValue(redactEmails(v.Error()))
+		case fmt.Stringer:
+			attr.Value = slog.StringValue(redactEmails(v.String()))
+		default:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				attr.Value = slog.StringValue(redactEmails(fmt.Sprintf("%+v", v)))
+				break
+			}
+			attr.Value = slog.AnyValue(json.RawMessage(redactEmails(string(encoded))))
+		}
+	}
+
+	return attr
+}
+
+func redactEmails(s string) string {
+	if !strings.Contains(s, "@") {
+		return s
+	}
+
+	return emailPattern.ReplaceAllString(s, RedactedEmail)
+}
@@ -0,0 +1,127 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// decodeLines returns the JSON lines written to logs.
+func decodeLines(t *testing.T, logs *bytes.Buffer) []map[string]any {
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var decoded map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &decoded), line)
+		lines = append(lines, decoded)
+	}
+
+	return lines
+}
+
+func TestNew_RedactsEmails(t *testing.T) {
+	var logs bytes.Buffer
+	logger := New(&logs, slog.LevelInfo)
+
+	user := domain.User{ID: uuid.New(), Name: "alice", Email: "alice@example.com"}
+	logger.Info("created",
+		"email", "alice@example.com",
+		"message", "invite bob.smith+tag@mail.example.org please",
+		"error", errors.New("duplicate email carol@example.com"),
+		slog.Group("request", "body", map[string]string{"email": "dave@example.com"}),
+		"user", user,
+		"count", 3,
+	)
+	logger.Debug("not logged")
+
+	lines := decodeLines(t, &logs)
+	assert.Len(t, lines, 1)
+	line := lines[0]
+	assert.Equal(t, RedactedEmail, line["email"])
+	assert.Equal(t, "invite "+RedactedEmail+" please", line["message"])
+	assert.Equal(t, "duplicate email "+RedactedEmail, line["error"])
+	assert.Equal(t, map[string]any{"body": map[string]any{"email": RedactedEmail}}, line["request"])
+	assert.Equal(t, RedactedEmail, line["user"].(map[string]any)["email"])
+	assert.Equal(t, "alice", line["user"].(map[string]any)["name"])
+	assert.Equal(t, float64(3), line["count"])
+	assert.NotContains(t, logs.String(), "example.com\"")
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("warn")
+	assert.NoError(t, err)
+	assert.Equal(t, slog.LevelWarn, level)
+
+	_, err = ParseLevel("verbose")
+	assert.Error(t, err)
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, slog.Default(), FromContext(context.Background()))
+
+	logger := New(&bytes.Buffer{}, slog.LevelInfo)
+	assert.Equal(t, logger, FromContext(WithLogger(context.Background(), logger)))
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		requestID string
+		keepsID   bool
+	}{
+		{name: "Keeps the caller's request ID", requestID: "req-123", keepsID: true},
+		{name: "Assigns a request ID when there is none"},
+		{name: "Replaces an invalid request ID", requestID: "has spaces\ninside"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			router := gin.New()
+			router.ContextWithFallback = true
+			router.Use(Middleware(New(&logs, slog.LevelInfo)))
+			router.GET("/users/:id", func(c *gin.Context) {
+				// Handlers and what they call log with the request's logger.
+				FromContext(c).Info("getting user", "id", c.Param("id"))
+				c.Status(http.StatusNotFound)
+			})
+
+			request := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+			if tc.requestID != "" {
+				request.Header.Set(RequestIDHeader, tc.requestID)
+			}
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, request)
+
+			requestID := response.Header().Get(RequestIDHeader)
+			if tc.keepsID {
+				assert.Equal(t, tc.requestID, requestID)
+			} else {
+				_, err := uuid.Parse(requestID)
+				assert.NoError(t, err)
+			}
+
+			lines := decodeLines(t, &logs)
+			assert.Len(t, lines, 2)
+			assert.Equal(t, "getting user", lines[0]["msg"])
+			assert.Equal(t, requestID, lines[0]["request_id"])
+			assert.Equal(t, "request handled", lines[1]["msg"])
+			assert.Equal(t, requestID, lines[1]["request_id"])
+			assert.Equal(t, "/users/:id", lines[1]["route"])
+			assert.Equal(t, "/users/42", lines[1]["path"])
+			assert.Equal(t, float64(http.StatusNotFound), lines[1]["status"])
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader carries the ID of a request, from the caller or assigned by the API.
+const RequestIDHeader = "X-Request-ID"
+
+const maxRequestIDLength = 128
+
+// Middleware gives every request an ID, taken from the X-Request-ID header when
+// the caller sends a valid one and returned in the same header, and a logger with
+// that ID and the trace ID that the handlers, services and repositories get
+// through the request context. Once the request is handled it logs one line for it.
+// It must run after the tracing middleware so the trace ID is known.
+func Middleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		requestLogger := logger.With(slog.String("request_id", requestID))
+		if spanContext := trace.SpanContextFromContext(c.Request.Context()); spanContext.HasTraceID() {
+			requestLogger = requestLogger.With(slog.String("trace_id", spanContext.TraceID().String()))
+		}
+		c.Request = c.Request.WithContext(WithLogger(c.Request.Context(), requestLogger))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		if status >= 500 {
+			level = slog.LevelError
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if handlerErrors := c.Errors.ByType(gin.ErrorTypePrivate).String(); handlerErrors != "" {
+			attrs = append(attrs, slog.String("errors", handlerErrors))
+		}
+		requestLogger.LogAttrs(c.Request.Context(), level, "request handled", attrs...)
+	}
+}
+
+// validRequestID accepts the IDs that are safe to echo and log: up to 128
+// printable ASCII characters, without spaces.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+// BulkRepository reads and writes whole data sets, to move them between backends.
+// Imports keep the given IDs and skip what is already stored, so re-running an
+// interrupted import is safe.
+type BulkRepository interface {
+	// ExportUsers calls fn for every user, ordered by ID, with only the profile
+	// fields set. It stops at the first error fn returns.
+	ExportUsers(ctx context.Context, fn func(domain.User) error) error
+	ExportFollows(ctx context.Context, fn func(domain.Follow) error) error
+	ExportTweets(ctx context.Context, fn func(domain.Tweet) error) error
+
+	// The import methods return how many records were written. Records that already
+	// exist, or that reference a user that does not, are skipped.
+	ImportUsers(ctx context.Context, users []domain.User) (int, error)
+	ImportFollows(ctx context.Context, follows []domain.Follow) (int, error)
+	ImportTweets(ctx context.Context, tweets []domain.Tweet) (int, error)
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// Cache is a key-value store of encoded values that expire after a TTL, the
+// operations a Redis-compatible server offers as GET, SET EX and DEL.
+type Cache interface {
+	// Get returns the value stored under key, and false when it is missing or expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+type NotificationsRepository interface {
+	CreateNotification(ctx context.Context, notification domain.Notification) (domain.Notification, error)
+	// GetNotifications returns the user's notifications, newest first.
+	GetNotifications(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.Notification, error)
+	CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error)
+	// MarkNotificationsRead marks the given notifications as read, or all of them when ids is empty.
+	MarkNotificationsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error
+}
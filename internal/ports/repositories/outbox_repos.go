@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"time"
+)
+
+// OutboxRepository reads back the events stored by the write methods of the other
+// repositories so they can be relayed to subscribers.
+type OutboxRepository interface {
+	// GetPendingEvents returns up to limit events that are neither dispatched nor dead
+	// and whose next attempt is at or before now, the earliest due first. Inside a unit
+	// of work they stay locked until it ends, and events locked by another unit of work
+	// are skipped, so concurrent dispatchers never get the same event.
+	GetPendingEvents(ctx context.Context, now time.Time, limit int) ([]domain.Event, error)
+	MarkEventsDispatched(ctx context.Context, ids []uuid.UUID) error
+	// MarkEventFailed stores the attempts, next attempt and dead-letter time of event.
+	MarkEventFailed(ctx context.Context, event domain.Event) error
+}
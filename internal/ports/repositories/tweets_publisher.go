@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+// TweetPublisher fans newly created tweets out to live subscribers.
+// Publishing must not block the caller on slow subscribers.
+type TweetPublisher interface {
+	PublishTweet(ctx context.Context, tweet domain.Tweet)
+}
@@ -2,9 +2,13 @@ package ports
 
 import (
 	"context"
+	"github.com/google/uuid"
 	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
 )
 
 type TweetRepository interface {
-	CreateTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, error)
+	CreateTweet(ctx context.Context, tweet domain.Tweet, events ...domain.Event) (domain.Tweet, error)
+	GetTweet(ctx context.Context, id uuid.UUID) (domain.Tweet, error)
+	// GetUserTweets returns the tweets of the user, newest first.
+	GetUserTweets(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.Tweet, error)
 }
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+)
+
+// UnitOfWork runs several repository calls atomically.
+type UnitOfWork interface {
+	// Do calls fn with a context bound to a new transaction. The repository calls fn
+	// makes with that context are committed together when fn returns nil and rolled
+	// back when it returns an error, which Do then returns. Calling Do again with the
+	// transaction context nests a unit of work that can roll back on its own.
+	// The transaction context must not be shared between goroutines.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
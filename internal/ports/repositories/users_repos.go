@@ -6,9 +6,32 @@ import (
 	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
 )
 
+// The write methods that take events store them in the outbox atomically with the write.
 type UsersRepository interface {
-	CreateUser(ctx context.Context, user domain.User) (domain.User, error)
+	CreateUser(ctx context.Context, user domain.User, events ...domain.Event) (domain.User, error)
+	// GetUser returns the profile with its counters, in a single read.
 	GetUser(ctx context.Context, id uuid.UUID) (domain.User, error)
-	FollowUser(ctx context.Context, userID uuid.UUID, followedID uuid.UUID) error
+	// GetUsers returns the profiles of the ids that exist, in no particular order.
+	GetUsers(ctx context.Context, ids []uuid.UUID) ([]domain.User, error)
+	// FollowUser does nothing, events included, when userID already follows followedID.
+	FollowUser(ctx context.Context, userID uuid.UUID, followedID uuid.UUID, events ...domain.Event) error
 	GetUserTimeline(ctx context.Context, userID uuid.UUID) ([]domain.Tweet, error)
+	// GetFollowers and GetFollowing order the users by name, then ID. An unknown
+	// user gets an empty list, as one with no follow edges.
+	GetFollowers(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error)
+	GetFollowing(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error)
+	IsFollowing(ctx context.Context, userID uuid.UUID, followedID uuid.UUID) (bool, error)
+	GetFollowedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	BlockUser(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error
+	UnblockUser(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error
+	IsBlocked(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) (bool, error)
+	GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	MuteUser(ctx context.Context, userID uuid.UUID, mutedID uuid.UUID) error
+	UnmuteUser(ctx context.Context, userID uuid.UUID, mutedID uuid.UUID) error
+	GetMutedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	SetProtected(ctx context.Context, userID uuid.UUID, protected bool) error
+	CreateFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID) error
+	GetFollowRequests(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error)
+	ApproveFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID, events ...domain.Event) error
+	RejectFollowRequest(ctx context.Context, followerID uuid.UUID, userID uuid.UUID) error
 }
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"time"
+)
+
+type WebhooksRepository interface {
+	CreateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error)
+	GetWebhook(ctx context.Context, id uuid.UUID) (domain.Webhook, error)
+	GetWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	// DeleteWebhook removes the webhook together with its deliveries.
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	// CreateDelivery queues a delivery. Queuing the same event for the same webhook
+	// again is a no-op, so redelivered events are not sent twice.
+	CreateDelivery(ctx context.Context, delivery domain.WebhookDelivery) error
+	UpdateDelivery(ctx context.Context, delivery domain.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id uuid.UUID) (domain.WebhookDelivery, error)
+	// GetDeliveries returns the webhook's deliveries, newest first, optionally only those with the given status.
+	GetDeliveries(ctx context.Context, webhookID uuid.UUID, status domain.WebhookDeliveryStatus, page domain.Pagination) ([]domain.WebhookDelivery, error)
+	// GetDueDeliveries returns up to limit pending deliveries whose next attempt is at or before now.
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error)
+}
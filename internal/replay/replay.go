@@ -0,0 +1,252 @@
+// Package replay runs scenarios of HTTP requests stored as JSON lines against the
+// API, either in-process or over the network, checking the status of every response.
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Record is one request of a scenario, one JSON object per line:
+//
+//	{"name":"create user","method":"POST","path":"/api/v1/users","body":{"name":"Ann"},"expect_status":200,"capture":{"user_id":"data.id"}}
+//	{"name":"get user","method":"GET","path":"/api/v1/users/{{user_id}}","expect_status":200}
+//
+// Values captured from earlier responses replace their {{name}} placeholders in the
+// path, the header values and the body.
+type Record struct {
+	Name    string            `json:"name"`
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+	// ExpectStatus is the status code the response must have; 0 accepts any.
+	ExpectStatus int `json:"expect_status,omitempty"`
+	// Capture maps variable names to dotted paths into the JSON response, e.g.
+	// "data.id" or "data.users.0.id".
+	Capture map[string]string `json:"capture,omitempty"`
+}
+
+// Result is the outcome of one record. Err is set when the record could not be
+// sent, its status was not the expected one or a capture failed.
+type Result struct {
+	// Step is the 1-based position of the record in the scenario.
+	Step     int
+	Record   Record
+	Status   int
+	Duration time.Duration
+	Err      error
+}
+
+var placeholder = regexp.MustCompile(`{{\s*([A-Za-z0-9_.-]+)\s*}}`)
+
+// Read parses a scenario. Blank lines are skipped.
+func Read(r io.Reader) ([]Record, error) {
+	var records []Record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var record Record
+		if err := json.Unmarshal(text, &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if record.Method == "" || record.Path == "" {
+			return nil, fmt.Errorf("line %d: method and path are required", line)
+		}
+		records = append(records, record)
+	}
+
+	return records, scanner.Err()
+}
+
+// Replayer sends the records of a scenario in order.
+type Replayer struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewRemote replays against the API served at baseURL, e.g. http://localhost:8080.
+func NewRemote(client *http.Client, baseURL string) *Replayer {
+	return &Replayer{
+		client:  client,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// NewInProcess replays against handler, usually the gin engine, without a network.
+func NewInProcess(handler http.Handler) *Replayer {
+	return &Replayer{
+		client:  &http.Client{Transport: handlerTransport{handler: handler}},
+		baseURL: "http://replay.local",
+	}
+}
+
+// handlerTransport serves requests by calling an http.Handler directly.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t handlerTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	t.handler.ServeHTTP(recorder, request)
+	return recorder.Result(), nil
+}
+
+// Replay sends the records in order and stops at the first failing one, since later
+// records usually depend on what it should have captured. The returned results end
+// with the failing record, whose error is also returned.
+func (r *Replayer) Replay(ctx context.Context, records []Record) ([]Result, error) {
+	variables := make(map[string]string)
+	results := make([]Result, 0, len(records))
+	for i, record := range records {
+		result := r.send(ctx, record, variables)
+		result.Step = i + 1
+		results = append(results, result)
+		if result.Err != nil {
+			return results, fmt.Errorf("step %d (%s): %w", result.Step, record.Name, result.Err)
+		}
+	}
+
+	return results, nil
+}
+
+func (r *Replayer) send(ctx context.Context, record Record, variables map[string]string) Result {
+	result := Result{Record: record}
+
+	path, err := substitute(record.Path, variables)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Record.Path = path
+
+	body, err := substitute(string(record.Body), variables)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	request, err := http.NewRequestWithContext(ctx, record.Method, r.baseURL+path, strings.NewReader(body))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if body != "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range record.Headers {
+		value, err := substitute(value, variables)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		request.Header.Set(name, value)
+	}
+
+	start := time.Now()
+	response, err := r.client.Do(request)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	result.Duration = time.Since(start)
+	result.Status = response.StatusCode
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	if record.ExpectStatus != 0 && response.StatusCode != record.ExpectStatus {
+		result.Err = fmt.Errorf("expected status %d, got %d: %s", record.ExpectStatus, response.StatusCode, bytes.TrimSpace(responseBody))
+		return result
+	}
+
+	if len(record.Capture) > 0 {
+		var decoded any
+		if err := json.Unmarshal(responseBody, &decoded); err != nil {
+			result.Err = fmt.Errorf("capturing from a response that is not JSON: %w", err)
+			return result
+		}
+
+		for name, path := range record.Capture {
+			value, err := lookup(decoded, path)
+			if err != nil {
+				result.Err = fmt.Errorf("capturing %s: %w", name, err)
+				return result
+			}
+			variables[name] = value
+		}
+	}
+
+	return result
+}
+
+// substitute replaces every {{name}} in s with its captured value.
+func substitute(s string, variables map[string]string) (string, error) {
+	var missing []string
+	replaced := placeholder.ReplaceAllStringFunc(s, func(match string) string {
+		name := placeholder.FindStringSubmatch(match)[1]
+		value, ok := variables[name]
+		if !ok {
+			missing = append(missing, name)
+		}
+		return value
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("undefined variables: %s", strings.Join(missing, ", "))
+	}
+	return replaced, nil
+}
+
+// lookup follows a dotted path of object keys and array indexes into a decoded JSON
+// value and returns the scalar it ends at as a string.
+func lookup(value any, path string) (string, error) {
+	for _, key := range strings.Split(path, ".") {
+		switch node := value.(type) {
+		case map[string]any:
+			next, ok := node[key]
+			if !ok {
+				return "", fmt.Errorf("%s: no field %q", path, key)
+			}
+			value = next
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return "", fmt.Errorf("%s: no element %q", path, key)
+			}
+			value = node[i]
+		default:
+			return "", fmt.Errorf("%s: cannot look up %q in a scalar", path, key)
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("%s: not a string, number or boolean", path)
+	}
+}
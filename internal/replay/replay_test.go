@@ -0,0 +1,113 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// echo answers 201 to POST /items with {"data":{"id":...,"tags":[...]}} and
+// echoes the path and X-Token header of any other request.
+var echo = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost && r.URL.Path == "/items" {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data":{"id":"item-1","count":3,"tags":["a","b"],"echo":` + string(body) + `}}`))
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"path": r.URL.Path, "token": r.Header.Get("X-Token")})
+})
+
+func TestRead(t *testing.T) {
+	records, err := Read(strings.NewReader(`{"name":"one","method":"GET","path":"/a","expect_status":200}
+
+{"name":"two","method":"POST","path":"/b","body":{"x":1}}
+`))
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "two", records[1].Name)
+	assert.JSONEq(t, `{"x":1}`, string(records[1].Body))
+
+	_, err = Read(strings.NewReader(`{"name":"no method","path":"/a"}`))
+	assert.EqualError(t, err, "line 1: method and path are required")
+
+	_, err = Read(strings.NewReader("{\"method\":\"GET\",\"path\":\"/a\"}\nnot json"))
+	assert.ErrorContains(t, err, "line 2:")
+}
+
+func TestReplayer_Replay(t *testing.T) {
+	records := []Record{
+		{Name: "create", Method: http.MethodPost, Path: "/items", Body: json.RawMessage(`{"name":"x"}`), ExpectStatus: http.StatusCreated,
+			Capture: map[string]string{"id": "data.id", "count": "data.count", "tag": "data.tags.1"}},
+		{Name: "use", Method: http.MethodGet, Path: "/items/{{id}}/{{ count }}", Headers: map[string]string{"X-Token": "t-{{tag}}"}, ExpectStatus: http.StatusOK,
+			Capture: map[string]string{"path": "path", "token": "token"}},
+		{Name: "check", Method: http.MethodGet, Path: "/done/{{token}}"},
+	}
+
+	server := httptest.NewServer(echo)
+	defer server.Close()
+
+	for name, replayer := range map[string]*Replayer{
+		"in-process": NewInProcess(echo),
+		"remote":     NewRemote(server.Client(), server.URL+"/"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			results, err := replayer.Replay(context.Background(), records)
+			assert.NoError(t, err)
+			assert.Len(t, results, 3)
+			assert.Equal(t, "/items/item-1/3", results[1].Record.Path)
+			assert.Equal(t, "/done/t-b", results[2].Record.Path)
+			assert.Equal(t, 3, results[2].Step)
+		})
+	}
+}
+
+func TestReplayer_ReplayStopsAtFirstFailure(t *testing.T) {
+	tests := []struct {
+		name          string
+		records       []Record
+		expectedSteps int
+		expectedError string
+	}{
+		{
+			name: "Unexpected status",
+			records: []Record{
+				{Name: "create", Method: http.MethodPost, Path: "/items", ExpectStatus: http.StatusOK},
+				{Name: "never sent", Method: http.MethodGet, Path: "/items"},
+			},
+			expectedSteps: 1,
+			expectedError: `step 1 (create): expected status 200, got 201: {"data":{"id":"item-1","count":3,"tags":["a","b"],"echo":}}`,
+		},
+		{
+			name: "Undefined variable",
+			records: []Record{
+				{Name: "get", Method: http.MethodGet, Path: "/items/{{id}}/{{other}}"},
+			},
+			expectedSteps: 1,
+			expectedError: "step 1 (get): undefined variables: id, other",
+		},
+		{
+			name: "Missing capture",
+			records: []Record{
+				{Name: "get", Method: http.MethodGet, Path: "/items", Capture: map[string]string{"id": "data.id"}},
+			},
+			expectedSteps: 1,
+			expectedError: `step 1 (get): capturing id: data.id: no field "data"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := NewInProcess(echo).Replay(context.Background(), tt.records)
+			assert.EqualError(t, err, tt.expectedError)
+			assert.Len(t, results, tt.expectedSteps)
+		})
+	}
+}
@@ -0,0 +1,260 @@
+// Package seed generates synthetic data sets for performance testing: users with a
+// power-law follow graph and tweets spread over time with a daily rhythm.
+package seed
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/bulk"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	ports "github.com/juanignaciorc/microbloggin-pltf/internal/ports/repositories"
+)
+
+// Config describes the data set to generate.
+type Config struct {
+	Users  int
+	Tweets int
+	// MeanFollowing is the average number of accounts a user follows.
+	MeanFollowing int
+	// Skew is the exponent of the power laws that popularity (followers) and activity
+	// (tweets) follow. It must be greater than 1; higher values concentrate followers
+	// and tweets on fewer users.
+	Skew float64
+	// Period is how far back from now the tweets are spread.
+	Period time.Duration
+	// Seed makes runs reproducible: the same config generates the same data.
+	Seed int64
+}
+
+var DefaultConfig = Config{
+	Users:         1000,
+	Tweets:        10000,
+	MeanFollowing: 20,
+	Skew:          1.2,
+	Period:        7 * 24 * time.Hour,
+	Seed:          1,
+}
+
+// Result summarizes what a run wrote.
+type Result struct {
+	UserIDs []uuid.UUID
+	Follows int
+	Tweets  int
+}
+
+var words = strings.Fields(`the a go api timeline latency release deploy coffee today tomorrow
+	weekend team build test ship review bug fix feature users follow tweet hello world
+	postgres cache index query fast slow great new launch meeting lunch music game`)
+
+// Seeder writes generated data through the repositories, bypassing the services:
+// no notifications or domain events are produced for it. With a bulk repository the
+// users, follows and tweets are imported in batches of bulk.DefaultBatchSize;
+// without one every record is one call to the users or tweets repository.
+type Seeder struct {
+	usersRepository  ports.UsersRepository
+	tweetsRepository ports.TweetRepository
+	bulkRepository   ports.BulkRepository
+	now              func() time.Time
+}
+
+// NewSeeder returns a seeder that writes through bulkRepository, which may be nil.
+func NewSeeder(usersRepository ports.UsersRepository, tweetsRepository ports.TweetRepository, bulkRepository ports.BulkRepository) *Seeder {
+	return &Seeder{
+		usersRepository:  usersRepository,
+		tweetsRepository: tweetsRepository,
+		bulkRepository:   bulkRepository,
+		now:              func() time.Time { return time.Now().UTC() },
+	}
+}
+
+func (s *Seeder) Seed(ctx context.Context, config Config) (Result, error) {
+	if config.Users < 2 {
+		return Result{}, fmt.Errorf("at least 2 users are needed, got %d", config.Users)
+	}
+	if config.Skew <= 1 {
+		return Result{}, fmt.Errorf("skew must be greater than 1, got %v", config.Skew)
+	}
+
+	r := rand.New(rand.NewSource(config.Seed))
+	var result Result
+
+	users := make([]domain.User, config.Users)
+	for i := range users {
+		id, err := uuid.NewRandomFromReader(r)
+		if err != nil {
+			return result, err
+		}
+
+		name := fmt.Sprintf("user%06d", i)
+		users[i] = domain.User{ID: id, Name: name, Email: name + "@seed.example"}
+	}
+
+	if _, err := s.writeUsers(ctx, users); err != nil {
+		return result, err
+	}
+
+	result.UserIDs = make([]uuid.UUID, len(users))
+	for i, user := range users {
+		result.UserIDs[i] = user.ID
+	}
+
+	var err error
+	result.Follows, err = s.writeFollows(ctx, generateFollows(r, result.UserIDs, config))
+	if err != nil {
+		return result, err
+	}
+
+	tweets, err := generateTweets(r, result.UserIDs, config, s.now())
+	if err != nil {
+		return result, err
+	}
+
+	result.Tweets, err = s.writeTweets(ctx, tweets)
+	return result, err
+}
+
+func (s *Seeder) writeUsers(ctx context.Context, users []domain.User) (int, error) {
+	if s.bulkRepository != nil {
+		return inBatches(ctx, users, s.bulkRepository.ImportUsers)
+	}
+
+	for i, user := range users {
+		if _, err := s.usersRepository.CreateUser(ctx, user); err != nil {
+			return i, err
+		}
+	}
+	return len(users), nil
+}
+
+func (s *Seeder) writeFollows(ctx context.Context, follows []domain.Follow) (int, error) {
+	if s.bulkRepository != nil {
+		return inBatches(ctx, follows, s.bulkRepository.ImportFollows)
+	}
+
+	for i, follow := range follows {
+		if err := s.usersRepository.FollowUser(ctx, follow.FollowerID, follow.FollowedID); err != nil {
+			return i, err
+		}
+	}
+	return len(follows), nil
+}
+
+func (s *Seeder) writeTweets(ctx context.Context, tweets []domain.Tweet) (int, error) {
+	if s.bulkRepository != nil {
+		return inBatches(ctx, tweets, s.bulkRepository.ImportTweets)
+	}
+
+	for i, tweet := range tweets {
+		if _, err := s.tweetsRepository.CreateTweet(ctx, tweet); err != nil {
+			return i, err
+		}
+	}
+	return len(tweets), nil
+}
+
+// inBatches imports items bulk.DefaultBatchSize at a time and returns how many were
+// written.
+func inBatches[T any](ctx context.Context, items []T, importBatch func(context.Context, []T) (int, error)) (int, error) {
+	written := 0
+	for start := 0; start < len(items); start += bulk.DefaultBatchSize {
+		n, err := importBatch(ctx, items[start:min(start+bulk.DefaultBatchSize, len(items))])
+		written += n
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// generateFollows makes every user follow an exponentially distributed number of accounts,
+// picked by a Zipf distribution over a random popularity ranking.
+func generateFollows(r *rand.Rand, userIDs []uuid.UUID, config Config) []domain.Follow {
+	byPopularity := r.Perm(len(userIDs))
+	popular := rand.NewZipf(r, config.Skew, 1, uint64(len(userIDs)-1))
+
+	var follows []domain.Follow
+	for i, userID := range userIDs {
+		following := int(r.ExpFloat64() * float64(config.MeanFollowing))
+		if following > len(userIDs)-1 {
+			following = len(userIDs) - 1
+		}
+
+		followed := make(map[int]struct{}, following)
+		// The most popular accounts are drawn over and over; give up on a user
+		// rather than loop until every draw lands on a new account.
+		for attempts := 0; len(followed) < following && attempts < 10*following; attempts++ {
+			j := byPopularity[popular.Uint64()]
+			if _, ok := followed[j]; ok || j == i {
+				continue
+			}
+			followed[j] = struct{}{}
+
+			follows = append(follows, domain.Follow{FollowerID: userID, FollowedID: userIDs[j]})
+		}
+	}
+
+	return follows
+}
+
+// generateTweets returns the tweets in chronological order, authored by a Zipf
+// distribution over a random activity ranking.
+func generateTweets(r *rand.Rand, userIDs []uuid.UUID, config Config, now time.Time) ([]domain.Tweet, error) {
+	byActivity := r.Perm(len(userIDs))
+	active := rand.NewZipf(r, config.Skew, 1, uint64(len(userIDs)-1))
+
+	times := make([]time.Time, config.Tweets)
+	for i := range times {
+		times[i] = tweetTime(r, now, config.Period)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	tweets := make([]domain.Tweet, len(times))
+	for i, createdAt := range times {
+		id, err := uuid.NewRandomFromReader(r)
+		if err != nil {
+			return nil, err
+		}
+
+		tweets[i] = domain.Tweet{
+			ID:        id,
+			UserID:    userIDs[byActivity[active.Uint64()]],
+			Message:   message(r),
+			CreatedAt: createdAt,
+		}
+	}
+
+	return tweets, nil
+}
+
+// tweetTime draws a time in the period before now, more likely in the afternoon
+// (UTC) than at night.
+func tweetTime(r *rand.Rand, now time.Time, period time.Duration) time.Time {
+	for {
+		t := now.Add(-time.Duration(r.Int63n(int64(period) + 1)))
+		if r.Float64() < activity(t) {
+			return t
+		}
+	}
+}
+
+// activity is the relative tweet rate at t: 1 at 15:00, 0.2 at 03:00.
+func activity(t time.Time) float64 {
+	hour := float64(t.Hour()) + float64(t.Minute())/60
+	return 0.6 + 0.4*math.Sin(2*math.Pi*(hour-9)/24)
+}
+
+func message(r *rand.Rand) string {
+	n := 3 + r.Intn(18)
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = words[r.Intn(len(words))]
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,78 @@
+package seed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/juanignaciorc/microbloggin-pltf/internal/adapters/repositories/in_memory_db"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeeder_Seed(t *testing.T) {
+	t.Run("Bulk imports", func(t *testing.T) {
+		db := in_memory_db.NewInMemoryDB()
+		testSeed(t, db, NewSeeder(db, db, db))
+	})
+	t.Run("One record at a time", func(t *testing.T) {
+		db := in_memory_db.NewInMemoryDB()
+		testSeed(t, db, NewSeeder(db, db, nil))
+	})
+}
+
+func testSeed(t *testing.T, db *in_memory_db.InMemoryDB, seeder *Seeder) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	seeder.now = func() time.Time { return now }
+
+	config := Config{Users: 200, Tweets: 1000, MeanFollowing: 10, Skew: 1.5, Period: 7 * 24 * time.Hour, Seed: 42}
+	result, err := seeder.Seed(ctx, config)
+	assert.NoError(t, err)
+	assert.Len(t, result.UserIDs, config.Users)
+	assert.Equal(t, config.Tweets, result.Tweets)
+
+	followers, follows, tweets, maxFollowers := 0, 0, 0, 0
+	for _, id := range result.UserIDs {
+		user, err := db.GetUser(ctx, id)
+		assert.NoError(t, err)
+		following, err := db.GetFollowedUserIDs(ctx, id)
+		assert.NoError(t, err)
+		assert.NotContains(t, following, id)
+
+		follows += user.FollowingCount
+		followers += user.FollowersCount
+		if user.FollowersCount > maxFollowers {
+			maxFollowers = user.FollowersCount
+		}
+
+		userTweets, err := db.GetUserTweets(ctx, id, domain.Pagination{Limit: config.Tweets})
+		assert.NoError(t, err)
+		for _, tweet := range userTweets {
+			assert.False(t, tweet.CreatedAt.After(now))
+			assert.False(t, tweet.CreatedAt.Before(now.Add(-config.Period)))
+		}
+		tweets += user.TweetsCount
+	}
+
+	assert.Equal(t, result.Follows, follows)
+	assert.Equal(t, follows, followers)
+	assert.Equal(t, config.Tweets, tweets)
+	// Popularity follows a power law: the most followed account has many times the
+	// average number of followers.
+	assert.Greater(t, maxFollowers, 5*follows/config.Users)
+
+	again, err := NewSeeder(in_memory_db.NewInMemoryDB(), in_memory_db.NewInMemoryDB(), nil).Seed(ctx, Config{Users: 10, Tweets: 0, Skew: 1.5, Seed: 42})
+	assert.NoError(t, err)
+	assert.Equal(t, result.UserIDs[:10], again.UserIDs)
+}
+
+func TestSeeder_SeedInvalidConfig(t *testing.T) {
+	db := in_memory_db.NewInMemoryDB()
+
+	_, err := NewSeeder(db, db, db).Seed(context.Background(), Config{Users: 1, Skew: 1.5})
+	assert.EqualError(t, err, "at least 2 users are needed, got 1")
+
+	_, err = NewSeeder(db, db, db).Seed(context.Background(), Config{Users: 10, Skew: 1})
+	assert.EqualError(t, err, "skew must be greater than 1, got 1")
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	mock_ports "github.com/juanignaciorc/microbloggin-pltf/mocks"
+	"go.uber.org/mock/gomock"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// withAssignedID matches the user or tweet the service built from want, whatever
+// non-nil ID (and, for tweets, creation time) it assigned to it.
+func withAssignedID(want any) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		switch got := x.(type) {
+		case domain.User:
+			if got.ID == uuid.Nil {
+				return false
+			}
+			got.ID = uuid.Nil
+			return reflect.DeepEqual(got, want)
+		case domain.Tweet:
+			if got.ID == uuid.Nil || got.CreatedAt.IsZero() {
+				return false
+			}
+			got.ID = uuid.Nil
+			got.CreatedAt = time.Time{}
+			return reflect.DeepEqual(got, want)
+		default:
+			return false
+		}
+	})
+}
+
+func eventOfType(eventType domain.EventType) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		event, ok := x.(domain.Event)
+		return ok && event.Type == eventType && event.ID != uuid.Nil
+	})
+}
+
+func TestUserService_CreateUser_EmitsUserCreated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCtx := context.Background()
+	mockRepo := mock_ports.NewMockUsersRepository(ctrl)
+	s := NewUserService(mockRepo, mock_ports.NewMockNotificationsRepository(ctrl), passThroughUnitOfWork(ctrl))
+
+	var event domain.Event
+	mockRepo.
+		EXPECT().
+		CreateUser(mockCtx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, user domain.User, events ...domain.Event) (domain.User, error) {
+			event = events[0]
+			return user, nil
+		})
+
+	user, err := s.CreateUser(mockCtx, "John Doe", "john@example.com")
+	if err != nil {
+		t.Fatalf("CreateUser() unexpected error = %v", err)
+	}
+
+	var payload domain.UserCreated
+	if err := event.DecodePayload(&payload); err != nil {
+		t.Fatalf("DecodePayload() error = %v", err)
+	}
+
+	expected := domain.UserCreated{UserID: user.ID, Name: "John Doe"}
+	if event.Type != domain.EventTypeUserCreated || !reflect.DeepEqual(payload, expected) {
+		t.Errorf("CreateUser() event = %v %+v, want = %v %+v", event.Type, payload, domain.EventTypeUserCreated, expected)
+	}
+}
+
+func TestTweetsService_CreateTweet_EmitsTweetCreated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCtx := context.Background()
+	authorID := uuid.New()
+	mockRepo := mock_ports.NewMockTweetRepository(ctrl)
+	mockPublisher := mock_ports.NewMockTweetPublisher(ctrl)
+	s := NewTweetsService(mockRepo, mock_ports.NewMockUsersRepository(ctrl), mock_ports.NewMockNotificationsRepository(ctrl), mockPublisher)
+
+	var event domain.Event
+	mockRepo.
+		EXPECT().
+		CreateTweet(mockCtx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, tweet domain.Tweet, events ...domain.Event) (domain.Tweet, error) {
+			event = events[0]
+			return tweet, nil
+		})
+	mockPublisher.EXPECT().PublishTweet(mockCtx, gomock.Any())
+
+	tweet, err := s.CreateTweet(mockCtx, authorID, "hello")
+	if err != nil {
+		t.Fatalf("CreateTweet() unexpected error = %v", err)
+	}
+
+	var payload domain.TweetCreated
+	if err := event.DecodePayload(&payload); err != nil {
+		t.Fatalf("DecodePayload() error = %v", err)
+	}
+
+	expected := domain.TweetCreated{TweetID: tweet.ID, UserID: authorID, Message: "hello"}
+	if event.Type != domain.EventTypeTweetCreated || !reflect.DeepEqual(payload, expected) {
+		t.Errorf("CreateTweet() event = %v %+v, want = %v %+v", event.Type, payload, domain.EventTypeTweetCreated, expected)
+	}
+}
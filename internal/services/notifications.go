@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/logging"
+	ports "github.com/juanignaciorc/microbloggin-pltf/internal/ports/repositories"
+)
+
+type notificationsServiceImpl struct {
+	notificationsRepository ports.NotificationsRepository
+}
+
+// NewNotificationsService creates a new NotificationService instance.
+func NewNotificationsService(notificationsRepository ports.NotificationsRepository) NotificationService {
+	return &notificationsServiceImpl{
+		notificationsRepository: notificationsRepository,
+	}
+}
+
+func (s *notificationsServiceImpl) GetNotifications(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.NotificationGroup, error) {
+	notifications, err := s.notificationsRepository.GetNotifications(ctx, userID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupNotifications(notifications), nil
+}
+
+func (s *notificationsServiceImpl) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	return s.notificationsRepository.CountUnreadNotifications(ctx, userID)
+}
+
+func (s *notificationsServiceImpl) MarkAsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
+	return s.notificationsRepository.MarkNotificationsRead(ctx, userID, ids)
+}
+
+// groupNotifications folds notifications of the same type about the same tweet into
+// one group, keeping the newest-first order of each group's most recent event.
+func groupNotifications(notifications []domain.Notification) []domain.NotificationGroup {
+	type groupKey struct {
+		notificationType domain.NotificationType
+		tweetID          uuid.UUID
+	}
+
+	groups := []domain.NotificationGroup{}
+	indexByKey := make(map[groupKey]int)
+	for _, notification := range notifications {
+		key := groupKey{notificationType: notification.Type}
+		if notification.TweetID != nil {
+			key.tweetID = *notification.TweetID
+		}
+
+		i, ok := indexByKey[key]
+		if !ok {
+			i = len(groups)
+			indexByKey[key] = i
+			groups = append(groups, domain.NotificationGroup{
+				Type:     notification.Type,
+				TweetID:  notification.TweetID,
+				LatestAt: notification.CreatedAt,
+			})
+		}
+
+		group := &groups[i]
+		if !containsID(group.ActorIDs, notification.ActorID) {
+			group.ActorIDs = append(group.ActorIDs, notification.ActorID)
+		}
+		group.NotificationIDs = append(group.NotificationIDs, notification.ID)
+		group.Unread = group.Unread || !notification.Read
+	}
+
+	return groups
+}
+
+// notify records a notification on a best-effort basis: failing to notify must not
+// undo the action that triggered it, so errors are only logged.
+func notify(ctx context.Context, notificationsRepository ports.NotificationsRepository, notification domain.Notification) {
+	if notification.UserID == notification.ActorID {
+		return
+	}
+
+	if _, err := notificationsRepository.CreateNotification(ctx, notification); err != nil {
+		logging.FromContext(ctx).Error("Failed to create notification", "type", notification.Type, "user_id", notification.UserID, "error", err)
+	}
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+type NotificationService interface {
+	// GetNotifications groups the page of notifications, newest first. The page counts
+	// notifications, not groups, so a page may hold fewer groups than its limit and a
+	// group may continue on the next page, with the ids of its older notifications.
+	GetNotifications(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.NotificationGroup, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkAsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error
+}
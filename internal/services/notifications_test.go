@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	mock_ports "github.com/juanignaciorc/microbloggin-pltf/mocks"
+	"go.uber.org/mock/gomock"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNotificationsService_GetNotifications(t *testing.T) {
+	userID := uuid.New()
+	actorA, actorB, actorC := uuid.New(), uuid.New(), uuid.New()
+	tweetID := uuid.New()
+	now := time.Now()
+	page := domain.NewPagination(10, 0)
+
+	notifications := []domain.Notification{
+		{ID: uuid.New(), UserID: userID, ActorID: actorA, Type: domain.NotificationTypeFollow, CreatedAt: now},
+		{ID: uuid.New(), UserID: userID, ActorID: actorB, Type: domain.NotificationTypeMention, TweetID: &tweetID, CreatedAt: now.Add(-time.Minute)},
+		{ID: uuid.New(), UserID: userID, ActorID: actorB, Type: domain.NotificationTypeFollow, Read: true, CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: uuid.New(), UserID: userID, ActorID: actorC, Type: domain.NotificationTypeFollow, Read: true, CreatedAt: now.Add(-3 * time.Minute)},
+	}
+
+	type testCase struct {
+		name       string
+		mockOutput []domain.Notification
+		mockErr    error
+		expected   []domain.NotificationGroup
+		wantErr    bool
+	}
+
+	tests := []testCase{
+		{
+			name:       "Similar notifications are grouped",
+			mockOutput: notifications,
+			expected: []domain.NotificationGroup{
+				{
+					Type:            domain.NotificationTypeFollow,
+					ActorIDs:        []uuid.UUID{actorA, actorB, actorC},
+					NotificationIDs: []uuid.UUID{notifications[0].ID, notifications[2].ID, notifications[3].ID},
+					Unread:          true,
+					LatestAt:        now,
+				},
+				{
+					Type:            domain.NotificationTypeMention,
+					TweetID:         &tweetID,
+					ActorIDs:        []uuid.UUID{actorB},
+					NotificationIDs: []uuid.UUID{notifications[1].ID},
+					Unread:          true,
+					LatestAt:        now.Add(-time.Minute),
+				},
+			},
+		},
+		{
+			name:       "No notifications",
+			mockOutput: []domain.Notification{},
+			expected:   []domain.NotificationGroup{},
+		},
+		{
+			name:    "Repository error",
+			mockErr: errors.New("notifications fetch failed"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCtx := context.Background()
+			mockRepo := mock_ports.NewMockNotificationsRepository(ctrl)
+			s := NewNotificationsService(mockRepo)
+
+			mockRepo.
+				EXPECT().
+				GetNotifications(mockCtx, userID, page).
+				Return(tc.mockOutput, tc.mockErr)
+
+			got, err := s.GetNotifications(mockCtx, userID, page)
+
+			if (err != nil) != tc.wantErr {
+				t.Errorf("GetNotifications() error = %v, wantErr = %v", err, tc.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("GetNotifications() got = %v, want = %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestNotificationsService_GetNotifications_PagesNotifications(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+	follows := make([]domain.Notification, 3)
+	for i := range follows {
+		follows[i] = domain.Notification{ID: uuid.New(), UserID: userID, ActorID: uuid.New(), Type: domain.NotificationTypeFollow, CreatedAt: now.Add(-time.Duration(i) * time.Minute)}
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCtx := context.Background()
+	mockRepo := mock_ports.NewMockNotificationsRepository(ctrl)
+	s := NewNotificationsService(mockRepo)
+
+	// The limit counts notifications: the three follows fill a page and a half, so
+	// their group is split across both.
+	mockRepo.EXPECT().GetNotifications(mockCtx, userID, domain.NewPagination(2, 0)).Return(follows[:2], nil)
+	mockRepo.EXPECT().GetNotifications(mockCtx, userID, domain.NewPagination(2, 2)).Return(follows[2:], nil)
+
+	first, err := s.GetNotifications(mockCtx, userID, domain.NewPagination(2, 0))
+	if err != nil {
+		t.Fatalf("GetNotifications() unexpected error = %v", err)
+	}
+	second, err := s.GetNotifications(mockCtx, userID, domain.NewPagination(2, 2))
+	if err != nil {
+		t.Fatalf("GetNotifications() unexpected error = %v", err)
+	}
+
+	if len(first) != 1 || !reflect.DeepEqual(first[0].NotificationIDs, []uuid.UUID{follows[0].ID, follows[1].ID}) {
+		t.Errorf("GetNotifications() first page = %v", first)
+	}
+	if len(second) != 1 || !reflect.DeepEqual(second[0].NotificationIDs, []uuid.UUID{follows[2].ID}) {
+		t.Errorf("GetNotifications() second page = %v", second)
+	}
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/juanignaciorc/microbloggin-pltf/internal/services")
+
+// recordError marks span as failed when the operation it covers returned err.
+func recordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+type tracingUserService struct {
+	next UserService
+}
+
+// WithUserTracing records a span for each call to next, so the repository calls
+// made by the service are grouped under the operation that made them.
+func WithUserTracing(next UserService) UserService {
+	return tracingUserService{next: next}
+}
+
+func (s tracingUserService) CreateUser(ctx context.Context, name, mail string) (domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.CreateUser")
+	defer span.End()
+
+	result, err := s.next.CreateUser(ctx, name, mail)
+	recordError(span, err)
+	return result, err
+}
+
+func (s tracingUserService) GetUser(ctx context.Context, id uuid.UUID) (domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetUser")
+	defer span.End()
+
+	result, err := s.next.GetUser(ctx, id)
+	recordError(span, err)
+	return result, err
+}
+
+func (s tracingUserService) GetUsers(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetUsers")
+	defer span.End()
+
+	result, err := s.next.GetUsers(ctx, ids)
+	recordError(span, err)
+	return result, err
+}
+
+func (s tracingUserService) GetUserAsViewer(ctx context.Context, viewerID, id uuid.UUID) (domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetUserAsViewer")
+	defer span.End()
+
+	result, err := s.next.GetUserAsViewer(ctx, viewerID, id)
+	recordError(span, err)
+	return result, err
+}
+
+func (s tracingUserService) CanViewTweets(ctx context.Context, viewerID, authorID uuid.UUID) (bool, error) {
+	ctx, span := tracer.Start(ctx, "UserService.CanViewTweets")
+	defer span.End()
+
+	result, err := s.next.CanViewTweets(ctx, viewerID, authorID)
+	recordError(span, err)
+	return result, err
+}
+
+func (s tracingUserService) FollowUser(ctx context.Context, userID, followedID uuid.UUID) (domain.FollowStatus, error) {
+	ctx, span := tracer.Start(ctx, "UserService.FollowUser")
+	defer span.End()
+
+	result, err := s.next.FollowUser(ctx, userID, followedID)
+	recordError(span, err)
+	return result, err
+}
+
+func (s tracingUserService) GetUserTimeline(ctx context.Context, userID uuid.UUID) ([]domain.Tweet, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetUserTimeline")
+	defer span.End()
+
+	result, err := s.next.GetUserTimeline(ctx, userID)
+	recordError(span, err)
+	return result, err
+}
+
+func (s tracingUserService) GetTimelineAuthorIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetTimelineAuthorIDs")
+	defer span.End()
+
+	result, err := s.next.GetTimelineAuthorIDs(ctx, userID)
+	recordError(span, err)
+	return result, err
+}
+
+func (s tracingUserService) GetFollowers(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetFollowers")
+	defer span.End()
+
+	result, err := s.next.GetFollowers(ctx, userID, page)
+	recordError(span, err)
+	return result, err
+}
+
+func (s tracingUserService) GetFollowing(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetFollowing")
+	defer span.End()
+
+	result, err := s.next.GetFollowing(ctx, userID, page)
+	recordError(span, err)
+	return result, err
+}
+
+func (s tracingUserService) IsFollowing(ctx context.Context, userID, followedID uuid.UUID) (bool, error) {
+	ctx, span := tracer.Start(ctx, "UserService.IsFollowing")
+	defer span.End()
+
+	result, err := s.next.IsFollowing(ctx, userID, followedID)
+	recordError(span, err)
+	return result, err
+}
+
+func (s tracingUserService) IsMuted(ctx context.Context, userID, mutedID uuid.UUID) (bool, error) {
+	ctx, span := tracer.Start(ctx, "UserService.IsMuted")
+	defer span.End()
+
+	result, err := s.next.IsMuted(ctx, userID, mutedID)
+	recordError(span, err)
+	return result, err
+}
+
+func (s tracingUserService) BlockUser(ctx context.Context, userID, blockedID uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "UserService.BlockUser")
+	defer span.End()
+
+	err := s.next.BlockUser(ctx, userID, blockedID)
+	recordError(span, err)
+	return err
+}
+
+func (s tracingUserService) UnblockUser(ctx context.Context, userID, blockedID uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "UserService.UnblockUser")
+	defer span.End()
+
+	err := s.next.UnblockUser(ctx, userID, blockedID)
+	recordError(span, err)
+	return err
+}
+
+func (s tracingUserService) MuteUser(ctx context.Context, userID, mutedID uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "UserService.MuteUser")
+	defer span.End()
+
+	err := s.next.MuteUser(ctx, userID, mutedID)
+	recordError(span, err)
+	return err
+}
+
+func (s tracingUserService) UnmuteUser(ctx context.Context, userID, mutedID uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "UserService.UnmuteUser")
+	defer span.End()
+
+	err := s.next.UnmuteUser(ctx, userID, mutedID)
+	recordError(span, err)
+	return err
+}
+
+func (s tracingUserService) SetProtected(ctx context.Context, userID uuid.UUID, protected bool) error {
+	ctx, span := tracer.Start(ctx, "UserService.SetProtected")
+	defer span.End()
+
+	err := s.next.SetProtected(ctx, userID, protected)
+	recordError(span, err)
+	return err
+}
+
+func (s tracingUserService) GetFollowRequests(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetFollowRequests")
+	defer span.End()
+
+	result, err := s.next.GetFollowRequests(ctx, userID, page)
+	recordError(span, err)
+	return result, err
+}
+
+func (s tracingUserService) ApproveFollowRequest(ctx context.Context, userID, followerID uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "UserService.ApproveFollowRequest")
+	defer span.End()
+
+	err := s.next.ApproveFollowRequest(ctx, userID, followerID)
+	recordError(span, err)
+	return err
+}
+
+func (s tracingUserService) RejectFollowRequest(ctx context.Context, userID, followerID uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "UserService.RejectFollowRequest")
+	defer span.End()
+
+	err := s.next.RejectFollowRequest(ctx, userID, followerID)
+	recordError(span, err)
+	return err
+}
+
+type tracingTweetService struct {
+	next TweetService
+}
+
+// WithTweetTracing records a span for each call to next.
+func WithTweetTracing(next TweetService) TweetService {
+	return tracingTweetService{next: next}
+}
+
+func (s tracingTweetService) CreateTweet(ctx context.Context, userID uuid.UUID, message string) (domain.Tweet, error) {
+	ctx, span := tracer.Start(ctx, "TweetService.CreateTweet")
+	defer span.End()
+
+	result, err := s.next.CreateTweet(ctx, userID, message)
+	recordError(span, err)
+	return result, err
+}
+
+func (s tracingTweetService) GetTweet(ctx context.Context, viewerID, tweetID uuid.UUID) (domain.Tweet, error) {
+	ctx, span := tracer.Start(ctx, "TweetService.GetTweet")
+	defer span.End()
+
+	result, err := s.next.GetTweet(ctx, viewerID, tweetID)
+	recordError(span, err)
+	return result, err
+}
+
+func (s tracingTweetService) GetUserTweets(ctx context.Context, viewerID, authorID uuid.UUID, page domain.Pagination) ([]domain.Tweet, error) {
+	ctx, span := tracer.Start(ctx, "TweetService.GetUserTweets")
+	defer span.End()
+
+	result, err := s.next.GetUserTweets(ctx, viewerID, authorID, page)
+	recordError(span, err)
+	return result, err
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	mock_ports "github.com/juanignaciorc/microbloggin-pltf/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWithUserTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+
+	ctrl := gomock.NewController(t)
+	next := mock_ports.NewMockUserService(ctrl)
+	service := WithUserTracing(next)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	userID := uuid.New()
+	errFailed := errors.New("failed")
+	next.EXPECT().GetUser(gomock.Any(), userID).DoAndReturn(func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+		// The repositories get the service's span.
+		assert.NotEqual(t, parent.SpanContext().SpanID(), trace.SpanContextFromContext(ctx).SpanID())
+		return domain.User{}, errFailed
+	})
+
+	_, err := service.GetUser(ctx, userID)
+	assert.ErrorIs(t, err, errFailed)
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "UserService.GetUser", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "failed", spans[0].Status().Description)
+}
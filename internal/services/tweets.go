@@ -4,29 +4,126 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/logging"
 	ports "github.com/juanignaciorc/microbloggin-pltf/internal/ports/repositories"
+	"time"
 )
 
 type tweetsServiceImpl struct {
-	tweetsRepository ports.TweetRepository
+	tweetsRepository        ports.TweetRepository
+	usersRepository         ports.UsersRepository
+	notificationsRepository ports.NotificationsRepository
+	tweetPublisher          ports.TweetPublisher
 }
 
 // NewTweetsService creates a new TweetService instance.
-func NewTweetsService(tweetsRepository ports.TweetRepository) TweetService {
+func NewTweetsService(tweetsRepository ports.TweetRepository, usersRepository ports.UsersRepository, notificationsRepository ports.NotificationsRepository, tweetPublisher ports.TweetPublisher) TweetService {
 	return &tweetsServiceImpl{
-		tweetsRepository: tweetsRepository,
+		tweetsRepository:        tweetsRepository,
+		usersRepository:         usersRepository,
+		notificationsRepository: notificationsRepository,
+		tweetPublisher:          tweetPublisher,
 	}
 }
 
 func (s *tweetsServiceImpl) CreateTweet(ctx context.Context, userID uuid.UUID, message string) (domain.Tweet, error) {
+	// The ID is assigned here so the TweetCreated event can carry it.
 	tweet := domain.Tweet{
-		UserID:  userID,
-		Message: message,
+		ID:        uuid.New(),
+		UserID:    userID,
+		Message:   message,
+		CreatedAt: time.Now().UTC(),
 	}
-	tw, err := s.tweetsRepository.CreateTweet(ctx, tweet)
+	tw, err := s.tweetsRepository.CreateTweet(ctx, tweet, domain.NewTweetCreatedEvent(tweet))
 	if err != nil {
 		return domain.Tweet{}, err
 	}
 
+	s.tweetPublisher.PublishTweet(ctx, tw)
+
+	if mentionedIDs := tw.MentionedUserIDs(); len(mentionedIDs) > 0 {
+		s.notifyMentions(ctx, tw, mentionedIDs)
+	}
+
 	return tw, nil
 }
+
+// notifyMentions tells the mentioned users about tweet, except those who cannot see
+// it, because of a block either way or a protected author they do not follow, and
+// those who muted the author. Like notify, it is best-effort: errors are logged.
+func (s *tweetsServiceImpl) notifyMentions(ctx context.Context, tweet domain.Tweet, mentionedIDs []uuid.UUID) {
+	logger := logging.FromContext(ctx)
+
+	author, err := s.usersRepository.GetUser(ctx, tweet.UserID)
+	if err != nil {
+		logger.Error("Failed to read the author of mentions", "tweet_id", tweet.ID, "error", err)
+		return
+	}
+
+	for _, mentionedID := range mentionedIDs {
+		if mentionedID == author.ID {
+			continue
+		}
+
+		visible, err := canViewTweets(ctx, s.usersRepository, mentionedID, author)
+		if err != nil {
+			logger.Error("Failed to check who can see a mention", "user_id", mentionedID, "error", err)
+			continue
+		}
+
+		mutedIDs, err := s.usersRepository.GetMutedUserIDs(ctx, mentionedID)
+		if err != nil {
+			logger.Error("Failed to check who muted the author of a mention", "user_id", mentionedID, "error", err)
+			continue
+		}
+
+		if visible && !containsID(mutedIDs, author.ID) {
+			notify(ctx, s.notificationsRepository, domain.Notification{UserID: mentionedID, ActorID: author.ID, Type: domain.NotificationTypeMention, TweetID: &tweet.ID})
+		}
+	}
+}
+
+// GetTweet returns the tweet if viewerID is allowed to see it. Tweets hidden from
+// the viewer are reported as domain.ErrTweetNotFound so their existence is not leaked.
+func (s *tweetsServiceImpl) GetTweet(ctx context.Context, viewerID, tweetID uuid.UUID) (domain.Tweet, error) {
+	tweet, err := s.tweetsRepository.GetTweet(ctx, tweetID)
+	if err != nil {
+		return domain.Tweet{}, err
+	}
+
+	author, err := s.usersRepository.GetUser(ctx, tweet.UserID)
+	if err != nil {
+		return domain.Tweet{}, err
+	}
+
+	visible, err := canViewTweets(ctx, s.usersRepository, viewerID, author)
+	if err != nil {
+		return domain.Tweet{}, err
+	}
+
+	if !visible {
+		return domain.Tweet{}, domain.ErrTweetNotFound
+	}
+
+	return tweet, nil
+}
+
+// GetUserTweets returns a page of authorID's tweets, newest first, if viewerID is
+// allowed to see them, and domain.ErrTweetsProtected otherwise.
+func (s *tweetsServiceImpl) GetUserTweets(ctx context.Context, viewerID, authorID uuid.UUID, page domain.Pagination) ([]domain.Tweet, error) {
+	author, err := s.usersRepository.GetUser(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, err := canViewTweets(ctx, s.usersRepository, viewerID, author)
+	if err != nil {
+		return nil, err
+	}
+
+	if !visible {
+		return nil, domain.ErrTweetsProtected
+	}
+
+	return s.tweetsRepository.GetUserTweets(ctx, authorID, page)
+}
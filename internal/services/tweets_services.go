@@ -8,4 +8,6 @@ import (
 
 type TweetService interface {
 	CreateTweet(ctx context.Context, userID uuid.UUID, message string) (domain.Tweet, error)
+	GetTweet(ctx context.Context, viewerID, tweetID uuid.UUID) (domain.Tweet, error)
+	GetUserTweets(ctx context.Context, viewerID, authorID uuid.UUID, page domain.Pagination) ([]domain.Tweet, error)
 }
@@ -49,13 +49,20 @@ func TestTweetsService_CreateTweet(t *testing.T) {
 
 			mockCtx := context.Background()
 			mockRepo := mock_ports.NewMockTweetRepository(ctrl)
-			s := NewTweetsService(mockRepo)
+			mockPublisher := mock_ports.NewMockTweetPublisher(ctrl)
+			s := NewTweetsService(mockRepo, mock_ports.NewMockUsersRepository(ctrl), mock_ports.NewMockNotificationsRepository(ctrl), mockPublisher)
 
 			mockRepo.
 				EXPECT().
-				CreateTweet(mockCtx, tc.mockInput).
+				CreateTweet(mockCtx, withAssignedID(tc.mockInput), eventOfType(domain.EventTypeTweetCreated)).
 				Return(tc.mockOutput, tc.mockErr)
 
+			if !tc.wantErr {
+				mockPublisher.
+					EXPECT().
+					PublishTweet(mockCtx, tc.mockOutput)
+			}
+
 			got, err := s.CreateTweet(mockCtx, tc.mockInput.UserID, tc.mockInput.Message)
 
 			if (err != nil) != tc.wantErr {
@@ -69,3 +76,275 @@ func TestTweetsService_CreateTweet(t *testing.T) {
 		})
 	}
 }
+
+func TestTweetsService_GetTweet(t *testing.T) {
+	authorID := uuid.New()
+	viewerID := uuid.New()
+	tweet := domain.Tweet{ID: uuid.New(), UserID: authorID, Message: "message"}
+
+	type testCase struct {
+		name          string
+		viewerID      uuid.UUID
+		author        domain.User
+		setupMock     func(*mock_ports.MockUsersRepository)
+		expected      domain.Tweet
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:     "Public author, anonymous viewer",
+			viewerID: uuid.Nil,
+			author:   domain.User{ID: authorID},
+			expected: tweet,
+		},
+		{
+			name:     "Protected author, approved follower",
+			viewerID: viewerID,
+			author:   domain.User{ID: authorID, Protected: true},
+			setupMock: func(repo *mock_ports.MockUsersRepository) {
+				repo.EXPECT().IsBlocked(gomock.Any(), viewerID, authorID).Return(false, nil)
+				repo.EXPECT().IsBlocked(gomock.Any(), authorID, viewerID).Return(false, nil)
+				repo.EXPECT().IsFollowing(gomock.Any(), viewerID, authorID).Return(true, nil)
+			},
+			expected: tweet,
+		},
+		{
+			name:     "Protected author, non-follower",
+			viewerID: viewerID,
+			author:   domain.User{ID: authorID, Protected: true},
+			setupMock: func(repo *mock_ports.MockUsersRepository) {
+				repo.EXPECT().IsBlocked(gomock.Any(), viewerID, authorID).Return(false, nil)
+				repo.EXPECT().IsBlocked(gomock.Any(), authorID, viewerID).Return(false, nil)
+				repo.EXPECT().IsFollowing(gomock.Any(), viewerID, authorID).Return(false, nil)
+			},
+			expectedError: domain.ErrTweetNotFound,
+		},
+		{
+			name:          "Protected author, anonymous viewer",
+			viewerID:      uuid.Nil,
+			author:        domain.User{ID: authorID, Protected: true},
+			expectedError: domain.ErrTweetNotFound,
+		},
+		{
+			name:     "Public author who blocked the viewer",
+			viewerID: viewerID,
+			author:   domain.User{ID: authorID},
+			setupMock: func(repo *mock_ports.MockUsersRepository) {
+				repo.EXPECT().IsBlocked(gomock.Any(), viewerID, authorID).Return(false, nil)
+				repo.EXPECT().IsBlocked(gomock.Any(), authorID, viewerID).Return(true, nil)
+			},
+			expectedError: domain.ErrTweetNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCtx := context.Background()
+			mockTweetRepo := mock_ports.NewMockTweetRepository(ctrl)
+			mockUserRepo := mock_ports.NewMockUsersRepository(ctrl)
+			s := NewTweetsService(mockTweetRepo, mockUserRepo, mock_ports.NewMockNotificationsRepository(ctrl), mock_ports.NewMockTweetPublisher(ctrl))
+
+			mockTweetRepo.EXPECT().GetTweet(mockCtx, tweet.ID).Return(tweet, nil)
+			mockUserRepo.EXPECT().GetUser(mockCtx, authorID).Return(tc.author, nil)
+			if tc.setupMock != nil {
+				tc.setupMock(mockUserRepo)
+			}
+
+			got, err := s.GetTweet(mockCtx, tc.viewerID, tweet.ID)
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("GetTweet() error = %v, want = %v", err, tc.expectedError)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("GetTweet() got = %v, want = %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestTweetsService_GetUserTweets(t *testing.T) {
+	authorID := uuid.New()
+	viewerID := uuid.New()
+	page := domain.NewPagination(10, 0)
+	tweets := []domain.Tweet{{ID: uuid.New(), UserID: authorID, Message: "message"}}
+
+	type testCase struct {
+		name          string
+		viewerID      uuid.UUID
+		author        domain.User
+		setupMock     func(*mock_ports.MockUsersRepository, *mock_ports.MockTweetRepository)
+		expected      []domain.Tweet
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:     "Public author, anonymous viewer",
+			viewerID: uuid.Nil,
+			author:   domain.User{ID: authorID},
+			setupMock: func(_ *mock_ports.MockUsersRepository, tweetRepo *mock_ports.MockTweetRepository) {
+				tweetRepo.EXPECT().GetUserTweets(gomock.Any(), authorID, page).Return(tweets, nil)
+			},
+			expected: tweets,
+		},
+		{
+			name:     "Protected author, non-follower",
+			viewerID: viewerID,
+			author:   domain.User{ID: authorID, Protected: true},
+			setupMock: func(userRepo *mock_ports.MockUsersRepository, _ *mock_ports.MockTweetRepository) {
+				userRepo.EXPECT().IsBlocked(gomock.Any(), viewerID, authorID).Return(false, nil)
+				userRepo.EXPECT().IsBlocked(gomock.Any(), authorID, viewerID).Return(false, nil)
+				userRepo.EXPECT().IsFollowing(gomock.Any(), viewerID, authorID).Return(false, nil)
+			},
+			expectedError: domain.ErrTweetsProtected,
+		},
+		{
+			name:     "Protected author, owner",
+			viewerID: authorID,
+			author:   domain.User{ID: authorID, Protected: true},
+			setupMock: func(_ *mock_ports.MockUsersRepository, tweetRepo *mock_ports.MockTweetRepository) {
+				tweetRepo.EXPECT().GetUserTweets(gomock.Any(), authorID, page).Return(tweets, nil)
+			},
+			expected: tweets,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCtx := context.Background()
+			mockTweetRepo := mock_ports.NewMockTweetRepository(ctrl)
+			mockUserRepo := mock_ports.NewMockUsersRepository(ctrl)
+			s := NewTweetsService(mockTweetRepo, mockUserRepo, mock_ports.NewMockNotificationsRepository(ctrl), mock_ports.NewMockTweetPublisher(ctrl))
+
+			mockUserRepo.EXPECT().GetUser(mockCtx, authorID).Return(tc.author, nil)
+			tc.setupMock(mockUserRepo, mockTweetRepo)
+
+			got, err := s.GetUserTweets(mockCtx, tc.viewerID, authorID, page)
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("GetUserTweets() error = %v, want = %v", err, tc.expectedError)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("GetUserTweets() got = %v, want = %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestTweetsService_CreateTweet_NotifiesMentions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCtx := context.Background()
+	authorID := uuid.New()
+	mentionedID := uuid.New()
+	tweetID := uuid.New()
+	message := "hello @" + mentionedID.String() + " and @" + authorID.String()
+
+	mockRepo := mock_ports.NewMockTweetRepository(ctrl)
+	mockUsers := mock_ports.NewMockUsersRepository(ctrl)
+	mockNotifications := mock_ports.NewMockNotificationsRepository(ctrl)
+	mockPublisher := mock_ports.NewMockTweetPublisher(ctrl)
+	s := NewTweetsService(mockRepo, mockUsers, mockNotifications, mockPublisher)
+
+	mockRepo.
+		EXPECT().
+		CreateTweet(mockCtx, withAssignedID(domain.Tweet{UserID: authorID, Message: message}), eventOfType(domain.EventTypeTweetCreated)).
+		Return(domain.Tweet{ID: tweetID, UserID: authorID, Message: message}, nil)
+
+	mockPublisher.
+		EXPECT().
+		PublishTweet(mockCtx, domain.Tweet{ID: tweetID, UserID: authorID, Message: message})
+
+	mockUsers.EXPECT().GetUser(mockCtx, authorID).Return(domain.User{ID: authorID}, nil)
+	mockUsers.EXPECT().IsBlocked(mockCtx, gomock.Any(), gomock.Any()).Return(false, nil).Times(2)
+	mockUsers.EXPECT().GetMutedUserIDs(mockCtx, mentionedID).Return(nil, nil)
+
+	// Mentioning yourself does not produce a notification
+	mockNotifications.
+		EXPECT().
+		CreateNotification(mockCtx, domain.Notification{UserID: mentionedID, ActorID: authorID, Type: domain.NotificationTypeMention, TweetID: &tweetID}).
+		Return(domain.Notification{}, nil)
+
+	if _, err := s.CreateTweet(mockCtx, authorID, message); err != nil {
+		t.Errorf("CreateTweet() unexpected error = %v", err)
+	}
+}
+
+func TestTweetsService_CreateTweet_SkipsHiddenMentions(t *testing.T) {
+	authorID := uuid.New()
+	mentionedID := uuid.New()
+	message := "hello @" + mentionedID.String()
+
+	tests := []struct {
+		name      string
+		protected bool
+		setupMock func(users *mock_ports.MockUsersRepository)
+	}{
+		{
+			name: "The mentioned user blocked the author",
+			setupMock: func(users *mock_ports.MockUsersRepository) {
+				users.EXPECT().IsBlocked(gomock.Any(), mentionedID, authorID).Return(true, nil)
+			},
+		},
+		{
+			name: "The author blocked the mentioned user",
+			setupMock: func(users *mock_ports.MockUsersRepository) {
+				users.EXPECT().IsBlocked(gomock.Any(), mentionedID, authorID).Return(false, nil)
+				users.EXPECT().IsBlocked(gomock.Any(), authorID, mentionedID).Return(true, nil)
+			},
+		},
+		{
+			name:      "The author is protected and not followed",
+			protected: true,
+			setupMock: func(users *mock_ports.MockUsersRepository) {
+				users.EXPECT().IsBlocked(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).Times(2)
+				users.EXPECT().IsFollowing(gomock.Any(), mentionedID, authorID).Return(false, nil)
+			},
+		},
+		{
+			name: "The mentioned user muted the author",
+			setupMock: func(users *mock_ports.MockUsersRepository) {
+				users.EXPECT().IsBlocked(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).Times(2)
+				users.EXPECT().GetMutedUserIDs(gomock.Any(), mentionedID).Return([]uuid.UUID{authorID}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_ports.NewMockTweetRepository(ctrl)
+			mockUsers := mock_ports.NewMockUsersRepository(ctrl)
+			mockPublisher := mock_ports.NewMockTweetPublisher(ctrl)
+			// No notification is expected, so creating one fails the test.
+			s := NewTweetsService(mockRepo, mockUsers, mock_ports.NewMockNotificationsRepository(ctrl), mockPublisher)
+
+			mockRepo.EXPECT().CreateTweet(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, tweet domain.Tweet, events ...domain.Event) (domain.Tweet, error) {
+					return tweet, nil
+				})
+			mockPublisher.EXPECT().PublishTweet(gomock.Any(), gomock.Any())
+			mockUsers.EXPECT().GetUser(gomock.Any(), authorID).Return(domain.User{ID: authorID, Protected: tc.protected}, nil)
+			tc.setupMock(mockUsers)
+			mockUsers.EXPECT().GetMutedUserIDs(gomock.Any(), mentionedID).Return(nil, nil).AnyTimes()
+
+			if _, err := s.CreateTweet(context.Background(), authorID, message); err != nil {
+				t.Errorf("CreateTweet() unexpected error = %v", err)
+			}
+		})
+	}
+}
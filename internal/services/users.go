@@ -8,22 +8,28 @@ import (
 )
 
 type userServiceImpl struct {
-	userRepository ports.UsersRepository
+	userRepository          ports.UsersRepository
+	notificationsRepository ports.NotificationsRepository
+	unitOfWork              ports.UnitOfWork
 }
 
-func NewUserService(userRepository ports.UsersRepository) userServiceImpl {
+func NewUserService(userRepository ports.UsersRepository, notificationsRepository ports.NotificationsRepository, unitOfWork ports.UnitOfWork) userServiceImpl {
 	return userServiceImpl{
-		userRepository: userRepository,
+		userRepository:          userRepository,
+		notificationsRepository: notificationsRepository,
+		unitOfWork:              unitOfWork,
 	}
 }
 
 func (s userServiceImpl) CreateUser(ctx context.Context, name, mail string) (domain.User, error) {
+	// The ID is assigned here so the UserCreated event can carry it.
 	user := domain.User{
+		ID:    uuid.New(),
 		Name:  name,
 		Email: mail,
 	}
 
-	createdUser, err := s.userRepository.CreateUser(ctx, user)
+	createdUser, err := s.userRepository.CreateUser(ctx, user, domain.NewUserCreatedEvent(user))
 	if err != nil {
 		return domain.User{}, err
 	}
@@ -40,12 +46,95 @@ func (s userServiceImpl) GetUser(ctx context.Context, id uuid.UUID) (domain.User
 	return user, nil
 }
 
-func (s userServiceImpl) FollowUser(ctx context.Context, userID, followedID uuid.UUID) error {
-	if err := s.userRepository.FollowUser(ctx, userID, followedID); err != nil {
-		return err
+// GetUsers looks up several profiles in a single read. Unknown IDs are left out.
+func (s userServiceImpl) GetUsers(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	return s.userRepository.GetUsers(ctx, ids)
+}
+
+// GetUserAsViewer returns the user as seen by viewerID: the tweet count of a
+// protected account is only included for the owner and its approved followers.
+func (s userServiceImpl) GetUserAsViewer(ctx context.Context, viewerID, id uuid.UUID) (domain.User, error) {
+	user, err := s.userRepository.GetUser(ctx, id)
+	if err != nil {
+		return domain.User{}, err
 	}
 
-	return nil
+	visible, err := canViewTweets(ctx, s.userRepository, viewerID, user)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	if !visible {
+		user.TweetsCount = 0
+	}
+
+	return user, nil
+}
+
+// CanViewTweets reports whether viewerID may read authorID's tweets.
+func (s userServiceImpl) CanViewTweets(ctx context.Context, viewerID, authorID uuid.UUID) (bool, error) {
+	author, err := s.userRepository.GetUser(ctx, authorID)
+	if err != nil {
+		return false, err
+	}
+
+	return canViewTweets(ctx, s.userRepository, viewerID, author)
+}
+
+// FollowUser follows followedID right away, or leaves a pending follow request when the account is protected.
+// Following an account userID already follows changes nothing. The block and privacy checks run in the same unit of work as the write they decide.
+func (s userServiceImpl) FollowUser(ctx context.Context, userID, followedID uuid.UUID) (domain.FollowStatus, error) {
+	var status domain.FollowStatus
+	changed := true
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		blocked, err := isBlockedEitherWay(ctx, s.userRepository, userID, followedID)
+		if err != nil {
+			return err
+		}
+
+		if blocked {
+			return domain.ErrUserBlocked
+		}
+
+		followedUser, err := s.userRepository.GetUser(ctx, followedID)
+		if err != nil {
+			return err
+		}
+
+		following, err := s.userRepository.IsFollowing(ctx, userID, followedID)
+		if err != nil {
+			return err
+		}
+
+		if following {
+			status, changed = domain.FollowStatusFollowing, false
+			return nil
+		}
+
+		if followedUser.Protected {
+			status = domain.FollowStatusPending
+			return s.userRepository.CreateFollowRequest(ctx, userID, followedID)
+		}
+
+		status = domain.FollowStatusFollowing
+		return s.userRepository.FollowUser(ctx, userID, followedID, domain.NewUserFollowedEvent(userID, followedID))
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Notifications are best-effort, so they are sent once the follow is committed.
+	if !changed {
+		return status, nil
+	}
+
+	if status == domain.FollowStatusPending {
+		notify(ctx, s.notificationsRepository, domain.Notification{UserID: followedID, ActorID: userID, Type: domain.NotificationTypeFollowRequest})
+	} else {
+		notify(ctx, s.notificationsRepository, domain.Notification{UserID: followedID, ActorID: userID, Type: domain.NotificationTypeFollow})
+	}
+
+	return status, nil
 }
 
 func (s userServiceImpl) GetUserTimeline(ctx context.Context, userID uuid.UUID) ([]domain.Tweet, error) {
@@ -54,5 +143,204 @@ func (s userServiceImpl) GetUserTimeline(ctx context.Context, userID uuid.UUID)
 		return nil, err
 	}
 
-	return tweets, nil
+	blockedIDs, err := s.userRepository.GetBlockedUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	mutedIDs, err := s.userRepository.GetMutedUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Protected authors need no extra filtering here: the timeline is built from follow
+	// edges, and those only exist for protected accounts once a follow request is approved.
+	return filterTweetsByAuthor(tweets, append(blockedIDs, mutedIDs...)), nil
+}
+
+// GetTimelineAuthorIDs returns the users whose tweets belong in userID's timeline:
+// everyone they follow except muted and blocked users.
+func (s userServiceImpl) GetTimelineAuthorIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	followedIDs, err := s.userRepository.GetFollowedUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedIDs, err := s.userRepository.GetBlockedUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	mutedIDs, err := s.userRepository.GetMutedUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	excludedIDs := append(blockedIDs, mutedIDs...)
+	authorIDs := make([]uuid.UUID, 0, len(followedIDs))
+	for _, id := range followedIDs {
+		if !containsID(excludedIDs, id) {
+			authorIDs = append(authorIDs, id)
+		}
+	}
+
+	return authorIDs, nil
+}
+
+func (s userServiceImpl) GetFollowers(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	followers, err := s.userRepository.GetFollowers(ctx, userID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return followers, nil
+}
+
+func (s userServiceImpl) GetFollowing(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	following, err := s.userRepository.GetFollowing(ctx, userID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return following, nil
+}
+
+func (s userServiceImpl) IsFollowing(ctx context.Context, userID, followedID uuid.UUID) (bool, error) {
+	following, err := s.userRepository.IsFollowing(ctx, userID, followedID)
+	if err != nil {
+		return false, err
+	}
+
+	return following, nil
+}
+
+// IsMuted reports whether userID has muted mutedID.
+func (s userServiceImpl) IsMuted(ctx context.Context, userID, mutedID uuid.UUID) (bool, error) {
+	mutedIDs, err := s.userRepository.GetMutedUserIDs(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return containsID(mutedIDs, mutedID), nil
+}
+
+func (s userServiceImpl) BlockUser(ctx context.Context, userID, blockedID uuid.UUID) error {
+	if userID == blockedID {
+		return domain.ErrSelfRelationship
+	}
+
+	return s.userRepository.BlockUser(ctx, userID, blockedID)
+}
+
+func (s userServiceImpl) UnblockUser(ctx context.Context, userID, blockedID uuid.UUID) error {
+	return s.userRepository.UnblockUser(ctx, userID, blockedID)
+}
+
+func (s userServiceImpl) MuteUser(ctx context.Context, userID, mutedID uuid.UUID) error {
+	if userID == mutedID {
+		return domain.ErrSelfRelationship
+	}
+
+	return s.userRepository.MuteUser(ctx, userID, mutedID)
+}
+
+func (s userServiceImpl) UnmuteUser(ctx context.Context, userID, mutedID uuid.UUID) error {
+	return s.userRepository.UnmuteUser(ctx, userID, mutedID)
+}
+
+// SetProtected changes whether userID's tweets are protected. Making the account public approves
+// its pending follow requests, as anyone may follow it from then on, except those between blocked users,
+// which are rejected.
+func (s userServiceImpl) SetProtected(ctx context.Context, userID uuid.UUID, protected bool) error {
+	if protected {
+		return s.userRepository.SetProtected(ctx, userID, true)
+	}
+
+	var approvedIDs []uuid.UUID
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.SetProtected(ctx, userID, false); err != nil {
+			return err
+		}
+
+		// Each request is dropped once handled, so the first page is read until it is empty.
+		for {
+			requesters, err := s.userRepository.GetFollowRequests(ctx, userID, domain.NewPagination(domain.MaxPageLimit, 0))
+			if err != nil {
+				return err
+			}
+
+			if len(requesters) == 0 {
+				return nil
+			}
+
+			for _, requester := range requesters {
+				blocked, err := isBlockedEitherWay(ctx, s.userRepository, requester.ID, userID)
+				if err != nil {
+					return err
+				}
+
+				if blocked {
+					err = s.userRepository.RejectFollowRequest(ctx, requester.ID, userID)
+				} else {
+					err = s.userRepository.ApproveFollowRequest(ctx, requester.ID, userID, domain.NewUserFollowedEvent(requester.ID, userID))
+					approvedIDs = append(approvedIDs, requester.ID)
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, followerID := range approvedIDs {
+		notify(ctx, s.notificationsRepository, domain.Notification{UserID: userID, ActorID: followerID, Type: domain.NotificationTypeFollow})
+	}
+
+	return nil
+}
+
+func (s userServiceImpl) GetFollowRequests(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	requesters, err := s.userRepository.GetFollowRequests(ctx, userID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return requesters, nil
+}
+
+func (s userServiceImpl) ApproveFollowRequest(ctx context.Context, userID, followerID uuid.UUID) error {
+	if err := s.userRepository.ApproveFollowRequest(ctx, followerID, userID, domain.NewUserFollowedEvent(followerID, userID)); err != nil {
+		return err
+	}
+
+	notify(ctx, s.notificationsRepository, domain.Notification{UserID: userID, ActorID: followerID, Type: domain.NotificationTypeFollow})
+	return nil
+}
+
+func (s userServiceImpl) RejectFollowRequest(ctx context.Context, userID, followerID uuid.UUID) error {
+	return s.userRepository.RejectFollowRequest(ctx, followerID, userID)
+}
+
+// filterTweetsByAuthor drops every tweet written by one of the excluded users.
+func filterTweetsByAuthor(tweets []domain.Tweet, excludedIDs []uuid.UUID) []domain.Tweet {
+	if len(excludedIDs) == 0 {
+		return tweets
+	}
+
+	excluded := make(map[uuid.UUID]struct{}, len(excludedIDs))
+	for _, id := range excludedIDs {
+		excluded[id] = struct{}{}
+	}
+
+	filtered := make([]domain.Tweet, 0, len(tweets))
+	for _, tweet := range tweets {
+		if _, ok := excluded[tweet.UserID]; !ok {
+			filtered = append(filtered, tweet)
+		}
+	}
+
+	return filtered
 }
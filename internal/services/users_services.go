@@ -9,6 +9,22 @@ import (
 type UserService interface {
 	CreateUser(ctx context.Context, name, mail string) (domain.User, error)
 	GetUser(ctx context.Context, id uuid.UUID) (domain.User, error)
-	FollowUser(ctx context.Context, userID, followedID uuid.UUID) error
+	GetUsers(ctx context.Context, ids []uuid.UUID) ([]domain.User, error)
+	GetUserAsViewer(ctx context.Context, viewerID, id uuid.UUID) (domain.User, error)
+	CanViewTweets(ctx context.Context, viewerID, authorID uuid.UUID) (bool, error)
+	FollowUser(ctx context.Context, userID, followedID uuid.UUID) (domain.FollowStatus, error)
 	GetUserTimeline(ctx context.Context, userID uuid.UUID) ([]domain.Tweet, error)
+	GetTimelineAuthorIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	GetFollowers(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error)
+	GetFollowing(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error)
+	IsFollowing(ctx context.Context, userID, followedID uuid.UUID) (bool, error)
+	IsMuted(ctx context.Context, userID, mutedID uuid.UUID) (bool, error)
+	BlockUser(ctx context.Context, userID, blockedID uuid.UUID) error
+	UnblockUser(ctx context.Context, userID, blockedID uuid.UUID) error
+	MuteUser(ctx context.Context, userID, mutedID uuid.UUID) error
+	UnmuteUser(ctx context.Context, userID, mutedID uuid.UUID) error
+	SetProtected(ctx context.Context, userID uuid.UUID, protected bool) error
+	GetFollowRequests(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error)
+	ApproveFollowRequest(ctx context.Context, userID, followerID uuid.UUID) error
+	RejectFollowRequest(ctx context.Context, userID, followerID uuid.UUID) error
 }
@@ -55,11 +55,11 @@ func TestUserService_CreateUser(t *testing.T) {
 
 			mockCtx := context.Background()
 			mockRepo := mock_ports.NewMockUsersRepository(ctrl)
-			s := NewUserService(mockRepo)
+			s := NewUserService(mockRepo, mock_ports.NewMockNotificationsRepository(ctrl), passThroughUnitOfWork(ctrl))
 
 			mockRepo.
 				EXPECT().
-				CreateUser(mockCtx, tc.mockInput).
+				CreateUser(mockCtx, withAssignedID(tc.mockInput), eventOfType(domain.EventTypeUserCreated)).
 				Return(tc.mockOutput, tc.mockErr)
 
 			got, err := s.CreateUser(mockCtx, tc.inputName, tc.inputEmail)
@@ -114,7 +114,7 @@ func TestUserService_GetUser(t *testing.T) {
 
 			mockCtx := context.Background()
 			mockRepo := mock_ports.NewMockUsersRepository(ctrl)
-			s := NewUserService(mockRepo)
+			s := NewUserService(mockRepo, mock_ports.NewMockNotificationsRepository(ctrl), passThroughUnitOfWork(ctrl))
 
 			mockRepo.
 				EXPECT().
@@ -140,27 +140,78 @@ func TestUserService_FollowUser(t *testing.T) {
 	followedID := uuid.New()
 
 	type testCase struct {
-		name       string
-		userID     uuid.UUID
-		followedID uuid.UUID
-		mockErr    error
-		wantErr    bool
+		name          string
+		userID        uuid.UUID
+		followedID    uuid.UUID
+		blocked       bool
+		blockedBy     bool
+		protected     bool
+		following     bool
+		expectFollow  bool
+		expected      domain.FollowStatus
+		mockErr       error
+		wantErr       bool
+		expectedError error
 	}
 
 	tests := []testCase{
 		{
-			name:       "Success case",
+			name:         "Success case",
+			userID:       userID,
+			followedID:   followedID,
+			expectFollow: true,
+			expected:     domain.FollowStatusFollowing,
+			mockErr:      nil,
+			wantErr:      false,
+		},
+		{
+			name:       "Protected account creates a follow request",
 			userID:     userID,
 			followedID: followedID,
-			mockErr:    nil,
+			protected:  true,
+			expected:   domain.FollowStatusPending,
 			wantErr:    false,
 		},
 		{
-			name:       "Repository error",
+			name:       "Protected account already followed",
 			userID:     userID,
 			followedID: followedID,
-			mockErr:    errors.New("follow operation failed"),
-			wantErr:    true,
+			protected:  true,
+			following:  true,
+			expected:   domain.FollowStatusFollowing,
+			wantErr:    false,
+		},
+		{
+			name:       "Public account already followed",
+			userID:     userID,
+			followedID: followedID,
+			following:  true,
+			expected:   domain.FollowStatusFollowing,
+			wantErr:    false,
+		},
+		{
+			name:         "Repository error",
+			userID:       userID,
+			followedID:   followedID,
+			expectFollow: true,
+			mockErr:      errors.New("follow operation failed"),
+			wantErr:      true,
+		},
+		{
+			name:          "Follower blocked the followed user",
+			userID:        userID,
+			followedID:    followedID,
+			blocked:       true,
+			wantErr:       true,
+			expectedError: domain.ErrUserBlocked,
+		},
+		{
+			name:          "Followed user blocked the follower",
+			userID:        userID,
+			followedID:    followedID,
+			blockedBy:     true,
+			wantErr:       true,
+			expectedError: domain.ErrUserBlocked,
 		},
 	}
 
@@ -171,18 +222,74 @@ func TestUserService_FollowUser(t *testing.T) {
 
 			mockCtx := context.Background()
 			mockRepo := mock_ports.NewMockUsersRepository(ctrl)
-			s := NewUserService(mockRepo)
+			mockNotifications := mock_ports.NewMockNotificationsRepository(ctrl)
+			s := NewUserService(mockRepo, mockNotifications, passThroughUnitOfWork(ctrl))
 
 			mockRepo.
 				EXPECT().
-				FollowUser(mockCtx, tc.userID, tc.followedID).
-				Return(tc.mockErr)
+				IsBlocked(mockCtx, tc.userID, tc.followedID).
+				Return(tc.blocked, nil)
+
+			if !tc.blocked {
+				mockRepo.
+					EXPECT().
+					IsBlocked(mockCtx, tc.followedID, tc.userID).
+					Return(tc.blockedBy, nil)
+			}
+
+			if !tc.blocked && !tc.blockedBy {
+				mockRepo.
+					EXPECT().
+					GetUser(mockCtx, tc.followedID).
+					Return(domain.User{ID: tc.followedID, Protected: tc.protected}, nil)
+			}
+
+			if !tc.blocked && !tc.blockedBy {
+				mockRepo.
+					EXPECT().
+					IsFollowing(mockCtx, tc.userID, tc.followedID).
+					Return(tc.following, nil)
+			}
+
+			if tc.protected && !tc.following {
+				mockRepo.
+					EXPECT().
+					CreateFollowRequest(mockCtx, tc.userID, tc.followedID).
+					Return(nil)
+			}
+
+			if tc.expectFollow {
+				mockRepo.
+					EXPECT().
+					FollowUser(mockCtx, tc.userID, tc.followedID, eventOfType(domain.EventTypeUserFollowed)).
+					Return(tc.mockErr)
+			}
+
+			if tc.expected != "" && !tc.following {
+				notificationType := domain.NotificationTypeFollow
+				if tc.expected == domain.FollowStatusPending {
+					notificationType = domain.NotificationTypeFollowRequest
+				}
 
-			err := s.FollowUser(mockCtx, tc.userID, tc.followedID)
+				mockNotifications.
+					EXPECT().
+					CreateNotification(mockCtx, domain.Notification{UserID: tc.followedID, ActorID: tc.userID, Type: notificationType}).
+					Return(domain.Notification{}, nil)
+			}
+
+			got, err := s.FollowUser(mockCtx, tc.userID, tc.followedID)
 
 			if (err != nil) != tc.wantErr {
 				t.Errorf("FollowUser() error = %v, wantErr = %v", err, tc.wantErr)
 			}
+
+			if got != tc.expected {
+				t.Errorf("FollowUser() got = %v, want = %v", got, tc.expected)
+			}
+
+			if tc.expectedError != nil && !errors.Is(err, tc.expectedError) {
+				t.Errorf("FollowUser() error = %v, want = %v", err, tc.expectedError)
+			}
 		})
 	}
 }
@@ -193,12 +300,21 @@ func TestUserService_GetUserTimeline(t *testing.T) {
 		{ID: uuid.New(), UserID: mockUUID, Message: "First tweet"},
 		{ID: uuid.New(), UserID: mockUUID, Message: "Second tweet"},
 	}
+	mutedUUID := uuid.New()
+	blockedUUID := uuid.New()
+	mixedTweets := []domain.Tweet{
+		mockTweets[0],
+		{ID: uuid.New(), UserID: mutedUUID, Message: "Muted tweet"},
+		{ID: uuid.New(), UserID: blockedUUID, Message: "Blocked tweet"},
+	}
 
 	type testCase struct {
 		name       string
 		inputID    uuid.UUID
 		mockOutput []domain.Tweet
 		mockErr    error
+		mutedIDs   []uuid.UUID
+		blockedIDs []uuid.UUID
 		expected   []domain.Tweet
 		wantErr    bool
 	}
@@ -228,6 +344,15 @@ func TestUserService_GetUserTimeline(t *testing.T) {
 			expected:   []domain.Tweet{},
 			wantErr:    false,
 		},
+		{
+			name:       "Muted and blocked authors are filtered out",
+			inputID:    mockUUID,
+			mockOutput: mixedTweets,
+			mutedIDs:   []uuid.UUID{mutedUUID},
+			blockedIDs: []uuid.UUID{blockedUUID},
+			expected:   []domain.Tweet{mockTweets[0]},
+			wantErr:    false,
+		},
 	}
 
 	for _, tc := range tests {
@@ -237,13 +362,18 @@ func TestUserService_GetUserTimeline(t *testing.T) {
 
 			mockCtx := context.Background()
 			mockRepo := mock_ports.NewMockUsersRepository(ctrl)
-			s := NewUserService(mockRepo)
+			s := NewUserService(mockRepo, mock_ports.NewMockNotificationsRepository(ctrl), passThroughUnitOfWork(ctrl))
 
 			mockRepo.
 				EXPECT().
 				GetUserTimeline(mockCtx, tc.inputID).
 				Return(tc.mockOutput, tc.mockErr)
 
+			if tc.mockErr == nil {
+				mockRepo.EXPECT().GetBlockedUserIDs(mockCtx, tc.inputID).Return(tc.blockedIDs, nil)
+				mockRepo.EXPECT().GetMutedUserIDs(mockCtx, tc.inputID).Return(tc.mutedIDs, nil)
+			}
+
 			got, err := s.GetUserTimeline(mockCtx, tc.inputID)
 
 			if (err != nil) != tc.wantErr {
@@ -256,4 +386,382 @@ func TestUserService_GetUserTimeline(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestUserService_GetFollowers(t *testing.T) {
+	mockUUID := uuid.New()
+	page := domain.NewPagination(10, 0)
+	mockFollowers := []domain.User{
+		{ID: uuid.New(), Name: "Follower One"},
+		{ID: uuid.New(), Name: "Follower Two"},
+	}
+
+	type testCase struct {
+		name       string
+		mockOutput []domain.User
+		mockErr    error
+		expected   []domain.User
+		wantErr    bool
+	}
+
+	tests := []testCase{
+		{
+			name:       "Success case",
+			mockOutput: mockFollowers,
+			expected:   mockFollowers,
+		},
+		{
+			name:    "Repository error",
+			mockErr: errors.New("followers fetch failed"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCtx := context.Background()
+			mockRepo := mock_ports.NewMockUsersRepository(ctrl)
+			s := NewUserService(mockRepo, mock_ports.NewMockNotificationsRepository(ctrl), passThroughUnitOfWork(ctrl))
+
+			mockRepo.
+				EXPECT().
+				GetFollowers(mockCtx, mockUUID, page).
+				Return(tc.mockOutput, tc.mockErr)
+
+			got, err := s.GetFollowers(mockCtx, mockUUID, page)
+
+			if (err != nil) != tc.wantErr {
+				t.Errorf("GetFollowers() error = %v, wantErr = %v", err, tc.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("GetFollowers() got = %v, want = %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestUserService_IsFollowing(t *testing.T) {
+	userID := uuid.New()
+	followedID := uuid.New()
+
+	type testCase struct {
+		name       string
+		mockOutput bool
+		mockErr    error
+		expected   bool
+		wantErr    bool
+	}
+
+	tests := []testCase{
+		{
+			name:       "Following",
+			mockOutput: true,
+			expected:   true,
+		},
+		{
+			name:       "Not following",
+			mockOutput: false,
+			expected:   false,
+		},
+		{
+			name:    "Repository error",
+			mockErr: errors.New("relationship lookup failed"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCtx := context.Background()
+			mockRepo := mock_ports.NewMockUsersRepository(ctrl)
+			s := NewUserService(mockRepo, mock_ports.NewMockNotificationsRepository(ctrl), passThroughUnitOfWork(ctrl))
+
+			mockRepo.
+				EXPECT().
+				IsFollowing(mockCtx, userID, followedID).
+				Return(tc.mockOutput, tc.mockErr)
+
+			got, err := s.IsFollowing(mockCtx, userID, followedID)
+
+			if (err != nil) != tc.wantErr {
+				t.Errorf("IsFollowing() error = %v, wantErr = %v", err, tc.wantErr)
+				return
+			}
+
+			if got != tc.expected {
+				t.Errorf("IsFollowing() got = %v, want = %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestUserService_IsMuted(t *testing.T) {
+	userID := uuid.New()
+	mutedID := uuid.New()
+
+	type testCase struct {
+		name       string
+		mockOutput []uuid.UUID
+		mockErr    error
+		expected   bool
+		wantErr    bool
+	}
+
+	tests := []testCase{
+		{
+			name:       "Muted",
+			mockOutput: []uuid.UUID{uuid.New(), mutedID},
+			expected:   true,
+		},
+		{
+			name:       "Not muted",
+			mockOutput: []uuid.UUID{uuid.New()},
+			expected:   false,
+		},
+		{
+			name:    "Repository error",
+			mockErr: errors.New("mutes lookup failed"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCtx := context.Background()
+			mockRepo := mock_ports.NewMockUsersRepository(ctrl)
+			s := NewUserService(mockRepo, mock_ports.NewMockNotificationsRepository(ctrl), passThroughUnitOfWork(ctrl))
+
+			mockRepo.
+				EXPECT().
+				GetMutedUserIDs(mockCtx, userID).
+				Return(tc.mockOutput, tc.mockErr)
+
+			got, err := s.IsMuted(mockCtx, userID, mutedID)
+
+			if (err != nil) != tc.wantErr {
+				t.Errorf("IsMuted() error = %v, wantErr = %v", err, tc.wantErr)
+				return
+			}
+
+			if got != tc.expected {
+				t.Errorf("IsMuted() got = %v, want = %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestUserService_BlockUser(t *testing.T) {
+	userID := uuid.New()
+	blockedID := uuid.New()
+
+	type testCase struct {
+		name          string
+		blockedID     uuid.UUID
+		expectRepo    bool
+		mockErr       error
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:       "Success case",
+			blockedID:  blockedID,
+			expectRepo: true,
+		},
+		{
+			name:          "Repository error",
+			blockedID:     blockedID,
+			expectRepo:    true,
+			mockErr:       errors.New("block operation failed"),
+			expectedError: errors.New("block operation failed"),
+		},
+		{
+			name:          "Blocking yourself",
+			blockedID:     userID,
+			expectedError: domain.ErrSelfRelationship,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCtx := context.Background()
+			mockRepo := mock_ports.NewMockUsersRepository(ctrl)
+			s := NewUserService(mockRepo, mock_ports.NewMockNotificationsRepository(ctrl), passThroughUnitOfWork(ctrl))
+
+			if tc.expectRepo {
+				mockRepo.
+					EXPECT().
+					BlockUser(mockCtx, userID, tc.blockedID).
+					Return(tc.mockErr)
+			}
+
+			err := s.BlockUser(mockCtx, userID, tc.blockedID)
+
+			if !reflect.DeepEqual(err, tc.expectedError) {
+				t.Errorf("BlockUser() error = %v, want = %v", err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestUserService_GetUserAsViewer(t *testing.T) {
+	ownerID := uuid.New()
+	viewerID := uuid.New()
+	type testCase struct {
+		name                string
+		viewerID            uuid.UUID
+		protected           bool
+		following           bool
+		expectedTweetsCount int
+	}
+
+	tests := []testCase{
+		{
+			name:                "Owner sees their own tweets",
+			viewerID:            ownerID,
+			protected:           true,
+			expectedTweetsCount: 3,
+		},
+		{
+			name:                "Follower sees protected tweets",
+			viewerID:            viewerID,
+			protected:           true,
+			following:           true,
+			expectedTweetsCount: 3,
+		},
+		{
+			name:                "Non-follower does not see protected tweets",
+			viewerID:            viewerID,
+			protected:           true,
+			expectedTweetsCount: 0,
+		},
+		{
+			name:                "Anyone sees public tweets",
+			viewerID:            viewerID,
+			expectedTweetsCount: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCtx := context.Background()
+			mockRepo := mock_ports.NewMockUsersRepository(ctrl)
+			s := NewUserService(mockRepo, mock_ports.NewMockNotificationsRepository(ctrl), passThroughUnitOfWork(ctrl))
+
+			mockRepo.EXPECT().
+				GetUser(mockCtx, ownerID).
+				Return(domain.User{ID: ownerID, Protected: tc.protected, TweetsCount: 3}, nil)
+			if tc.viewerID != ownerID {
+				mockRepo.EXPECT().IsBlocked(mockCtx, gomock.Any(), gomock.Any()).Return(false, nil).Times(2)
+			}
+			if tc.viewerID != ownerID && tc.protected {
+				mockRepo.EXPECT().IsFollowing(mockCtx, tc.viewerID, ownerID).Return(tc.following, nil)
+			}
+
+			got, err := s.GetUserAsViewer(mockCtx, tc.viewerID, ownerID)
+			if err != nil {
+				t.Fatalf("GetUserAsViewer() unexpected error = %v", err)
+			}
+
+			if got.TweetsCount != tc.expectedTweetsCount {
+				t.Errorf("GetUserAsViewer() tweets count = %v, want = %v", got.TweetsCount, tc.expectedTweetsCount)
+			}
+		})
+	}
+}
+
+func TestUserService_GetTimelineAuthorIDs(t *testing.T) {
+	userID := uuid.New()
+	followedID := uuid.New()
+	mutedID := uuid.New()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCtx := context.Background()
+	mockRepo := mock_ports.NewMockUsersRepository(ctrl)
+	s := NewUserService(mockRepo, mock_ports.NewMockNotificationsRepository(ctrl), passThroughUnitOfWork(ctrl))
+
+	mockRepo.EXPECT().GetFollowedUserIDs(mockCtx, userID).Return([]uuid.UUID{followedID, mutedID}, nil)
+	mockRepo.EXPECT().GetBlockedUserIDs(mockCtx, userID).Return(nil, nil)
+	mockRepo.EXPECT().GetMutedUserIDs(mockCtx, userID).Return([]uuid.UUID{mutedID}, nil)
+
+	got, err := s.GetTimelineAuthorIDs(mockCtx, userID)
+	if err != nil {
+		t.Fatalf("GetTimelineAuthorIDs() unexpected error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, []uuid.UUID{followedID}) {
+		t.Errorf("GetTimelineAuthorIDs() got = %v, want = %v", got, []uuid.UUID{followedID})
+	}
+}
+
+func TestUserService_SetProtected(t *testing.T) {
+	userID := uuid.New()
+	requesterID := uuid.New()
+	blockedID := uuid.New()
+	page := domain.NewPagination(domain.MaxPageLimit, 0)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCtx := context.Background()
+	mockRepo := mock_ports.NewMockUsersRepository(ctrl)
+	mockNotifications := mock_ports.NewMockNotificationsRepository(ctrl)
+	s := NewUserService(mockRepo, mockNotifications, passThroughUnitOfWork(ctrl))
+
+	// Protecting the account leaves the follow requests alone.
+	mockRepo.EXPECT().SetProtected(mockCtx, userID, true).Return(nil)
+	if err := s.SetProtected(mockCtx, userID, true); err != nil {
+		t.Fatalf("SetProtected() unexpected error = %v", err)
+	}
+
+	// Making it public approves the pending requests, except across a block.
+	gomock.InOrder(
+		mockRepo.EXPECT().SetProtected(mockCtx, userID, false).Return(nil),
+		mockRepo.EXPECT().GetFollowRequests(mockCtx, userID, page).Return([]domain.User{{ID: requesterID}, {ID: blockedID}}, nil),
+		mockRepo.EXPECT().GetFollowRequests(mockCtx, userID, page).Return([]domain.User{}, nil),
+	)
+	mockRepo.EXPECT().IsBlocked(mockCtx, userID, blockedID).Return(true, nil)
+	mockRepo.EXPECT().IsBlocked(mockCtx, gomock.Any(), gomock.Any()).Return(false, nil).Times(3)
+	mockRepo.EXPECT().ApproveFollowRequest(mockCtx, requesterID, userID, eventOfType(domain.EventTypeUserFollowed)).Return(nil)
+	mockRepo.EXPECT().RejectFollowRequest(mockCtx, blockedID, userID).Return(nil)
+	mockNotifications.EXPECT().
+		CreateNotification(mockCtx, domain.Notification{UserID: userID, ActorID: requesterID, Type: domain.NotificationTypeFollow}).
+		Return(domain.Notification{}, nil)
+
+	if err := s.SetProtected(mockCtx, userID, false); err != nil {
+		t.Fatalf("SetProtected() unexpected error = %v", err)
+	}
+}
+
+// passThroughUnitOfWork runs units of work directly on the mocked repositories.
+func passThroughUnitOfWork(ctrl *gomock.Controller) *mock_ports.MockUnitOfWork {
+	unitOfWork := mock_ports.NewMockUnitOfWork(ctrl)
+	unitOfWork.
+		EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	return unitOfWork
+}
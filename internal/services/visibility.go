@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	ports "github.com/juanignaciorc/microbloggin-pltf/internal/ports/repositories"
+)
+
+// canViewTweets reports whether viewerID may read the tweets of author.
+// A uuid.Nil viewer is anonymous and only sees public, unblocked accounts' tweets.
+func canViewTweets(ctx context.Context, usersRepository ports.UsersRepository, viewerID uuid.UUID, author domain.User) (bool, error) {
+	if viewerID == author.ID {
+		return true, nil
+	}
+
+	if viewerID != uuid.Nil {
+		blocked, err := isBlockedEitherWay(ctx, usersRepository, viewerID, author.ID)
+		if err != nil || blocked {
+			return false, err
+		}
+	}
+
+	if !author.Protected {
+		return true, nil
+	}
+
+	if viewerID == uuid.Nil {
+		return false, nil
+	}
+
+	return usersRepository.IsFollowing(ctx, viewerID, author.ID)
+}
+
+// isBlockedEitherWay reports whether either user has blocked the other.
+func isBlockedEitherWay(ctx context.Context, usersRepository ports.UsersRepository, userID, otherID uuid.UUID) (bool, error) {
+	blocked, err := usersRepository.IsBlocked(ctx, userID, otherID)
+	if err != nil || blocked {
+		return blocked, err
+	}
+
+	return usersRepository.IsBlocked(ctx, otherID, userID)
+}
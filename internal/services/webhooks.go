@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	ports "github.com/juanignaciorc/microbloggin-pltf/internal/ports/repositories"
+	"net"
+	"net/netip"
+	"net/url"
+	"time"
+)
+
+type webhooksServiceImpl struct {
+	webhooksRepository ports.WebhooksRepository
+	// lookupHost resolves the host of a webhook URL; IP literals resolve to themselves.
+	lookupHost func(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// NewWebhooksService creates a new WebhookService instance.
+func NewWebhooksService(webhooksRepository ports.WebhooksRepository) WebhookService {
+	return &webhooksServiceImpl{
+		webhooksRepository: webhooksRepository,
+		lookupHost:         net.DefaultResolver.LookupNetIP,
+	}
+}
+
+// CreateWebhook subscribes rawURL to the given event types. When secret is empty
+// a random one is generated; it is only ever returned here. The tweets of protected
+// accounts are only delivered when includeProtected is set.
+func (s *webhooksServiceImpl) CreateWebhook(ctx context.Context, rawURL string, events []domain.EventType, secret string, includeProtected bool) (domain.Webhook, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return domain.Webhook{}, fmt.Errorf("%w: url must be an absolute http or https URL", domain.ErrInvalidWebhook)
+	}
+
+	if err := s.checkHost(ctx, parsed.Hostname()); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	if len(events) == 0 {
+		return domain.Webhook{}, fmt.Errorf("%w: at least one event is required", domain.ErrInvalidWebhook)
+	}
+
+	var subscribed []domain.EventType
+	for _, event := range events {
+		if !domain.IsKnownEventType(event) {
+			return domain.Webhook{}, fmt.Errorf("%w: unknown event %q", domain.ErrInvalidWebhook, event)
+		}
+		if !containsEventType(subscribed, event) {
+			subscribed = append(subscribed, event)
+		}
+	}
+
+	if secret == "" {
+		secret, err = generateSecret()
+		if err != nil {
+			return domain.Webhook{}, err
+		}
+	}
+
+	return s.webhooksRepository.CreateWebhook(ctx, domain.Webhook{URL: rawURL, Events: subscribed, IncludeProtected: includeProtected, Secret: secret})
+}
+
+// checkHost rejects hosts that don't resolve or resolve to an address that isn't
+// public, such as the loopback or private ones of the API's own network. The
+// deliverer checks the address again when it connects, as the name may resolve
+// to another one by then.
+func (s *webhooksServiceImpl) checkHost(ctx context.Context, host string) error {
+	addrs, err := s.lookupHost(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("%w: cannot resolve %s", domain.ErrInvalidWebhook, host)
+	}
+
+	for _, addr := range addrs {
+		if !domain.IsPublicAddress(addr) {
+			return fmt.Errorf("%w: url must not point to a loopback, link-local or private address", domain.ErrInvalidWebhook)
+		}
+	}
+	return nil
+}
+
+func (s *webhooksServiceImpl) GetWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	return s.webhooksRepository.GetWebhooks(ctx)
+}
+
+func (s *webhooksServiceImpl) GetWebhook(ctx context.Context, id uuid.UUID) (domain.Webhook, error) {
+	return s.webhooksRepository.GetWebhook(ctx, id)
+}
+
+func (s *webhooksServiceImpl) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	return s.webhooksRepository.DeleteWebhook(ctx, id)
+}
+
+func (s *webhooksServiceImpl) GetDeliveries(ctx context.Context, webhookID uuid.UUID, status domain.WebhookDeliveryStatus, page domain.Pagination) ([]domain.WebhookDelivery, error) {
+	if _, err := s.webhooksRepository.GetWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	return s.webhooksRepository.GetDeliveries(ctx, webhookID, status, page)
+}
+
+// RetryDelivery schedules a delivery, typically a dead-lettered one, for an
+// immediate attempt with a fresh retry budget.
+func (s *webhooksServiceImpl) RetryDelivery(ctx context.Context, webhookID, deliveryID uuid.UUID) (domain.WebhookDelivery, error) {
+	delivery, err := s.webhooksRepository.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	if delivery.WebhookID != webhookID {
+		return domain.WebhookDelivery{}, domain.ErrWebhookDeliveryNotFound
+	}
+
+	now := time.Now().UTC()
+	delivery.Status = domain.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = now
+	delivery.UpdatedAt = now
+
+	if err := s.webhooksRepository.UpdateDelivery(ctx, delivery); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
+
+func containsEventType(eventTypes []domain.EventType, eventType domain.EventType) bool {
+	for _, t := range eventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
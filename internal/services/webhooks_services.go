@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+)
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, url string, events []domain.EventType, secret string, includeProtected bool) (domain.Webhook, error)
+	GetWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	GetWebhook(ctx context.Context, id uuid.UUID) (domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	GetDeliveries(ctx context.Context, webhookID uuid.UUID, status domain.WebhookDeliveryStatus, page domain.Pagination) ([]domain.WebhookDelivery, error)
+	RetryDelivery(ctx context.Context, webhookID, deliveryID uuid.UUID) (domain.WebhookDelivery, error)
+}
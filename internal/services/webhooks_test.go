@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	mock_ports "github.com/juanignaciorc/microbloggin-pltf/mocks"
+	"go.uber.org/mock/gomock"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+// lookupHost stands in for DNS in the tests: example.com is public, internal.example.com
+// resolves to a private address and every other name is unknown.
+func lookupHost(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return []netip.Addr{addr}, nil
+	}
+
+	switch host {
+	case "example.com":
+		return []netip.Addr{netip.MustParseAddr("93.184.215.14"), netip.MustParseAddr("2606:2800:21f:cb07:6820:80da:af6b:8b2c")}, nil
+	case "internal.example.com":
+		return []netip.Addr{netip.MustParseAddr("93.184.215.14"), netip.MustParseAddr("10.0.0.12")}, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func TestWebhooksService_CreateWebhook(t *testing.T) {
+	type testCase struct {
+		name           string
+		url            string
+		events         []domain.EventType
+		secret         string
+		expectedEvents []domain.EventType
+		expectedError  error
+	}
+
+	tests := []testCase{
+		{
+			name:           "Success case",
+			url:            "https://example.com/hook",
+			events:         []domain.EventType{domain.EventTypeTweetCreated, domain.EventTypeUserFollowed, domain.EventTypeTweetCreated},
+			secret:         "s3cret",
+			expectedEvents: []domain.EventType{domain.EventTypeTweetCreated, domain.EventTypeUserFollowed},
+		},
+		{
+			name:           "Generates a secret when none is given",
+			url:            "http://93.184.215.14:9000",
+			events:         []domain.EventType{domain.EventTypeUserCreated},
+			expectedEvents: []domain.EventType{domain.EventTypeUserCreated},
+		},
+		{
+			name:          "Loopback address",
+			url:           "http://127.0.0.1:9000",
+			events:        []domain.EventType{domain.EventTypeUserCreated},
+			expectedError: domain.ErrInvalidWebhook,
+		},
+		{
+			name:          "Link-local address",
+			url:           "http://169.254.169.254/latest/meta-data",
+			events:        []domain.EventType{domain.EventTypeUserCreated},
+			expectedError: domain.ErrInvalidWebhook,
+		},
+		{
+			name:          "IPv4-mapped private address",
+			url:           "http://[::ffff:192.168.1.1]/hook",
+			events:        []domain.EventType{domain.EventTypeUserCreated},
+			expectedError: domain.ErrInvalidWebhook,
+		},
+		{
+			name:          "Name resolving to a private address",
+			url:           "https://internal.example.com/hook",
+			events:        []domain.EventType{domain.EventTypeUserCreated},
+			expectedError: domain.ErrInvalidWebhook,
+		},
+		{
+			name:          "Unresolvable name",
+			url:           "https://localhost/hook",
+			events:        []domain.EventType{domain.EventTypeUserCreated},
+			expectedError: domain.ErrInvalidWebhook,
+		},
+		{
+			name:          "Relative URL",
+			url:           "/hook",
+			events:        []domain.EventType{domain.EventTypeUserCreated},
+			expectedError: domain.ErrInvalidWebhook,
+		},
+		{
+			name:          "Unsupported scheme",
+			url:           "ftp://example.com",
+			events:        []domain.EventType{domain.EventTypeUserCreated},
+			expectedError: domain.ErrInvalidWebhook,
+		},
+		{
+			name:          "No events",
+			url:           "https://example.com/hook",
+			expectedError: domain.ErrInvalidWebhook,
+		},
+		{
+			name:          "Unknown event",
+			url:           "https://example.com/hook",
+			events:        []domain.EventType{"tweet.deleted"},
+			expectedError: domain.ErrInvalidWebhook,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCtx := context.Background()
+			mockRepo := mock_ports.NewMockWebhooksRepository(ctrl)
+			s := &webhooksServiceImpl{webhooksRepository: mockRepo, lookupHost: lookupHost}
+
+			if tc.expectedError == nil {
+				mockRepo.
+					EXPECT().
+					CreateWebhook(mockCtx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+						return webhook, nil
+					})
+			}
+
+			got, err := s.CreateWebhook(mockCtx, tc.url, tc.events, tc.secret, false)
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("CreateWebhook() error = %v, want = %v", err, tc.expectedError)
+				return
+			}
+
+			if !reflect.DeepEqual(got.Events, tc.expectedEvents) {
+				t.Errorf("CreateWebhook() events = %v, want = %v", got.Events, tc.expectedEvents)
+			}
+
+			if tc.expectedError == nil && got.Secret == "" {
+				t.Errorf("CreateWebhook() secret is empty")
+			}
+			if tc.secret != "" && got.Secret != tc.secret {
+				t.Errorf("CreateWebhook() secret = %v, want = %v", got.Secret, tc.secret)
+			}
+		})
+	}
+}
+
+func TestWebhooksService_RetryDelivery(t *testing.T) {
+	webhookID := uuid.New()
+	delivery := domain.WebhookDelivery{ID: uuid.New(), WebhookID: webhookID, Status: domain.WebhookDeliveryDead, Attempts: 8}
+
+	type testCase struct {
+		name          string
+		webhookID     uuid.UUID
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:      "Success case",
+			webhookID: webhookID,
+		},
+		{
+			name:          "Delivery of another webhook",
+			webhookID:     uuid.New(),
+			expectedError: domain.ErrWebhookDeliveryNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCtx := context.Background()
+			mockRepo := mock_ports.NewMockWebhooksRepository(ctrl)
+			s := NewWebhooksService(mockRepo)
+
+			mockRepo.EXPECT().GetDelivery(mockCtx, delivery.ID).Return(delivery, nil)
+			if tc.expectedError == nil {
+				mockRepo.EXPECT().UpdateDelivery(mockCtx, gomock.Any()).Return(nil)
+			}
+
+			got, err := s.RetryDelivery(mockCtx, tc.webhookID, delivery.ID)
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("RetryDelivery() error = %v, want = %v", err, tc.expectedError)
+				return
+			}
+
+			if tc.expectedError == nil && (got.Status != domain.WebhookDeliveryPending || got.Attempts != 0) {
+				t.Errorf("RetryDelivery() got status = %v attempts = %v, want pending with 0 attempts", got.Status, got.Attempts)
+			}
+		})
+	}
+}
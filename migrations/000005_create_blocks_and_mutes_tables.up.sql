@@ -0,0 +1,11 @@
+CREATE TABLE blocks (
+                        blocker_id UUID NOT NULL REFERENCES users(id),
+                        blocked_id UUID NOT NULL REFERENCES users(id),
+                        PRIMARY KEY(blocker_id, blocked_id)
+);
+
+CREATE TABLE mutes (
+                       muter_id UUID NOT NULL REFERENCES users(id),
+                       muted_id UUID NOT NULL REFERENCES users(id),
+                       PRIMARY KEY(muter_id, muted_id)
+);
@@ -0,0 +1,8 @@
+ALTER TABLE users ADD COLUMN protected BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE follow_requests (
+                                 user_id UUID NOT NULL REFERENCES users(id),
+                                 follower_id UUID NOT NULL REFERENCES users(id),
+                                 created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                 PRIMARY KEY(user_id, follower_id)
+);
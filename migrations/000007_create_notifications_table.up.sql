@@ -0,0 +1,12 @@
+CREATE TABLE notifications (
+                               id UUID PRIMARY KEY,
+                               user_id UUID NOT NULL REFERENCES users(id),
+                               actor_id UUID NOT NULL REFERENCES users(id),
+                               type VARCHAR(32) NOT NULL,
+                               tweet_id UUID REFERENCES tweets(id),
+                               read BOOLEAN NOT NULL DEFAULT FALSE,
+                               created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Index on notifications.user_id for listing a user's notifications newest first
+CREATE INDEX idx_notifications_user_id_created_at ON notifications(user_id, created_at DESC);
@@ -0,0 +1,10 @@
+CREATE TABLE outbox_events (
+                               id UUID PRIMARY KEY,
+                               type VARCHAR(64) NOT NULL,
+                               payload JSONB NOT NULL,
+                               occurred_at TIMESTAMPTZ NOT NULL,
+                               dispatched_at TIMESTAMPTZ
+);
+
+-- Partial index so the dispatcher only scans events that are still pending
+CREATE INDEX idx_outbox_events_pending ON outbox_events(occurred_at) WHERE dispatched_at IS NULL;
@@ -0,0 +1,30 @@
+CREATE TABLE webhooks (
+                          id UUID PRIMARY KEY,
+                          url TEXT NOT NULL,
+                          events TEXT[] NOT NULL,
+                          secret TEXT NOT NULL,
+                          created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- The payload is stored as TEXT rather than JSONB so retries are signed over the exact same bytes
+CREATE TABLE webhook_deliveries (
+                                    id UUID PRIMARY KEY,
+                                    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+                                    event_id UUID NOT NULL,
+                                    event_type VARCHAR(64) NOT NULL,
+                                    payload TEXT NOT NULL,
+                                    status VARCHAR(16) NOT NULL,
+                                    attempts INT NOT NULL DEFAULT 0,
+                                    last_status_code INT NOT NULL DEFAULT 0,
+                                    last_error TEXT NOT NULL DEFAULT '',
+                                    next_attempt_at TIMESTAMPTZ NOT NULL,
+                                    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                    UNIQUE (webhook_id, event_id)
+);
+
+-- Index for the delivery log of a webhook, newest first
+CREATE INDEX idx_webhook_deliveries_webhook_id_created_at ON webhook_deliveries(webhook_id, created_at DESC);
+
+-- Partial index so the delivery worker only scans pending deliveries
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tweets_user_id_created_at;
+
+ALTER TABLE tweets DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,3 @@
+ALTER TABLE tweets ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX idx_tweets_user_id_created_at ON tweets(user_id, created_at DESC);
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS tweets_count_tweet ON tweets;
+DROP FUNCTION IF EXISTS count_tweet();
+DROP TRIGGER IF EXISTS followers_count_follow ON followers;
+DROP FUNCTION IF EXISTS count_follow();
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS followers_count,
+    DROP COLUMN IF EXISTS following_count,
+    DROP COLUMN IF EXISTS tweets_count;
@@ -0,0 +1,42 @@
+ALTER TABLE users
+    ADD COLUMN followers_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN following_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN tweets_count INTEGER NOT NULL DEFAULT 0;
+
+UPDATE users u SET
+    followers_count = (SELECT count(*) FROM followers f WHERE f.user_id = u.id),
+    following_count = (SELECT count(*) FROM followers f WHERE f.follower_id = u.id),
+    tweets_count = (SELECT count(*) FROM tweets t WHERE t.user_id = u.id);
+
+-- The counters are kept by triggers so every write path, bulk imports included, updates them
+CREATE FUNCTION count_follow() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE users SET followers_count = followers_count + 1 WHERE id = NEW.user_id;
+        UPDATE users SET following_count = following_count + 1 WHERE id = NEW.follower_id;
+    ELSE
+        UPDATE users SET followers_count = followers_count - 1 WHERE id = OLD.user_id;
+        UPDATE users SET following_count = following_count - 1 WHERE id = OLD.follower_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER followers_count_follow
+    AFTER INSERT OR DELETE ON followers
+    FOR EACH ROW EXECUTE FUNCTION count_follow();
+
+CREATE FUNCTION count_tweet() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE users SET tweets_count = tweets_count + 1 WHERE id = NEW.user_id;
+    ELSE
+        UPDATE users SET tweets_count = tweets_count - 1 WHERE id = OLD.user_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tweets_count_tweet
+    AFTER INSERT OR DELETE ON tweets
+    FOR EACH ROW EXECUTE FUNCTION count_tweet();
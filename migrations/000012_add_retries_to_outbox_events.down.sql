@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+
+ALTER TABLE outbox_events
+    DROP COLUMN IF EXISTS attempts,
+    DROP COLUMN IF EXISTS next_attempt_at,
+    DROP COLUMN IF EXISTS dead_at;
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events(occurred_at) WHERE dispatched_at IS NULL;
@@ -0,0 +1,11 @@
+ALTER TABLE outbox_events
+    ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN next_attempt_at TIMESTAMPTZ,
+    ADD COLUMN dead_at TIMESTAMPTZ;
+
+UPDATE outbox_events SET next_attempt_at = occurred_at;
+ALTER TABLE outbox_events ALTER COLUMN next_attempt_at SET NOT NULL;
+
+-- Failed events wait for their next attempt, and dead ones are out of the dispatcher's way
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+CREATE INDEX idx_outbox_events_pending ON outbox_events(next_attempt_at, id) WHERE dispatched_at IS NULL AND dead_at IS NULL;
@@ -0,0 +1,88 @@
+package migrations
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		assert.Equal(t, int64(i+1), migration.Version, "versions must have no gaps")
+		assert.NotEmpty(t, migration.Up, "migration %d", migration.Version)
+	}
+	assert.Equal(t, "create_users_table", migrations[0].Name)
+}
+
+func TestLoadSQLite(t *testing.T) {
+	postgres, err := Load()
+	assert.NoError(t, err)
+	sqlite, err := LoadSQLite()
+	assert.NoError(t, err)
+
+	// Both backends go through the same schema versions.
+	if !assert.Equal(t, len(postgres), len(sqlite)) {
+		return
+	}
+	for i := range sqlite {
+		assert.Equal(t, postgres[i].Version, sqlite[i].Version)
+		assert.Equal(t, postgres[i].Name, sqlite[i].Name)
+		assert.NotEmpty(t, sqlite[i].Up, "migration %d", sqlite[i].Version)
+	}
+}
+
+func TestLoadFS(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         fstest.MapFS
+		expected      []Migration
+		expectedError string
+	}{
+		{
+			name: "Sorted by version, other files ignored",
+			files: fstest.MapFS{
+				"000010_second.up.sql":   {Data: []byte("CREATE TABLE b ();")},
+				"000010_second.down.sql": {Data: []byte("DROP TABLE b;")},
+				"000002_first.up.sql":    {Data: []byte("CREATE TABLE a ();")},
+				"000002_first.down.sql":  {Data: []byte("")},
+				"README.md":              {Data: []byte("notes")},
+			},
+			expected: []Migration{
+				{Version: 2, Name: "first", Up: "CREATE TABLE a ();", Down: ""},
+				{Version: 10, Name: "second", Up: "CREATE TABLE b ();", Down: "DROP TABLE b;"},
+			},
+		},
+		{
+			name: "Missing down file",
+			files: fstest.MapFS{
+				"000001_first.up.sql": {Data: []byte("CREATE TABLE a ();")},
+			},
+			expectedError: "migration 1_first needs both an up and a down file",
+		},
+		{
+			name: "Version with two names",
+			files: fstest.MapFS{
+				"000001_first.up.sql":   {Data: []byte("CREATE TABLE a ();")},
+				"000001_other.down.sql": {Data: []byte("DROP TABLE a;")},
+			},
+			expectedError: "migration 1 has two names: first and other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := LoadFS(tt.files)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, migrations)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+-- SQLite has no UUID or timestamp types: IDs are stored as text, and times as UTC
+-- text with nine fractional digits (2006-01-02T15:04:05.000000000Z) so they sort in time order.
+CREATE TABLE users (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    email TEXT NOT NULL
+);
@@ -0,0 +1,5 @@
+CREATE TABLE tweets (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id),
+    message TEXT NOT NULL CHECK (length(message) <= 280)
+);
@@ -0,0 +1,5 @@
+CREATE TABLE followers (
+    user_id TEXT NOT NULL REFERENCES users(id),
+    follower_id TEXT NOT NULL REFERENCES users(id),
+    PRIMARY KEY(user_id, follower_id)
+);
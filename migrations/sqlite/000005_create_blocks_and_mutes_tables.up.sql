@@ -0,0 +1,11 @@
+CREATE TABLE blocks (
+    blocker_id TEXT NOT NULL REFERENCES users(id),
+    blocked_id TEXT NOT NULL REFERENCES users(id),
+    PRIMARY KEY(blocker_id, blocked_id)
+);
+
+CREATE TABLE mutes (
+    muter_id TEXT NOT NULL REFERENCES users(id),
+    muted_id TEXT NOT NULL REFERENCES users(id),
+    PRIMARY KEY(muter_id, muted_id)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS follow_requests;
+ALTER TABLE users DROP COLUMN protected;
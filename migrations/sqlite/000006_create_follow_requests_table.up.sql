@@ -0,0 +1,8 @@
+ALTER TABLE users ADD COLUMN protected BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE follow_requests (
+    user_id TEXT NOT NULL REFERENCES users(id),
+    follower_id TEXT NOT NULL REFERENCES users(id),
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000000Z', 'now')),
+    PRIMARY KEY(user_id, follower_id)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE notifications (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id),
+    actor_id TEXT NOT NULL REFERENCES users(id),
+    type TEXT NOT NULL,
+    tweet_id TEXT REFERENCES tweets(id),
+    read BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000000Z', 'now'))
+);
+
+-- Index on notifications.user_id for listing a user's notifications newest first
+CREATE INDEX idx_notifications_user_id_created_at ON notifications(user_id, created_at DESC);
@@ -0,0 +1,10 @@
+CREATE TABLE outbox_events (
+    id TEXT PRIMARY KEY,
+    type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    occurred_at TEXT NOT NULL,
+    dispatched_at TEXT
+);
+
+-- Partial index so the dispatcher only scans events that are still pending
+CREATE INDEX idx_outbox_events_pending ON outbox_events(occurred_at) WHERE dispatched_at IS NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tweets_user_id_created_at;
+ALTER TABLE tweets DROP COLUMN created_at;
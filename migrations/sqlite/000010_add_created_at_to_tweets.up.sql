@@ -0,0 +1,5 @@
+-- SQLite only adds columns with a constant default, so existing tweets are stamped afterwards
+ALTER TABLE tweets ADD COLUMN created_at TEXT NOT NULL DEFAULT '';
+UPDATE tweets SET created_at = strftime('%Y-%m-%dT%H:%M:%f000000Z', 'now');
+
+CREATE INDEX idx_tweets_user_id_created_at ON tweets(user_id, created_at DESC);
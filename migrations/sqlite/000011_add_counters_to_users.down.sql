@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS tweets_count_delete;
+DROP TRIGGER IF EXISTS tweets_count_insert;
+DROP TRIGGER IF EXISTS followers_count_delete;
+DROP TRIGGER IF EXISTS followers_count_insert;
+
+ALTER TABLE users DROP COLUMN followers_count;
+ALTER TABLE users DROP COLUMN following_count;
+ALTER TABLE users DROP COLUMN tweets_count;
@@ -0,0 +1,31 @@
+ALTER TABLE users ADD COLUMN followers_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN following_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN tweets_count INTEGER NOT NULL DEFAULT 0;
+
+UPDATE users SET
+    followers_count = (SELECT count(*) FROM followers f WHERE f.user_id = users.id),
+    following_count = (SELECT count(*) FROM followers f WHERE f.follower_id = users.id),
+    tweets_count = (SELECT count(*) FROM tweets t WHERE t.user_id = users.id);
+
+-- The counters are kept by triggers so every write path, bulk imports included, updates them
+CREATE TRIGGER followers_count_insert AFTER INSERT ON followers
+BEGIN
+    UPDATE users SET followers_count = followers_count + 1 WHERE id = NEW.user_id;
+    UPDATE users SET following_count = following_count + 1 WHERE id = NEW.follower_id;
+END;
+
+CREATE TRIGGER followers_count_delete AFTER DELETE ON followers
+BEGIN
+    UPDATE users SET followers_count = followers_count - 1 WHERE id = OLD.user_id;
+    UPDATE users SET following_count = following_count - 1 WHERE id = OLD.follower_id;
+END;
+
+CREATE TRIGGER tweets_count_insert AFTER INSERT ON tweets
+BEGIN
+    UPDATE users SET tweets_count = tweets_count + 1 WHERE id = NEW.user_id;
+END;
+
+CREATE TRIGGER tweets_count_delete AFTER DELETE ON tweets
+BEGIN
+    UPDATE users SET tweets_count = tweets_count - 1 WHERE id = OLD.user_id;
+END;
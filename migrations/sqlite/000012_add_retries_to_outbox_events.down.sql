@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+ALTER TABLE outbox_events DROP COLUMN attempts;
+ALTER TABLE outbox_events DROP COLUMN next_attempt_at;
+ALTER TABLE outbox_events DROP COLUMN dead_at;
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events(occurred_at) WHERE dispatched_at IS NULL;
@@ -0,0 +1,8 @@
+ALTER TABLE outbox_events ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
+-- SQLite only adds columns with a constant default, so pending events are scheduled afterwards
+ALTER TABLE outbox_events ADD COLUMN next_attempt_at TEXT NOT NULL DEFAULT '';
+ALTER TABLE outbox_events ADD COLUMN dead_at TEXT;
+UPDATE outbox_events SET next_attempt_at = occurred_at;
+
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+CREATE INDEX idx_outbox_events_pending ON outbox_events(next_attempt_at, id) WHERE dispatched_at IS NULL AND dead_at IS NULL;
@@ -0,0 +1,2 @@
+-- Webhooks only get the tweets of protected accounts when created with include_protected
+ALTER TABLE webhooks ADD COLUMN include_protected INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/ports/repositories/bulk_repos.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../internal/ports/repositories/bulk_repos.go -destination=./mock_bulk_repository.go -package=mock_ports
+//
+
+// Package mock_ports is a generated GoMock package.
+package mock_ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBulkRepository is a mock of BulkRepository interface.
+type MockBulkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBulkRepositoryMockRecorder
+}
+
+// MockBulkRepositoryMockRecorder is the mock recorder for MockBulkRepository.
+type MockBulkRepositoryMockRecorder struct {
+	mock *MockBulkRepository
+}
+
+// NewMockBulkRepository creates a new mock instance.
+func NewMockBulkRepository(ctrl *gomock.Controller) *MockBulkRepository {
+	mock := &MockBulkRepository{ctrl: ctrl}
+	mock.recorder = &MockBulkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBulkRepository) EXPECT() *MockBulkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ExportFollows mocks base method.
+func (m *MockBulkRepository) ExportFollows(ctx context.Context, fn func(domain.Follow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportFollows", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportFollows indicates an expected call of ExportFollows.
+func (mr *MockBulkRepositoryMockRecorder) ExportFollows(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportFollows", reflect.TypeOf((*MockBulkRepository)(nil).ExportFollows), ctx, fn)
+}
+
+// ExportTweets mocks base method.
+func (m *MockBulkRepository) ExportTweets(ctx context.Context, fn func(domain.Tweet) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTweets", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportTweets indicates an expected call of ExportTweets.
+func (mr *MockBulkRepositoryMockRecorder) ExportTweets(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTweets", reflect.TypeOf((*MockBulkRepository)(nil).ExportTweets), ctx, fn)
+}
+
+// ExportUsers mocks base method.
+func (m *MockBulkRepository) ExportUsers(ctx context.Context, fn func(domain.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUsers", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportUsers indicates an expected call of ExportUsers.
+func (mr *MockBulkRepositoryMockRecorder) ExportUsers(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUsers", reflect.TypeOf((*MockBulkRepository)(nil).ExportUsers), ctx, fn)
+}
+
+// ImportFollows mocks base method.
+func (m *MockBulkRepository) ImportFollows(ctx context.Context, follows []domain.Follow) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportFollows", ctx, follows)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportFollows indicates an expected call of ImportFollows.
+func (mr *MockBulkRepositoryMockRecorder) ImportFollows(ctx, follows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportFollows", reflect.TypeOf((*MockBulkRepository)(nil).ImportFollows), ctx, follows)
+}
+
+// ImportTweets mocks base method.
+func (m *MockBulkRepository) ImportTweets(ctx context.Context, tweets []domain.Tweet) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTweets", ctx, tweets)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTweets indicates an expected call of ImportTweets.
+func (mr *MockBulkRepositoryMockRecorder) ImportTweets(ctx, tweets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTweets", reflect.TypeOf((*MockBulkRepository)(nil).ImportTweets), ctx, tweets)
+}
+
+// ImportUsers mocks base method.
+func (m *MockBulkRepository) ImportUsers(ctx context.Context, users []domain.User) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportUsers", ctx, users)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportUsers indicates an expected call of ImportUsers.
+func (mr *MockBulkRepositoryMockRecorder) ImportUsers(ctx, users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportUsers", reflect.TypeOf((*MockBulkRepository)(nil).ImportUsers), ctx, users)
+}
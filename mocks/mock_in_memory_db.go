@@ -21,7 +21,6 @@ import (
 type MockInMemoryDBTweetsInterface struct {
 	ctrl     *gomock.Controller
 	recorder *MockInMemoryDBTweetsInterfaceMockRecorder
-	isgomock struct{}
 }
 
 // MockInMemoryDBTweetsInterfaceMockRecorder is the mock recorder for MockInMemoryDBTweetsInterface.
@@ -42,16 +41,21 @@ func (m *MockInMemoryDBTweetsInterface) EXPECT() *MockInMemoryDBTweetsInterfaceM
 }
 
 // CreateTweet mocks base method.
-func (m *MockInMemoryDBTweetsInterface) CreateTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, error) {
+func (m *MockInMemoryDBTweetsInterface) CreateTweet(ctx context.Context, tweet domain.Tweet, events ...domain.Event) (domain.Tweet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTweet", ctx, tweet)
+	varargs := []any{ctx, tweet}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateTweet", varargs...)
 	ret0, _ := ret[0].(domain.Tweet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTweet indicates an expected call of CreateTweet.
-func (mr *MockInMemoryDBTweetsInterfaceMockRecorder) CreateTweet(ctx, tweet any) *gomock.Call {
+func (mr *MockInMemoryDBTweetsInterfaceMockRecorder) CreateTweet(ctx, tweet any, events ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTweet", reflect.TypeOf((*MockInMemoryDBTweetsInterface)(nil).CreateTweet), ctx, tweet)
+	varargs := append([]any{ctx, tweet}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTweet", reflect.TypeOf((*MockInMemoryDBTweetsInterface)(nil).CreateTweet), varargs...)
 }
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/ports/repositories/notifications_repos.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../internal/ports/repositories/notifications_repos.go -destination=./mock_notifications_repository.go -package=mock_ports
+//
+
+// Package mock_ports is a generated GoMock package.
+package mock_ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	domain "github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationsRepository is a mock of NotificationsRepository interface.
+type MockNotificationsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationsRepositoryMockRecorder
+}
+
+// MockNotificationsRepositoryMockRecorder is the mock recorder for MockNotificationsRepository.
+type MockNotificationsRepositoryMockRecorder struct {
+	mock *MockNotificationsRepository
+}
+
+// NewMockNotificationsRepository creates a new mock instance.
+func NewMockNotificationsRepository(ctrl *gomock.Controller) *MockNotificationsRepository {
+	mock := &MockNotificationsRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationsRepository) EXPECT() *MockNotificationsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountUnreadNotifications mocks base method.
+func (m *MockNotificationsRepository) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadNotifications", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadNotifications indicates an expected call of CountUnreadNotifications.
+func (mr *MockNotificationsRepositoryMockRecorder) CountUnreadNotifications(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadNotifications", reflect.TypeOf((*MockNotificationsRepository)(nil).CountUnreadNotifications), ctx, userID)
+}
+
+// CreateNotification mocks base method.
+func (m *MockNotificationsRepository) CreateNotification(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", ctx, notification)
+	ret0, _ := ret[0].(domain.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockNotificationsRepositoryMockRecorder) CreateNotification(ctx, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockNotificationsRepository)(nil).CreateNotification), ctx, notification)
+}
+
+// GetNotifications mocks base method.
+func (m *MockNotificationsRepository) GetNotifications(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, userID, page)
+	ret0, _ := ret[0].([]domain.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockNotificationsRepositoryMockRecorder) GetNotifications(ctx, userID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockNotificationsRepository)(nil).GetNotifications), ctx, userID, page)
+}
+
+// MarkNotificationsRead mocks base method.
+func (m *MockNotificationsRepository) MarkNotificationsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationsRead", ctx, userID, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationsRead indicates an expected call of MarkNotificationsRead.
+func (mr *MockNotificationsRepositoryMockRecorder) MarkNotificationsRead(ctx, userID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationsRead", reflect.TypeOf((*MockNotificationsRepository)(nil).MarkNotificationsRead), ctx, userID, ids)
+}
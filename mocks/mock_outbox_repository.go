@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/ports/repositories/outbox_repos.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../internal/ports/repositories/outbox_repos.go -destination=./mock_outbox_repository.go -package=mock_ports
+//
+
+// Package mock_ports is a generated GoMock package.
+package mock_ports
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	domain "github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPendingEvents mocks base method.
+func (m *MockOutboxRepository) GetPendingEvents(ctx context.Context, now time.Time, limit int) ([]domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingEvents", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingEvents indicates an expected call of GetPendingEvents.
+func (mr *MockOutboxRepositoryMockRecorder) GetPendingEvents(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingEvents", reflect.TypeOf((*MockOutboxRepository)(nil).GetPendingEvents), ctx, now, limit)
+}
+
+// MarkEventFailed mocks base method.
+func (m *MockOutboxRepository) MarkEventFailed(ctx context.Context, event domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventFailed", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventFailed indicates an expected call of MarkEventFailed.
+func (mr *MockOutboxRepositoryMockRecorder) MarkEventFailed(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventFailed", reflect.TypeOf((*MockOutboxRepository)(nil).MarkEventFailed), ctx, event)
+}
+
+// MarkEventsDispatched mocks base method.
+func (m *MockOutboxRepository) MarkEventsDispatched(ctx context.Context, ids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventsDispatched", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventsDispatched indicates an expected call of MarkEventsDispatched.
+func (mr *MockOutboxRepositoryMockRecorder) MarkEventsDispatched(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventsDispatched", reflect.TypeOf((*MockOutboxRepository)(nil).MarkEventsDispatched), ctx, ids)
+}
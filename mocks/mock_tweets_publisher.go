@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/ports/repositories/tweets_publisher.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../internal/ports/repositories/tweets_publisher.go -destination=./mock_tweets_publisher.go -package=mock_ports
+//
+
+// Package mock_ports is a generated GoMock package.
+package mock_ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/juanignaciorc/microbloggin-pltf/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTweetPublisher is a mock of TweetPublisher interface.
+type MockTweetPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockTweetPublisherMockRecorder
+}
+
+// MockTweetPublisherMockRecorder is the mock recorder for MockTweetPublisher.
+type MockTweetPublisherMockRecorder struct {
+	mock *MockTweetPublisher
+}
+
+// NewMockTweetPublisher creates a new mock instance.
+func NewMockTweetPublisher(ctrl *gomock.Controller) *MockTweetPublisher {
+	mock := &MockTweetPublisher{ctrl: ctrl}
+	mock.recorder = &MockTweetPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTweetPublisher) EXPECT() *MockTweetPublisherMockRecorder {
+	return m.recorder
+}
+
+// PublishTweet mocks base method.
+func (m *MockTweetPublisher) PublishTweet(ctx context.Context, tweet domain.Tweet) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PublishTweet", ctx, tweet)
+}
+
+// PublishTweet indicates an expected call of PublishTweet.
+func (mr *MockTweetPublisherMockRecorder) PublishTweet(ctx, tweet any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishTweet", reflect.TypeOf((*MockTweetPublisher)(nil).PublishTweet), ctx, tweet)
+}
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=../internal/ports/repositories/tweets_repos.go -destination=./mock_tweets_repository.go
+//	mockgen -source=../internal/ports/repositories/tweets_repos.go -destination=./mock_tweets_repository.go -package=mock_ports
 //
 
 // Package mock_ports is a generated GoMock package.
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	uuid "github.com/google/uuid"
 	domain "github.com/juanignaciorc/microbloggin-pltf/internal/domain"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,16 +42,51 @@ func (m *MockTweetRepository) EXPECT() *MockTweetRepositoryMockRecorder {
 }
 
 // CreateTweet mocks base method.
-func (m *MockTweetRepository) CreateTweet(ctx context.Context, tweet domain.Tweet) (domain.Tweet, error) {
+func (m *MockTweetRepository) CreateTweet(ctx context.Context, tweet domain.Tweet, events ...domain.Event) (domain.Tweet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTweet", ctx, tweet)
+	varargs := []any{ctx, tweet}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateTweet", varargs...)
 	ret0, _ := ret[0].(domain.Tweet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTweet indicates an expected call of CreateTweet.
-func (mr *MockTweetRepositoryMockRecorder) CreateTweet(ctx, tweet any) *gomock.Call {
+func (mr *MockTweetRepositoryMockRecorder) CreateTweet(ctx, tweet any, events ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTweet", reflect.TypeOf((*MockTweetRepository)(nil).CreateTweet), ctx, tweet)
+	varargs := append([]any{ctx, tweet}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTweet", reflect.TypeOf((*MockTweetRepository)(nil).CreateTweet), varargs...)
+}
+
+// GetTweet mocks base method.
+func (m *MockTweetRepository) GetTweet(ctx context.Context, id uuid.UUID) (domain.Tweet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTweet", ctx, id)
+	ret0, _ := ret[0].(domain.Tweet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTweet indicates an expected call of GetTweet.
+func (mr *MockTweetRepositoryMockRecorder) GetTweet(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTweet", reflect.TypeOf((*MockTweetRepository)(nil).GetTweet), ctx, id)
+}
+
+// GetUserTweets mocks base method.
+func (m *MockTweetRepository) GetUserTweets(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.Tweet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTweets", ctx, userID, page)
+	ret0, _ := ret[0].([]domain.Tweet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTweets indicates an expected call of GetUserTweets.
+func (mr *MockTweetRepositoryMockRecorder) GetUserTweets(ctx, userID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTweets", reflect.TypeOf((*MockTweetRepository)(nil).GetUserTweets), ctx, userID, page)
 }
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: ../internal/ports/services/tweets_services.go
+// Source: ../internal/services/tweets_services.go
 //
 // Generated by this command:
 //
-//	mockgen -source=../internal/ports/services/tweets_services.go -destination=./mock_tweets_service.go
+//	mockgen -source=../internal/services/tweets_services.go -destination=./mock_tweets_service.go -package=mock_ports
 //
 
 // Package mock_ports is a generated GoMock package.
@@ -55,3 +55,33 @@ func (mr *MockTweetServiceMockRecorder) CreateTweet(ctx, userID, message any) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTweet", reflect.TypeOf((*MockTweetService)(nil).CreateTweet), ctx, userID, message)
 }
+
+// GetTweet mocks base method.
+func (m *MockTweetService) GetTweet(ctx context.Context, viewerID, tweetID uuid.UUID) (domain.Tweet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTweet", ctx, viewerID, tweetID)
+	ret0, _ := ret[0].(domain.Tweet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTweet indicates an expected call of GetTweet.
+func (mr *MockTweetServiceMockRecorder) GetTweet(ctx, viewerID, tweetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTweet", reflect.TypeOf((*MockTweetService)(nil).GetTweet), ctx, viewerID, tweetID)
+}
+
+// GetUserTweets mocks base method.
+func (m *MockTweetService) GetUserTweets(ctx context.Context, viewerID, authorID uuid.UUID, page domain.Pagination) ([]domain.Tweet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTweets", ctx, viewerID, authorID, page)
+	ret0, _ := ret[0].([]domain.Tweet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTweets indicates an expected call of GetUserTweets.
+func (mr *MockTweetServiceMockRecorder) GetUserTweets(ctx, viewerID, authorID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTweets", reflect.TypeOf((*MockTweetService)(nil).GetUserTweets), ctx, viewerID, authorID, page)
+}
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=../internal/ports/repositories/users_repos.go -destination=mocks
+//	mockgen -source=../internal/ports/repositories/users_repos.go -destination=./mock_users_repository.go -package=mock_ports
 //
 
 // Package mock_ports is a generated GoMock package.
@@ -41,33 +41,180 @@ func (m *MockUsersRepository) EXPECT() *MockUsersRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ApproveFollowRequest mocks base method.
+func (m *MockUsersRepository) ApproveFollowRequest(ctx context.Context, followerID, userID uuid.UUID, events ...domain.Event) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, followerID, userID}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ApproveFollowRequest", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveFollowRequest indicates an expected call of ApproveFollowRequest.
+func (mr *MockUsersRepositoryMockRecorder) ApproveFollowRequest(ctx, followerID, userID any, events ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, followerID, userID}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveFollowRequest", reflect.TypeOf((*MockUsersRepository)(nil).ApproveFollowRequest), varargs...)
+}
+
+// BlockUser mocks base method.
+func (m *MockUsersRepository) BlockUser(ctx context.Context, userID, blockedID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUser", ctx, userID, blockedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockUser indicates an expected call of BlockUser.
+func (mr *MockUsersRepositoryMockRecorder) BlockUser(ctx, userID, blockedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUser", reflect.TypeOf((*MockUsersRepository)(nil).BlockUser), ctx, userID, blockedID)
+}
+
+// CreateFollowRequest mocks base method.
+func (m *MockUsersRepository) CreateFollowRequest(ctx context.Context, followerID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFollowRequest", ctx, followerID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFollowRequest indicates an expected call of CreateFollowRequest.
+func (mr *MockUsersRepositoryMockRecorder) CreateFollowRequest(ctx, followerID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFollowRequest", reflect.TypeOf((*MockUsersRepository)(nil).CreateFollowRequest), ctx, followerID, userID)
+}
+
 // CreateUser mocks base method.
-func (m *MockUsersRepository) CreateUser(ctx context.Context, user domain.User) (domain.User, error) {
+func (m *MockUsersRepository) CreateUser(ctx context.Context, user domain.User, events ...domain.Event) (domain.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", ctx, user)
+	varargs := []any{ctx, user}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateUser", varargs...)
 	ret0, _ := ret[0].(domain.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *MockUsersRepositoryMockRecorder) CreateUser(ctx, user any) *gomock.Call {
+func (mr *MockUsersRepositoryMockRecorder) CreateUser(ctx, user any, events ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUsersRepository)(nil).CreateUser), ctx, user)
+	varargs := append([]any{ctx, user}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUsersRepository)(nil).CreateUser), varargs...)
 }
 
 // FollowUser mocks base method.
-func (m *MockUsersRepository) FollowUser(ctx context.Context, userID, followedID uuid.UUID) error {
+func (m *MockUsersRepository) FollowUser(ctx context.Context, userID, followedID uuid.UUID, events ...domain.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FollowUser", ctx, userID, followedID)
+	varargs := []any{ctx, userID, followedID}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FollowUser", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // FollowUser indicates an expected call of FollowUser.
-func (mr *MockUsersRepositoryMockRecorder) FollowUser(ctx, userID, followedID any) *gomock.Call {
+func (mr *MockUsersRepositoryMockRecorder) FollowUser(ctx, userID, followedID any, events ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, userID, followedID}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowUser", reflect.TypeOf((*MockUsersRepository)(nil).FollowUser), varargs...)
+}
+
+// GetBlockedUserIDs mocks base method.
+func (m *MockUsersRepository) GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockedUserIDs", ctx, userID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockedUserIDs indicates an expected call of GetBlockedUserIDs.
+func (mr *MockUsersRepositoryMockRecorder) GetBlockedUserIDs(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockedUserIDs", reflect.TypeOf((*MockUsersRepository)(nil).GetBlockedUserIDs), ctx, userID)
+}
+
+// GetFollowRequests mocks base method.
+func (m *MockUsersRepository) GetFollowRequests(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowRequests", ctx, userID, page)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowRequests indicates an expected call of GetFollowRequests.
+func (mr *MockUsersRepositoryMockRecorder) GetFollowRequests(ctx, userID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowRequests", reflect.TypeOf((*MockUsersRepository)(nil).GetFollowRequests), ctx, userID, page)
+}
+
+// GetFollowedUserIDs mocks base method.
+func (m *MockUsersRepository) GetFollowedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowedUserIDs", ctx, userID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowedUserIDs indicates an expected call of GetFollowedUserIDs.
+func (mr *MockUsersRepositoryMockRecorder) GetFollowedUserIDs(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowedUserIDs", reflect.TypeOf((*MockUsersRepository)(nil).GetFollowedUserIDs), ctx, userID)
+}
+
+// GetFollowers mocks base method.
+func (m *MockUsersRepository) GetFollowers(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowers", ctx, userID, page)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowers indicates an expected call of GetFollowers.
+func (mr *MockUsersRepositoryMockRecorder) GetFollowers(ctx, userID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowers", reflect.TypeOf((*MockUsersRepository)(nil).GetFollowers), ctx, userID, page)
+}
+
+// GetFollowing mocks base method.
+func (m *MockUsersRepository) GetFollowing(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowing", ctx, userID, page)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowing indicates an expected call of GetFollowing.
+func (mr *MockUsersRepositoryMockRecorder) GetFollowing(ctx, userID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowing", reflect.TypeOf((*MockUsersRepository)(nil).GetFollowing), ctx, userID, page)
+}
+
+// GetMutedUserIDs mocks base method.
+func (m *MockUsersRepository) GetMutedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMutedUserIDs", ctx, userID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMutedUserIDs indicates an expected call of GetMutedUserIDs.
+func (mr *MockUsersRepositoryMockRecorder) GetMutedUserIDs(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowUser", reflect.TypeOf((*MockUsersRepository)(nil).FollowUser), ctx, userID, followedID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMutedUserIDs", reflect.TypeOf((*MockUsersRepository)(nil).GetMutedUserIDs), ctx, userID)
 }
 
 // GetUser mocks base method.
@@ -99,3 +246,118 @@ func (mr *MockUsersRepositoryMockRecorder) GetUserTimeline(ctx, userID any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTimeline", reflect.TypeOf((*MockUsersRepository)(nil).GetUserTimeline), ctx, userID)
 }
+
+// GetUsers mocks base method.
+func (m *MockUsersRepository) GetUsers(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, ids)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockUsersRepositoryMockRecorder) GetUsers(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockUsersRepository)(nil).GetUsers), ctx, ids)
+}
+
+// IsBlocked mocks base method.
+func (m *MockUsersRepository) IsBlocked(ctx context.Context, userID, blockedID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBlocked", ctx, userID, blockedID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlocked indicates an expected call of IsBlocked.
+func (mr *MockUsersRepositoryMockRecorder) IsBlocked(ctx, userID, blockedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlocked", reflect.TypeOf((*MockUsersRepository)(nil).IsBlocked), ctx, userID, blockedID)
+}
+
+// IsFollowing mocks base method.
+func (m *MockUsersRepository) IsFollowing(ctx context.Context, userID, followedID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFollowing", ctx, userID, followedID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsFollowing indicates an expected call of IsFollowing.
+func (mr *MockUsersRepositoryMockRecorder) IsFollowing(ctx, userID, followedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFollowing", reflect.TypeOf((*MockUsersRepository)(nil).IsFollowing), ctx, userID, followedID)
+}
+
+// MuteUser mocks base method.
+func (m *MockUsersRepository) MuteUser(ctx context.Context, userID, mutedID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteUser", ctx, userID, mutedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MuteUser indicates an expected call of MuteUser.
+func (mr *MockUsersRepositoryMockRecorder) MuteUser(ctx, userID, mutedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteUser", reflect.TypeOf((*MockUsersRepository)(nil).MuteUser), ctx, userID, mutedID)
+}
+
+// RejectFollowRequest mocks base method.
+func (m *MockUsersRepository) RejectFollowRequest(ctx context.Context, followerID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectFollowRequest", ctx, followerID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectFollowRequest indicates an expected call of RejectFollowRequest.
+func (mr *MockUsersRepositoryMockRecorder) RejectFollowRequest(ctx, followerID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectFollowRequest", reflect.TypeOf((*MockUsersRepository)(nil).RejectFollowRequest), ctx, followerID, userID)
+}
+
+// SetProtected mocks base method.
+func (m *MockUsersRepository) SetProtected(ctx context.Context, userID uuid.UUID, protected bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProtected", ctx, userID, protected)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProtected indicates an expected call of SetProtected.
+func (mr *MockUsersRepositoryMockRecorder) SetProtected(ctx, userID, protected any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProtected", reflect.TypeOf((*MockUsersRepository)(nil).SetProtected), ctx, userID, protected)
+}
+
+// UnblockUser mocks base method.
+func (m *MockUsersRepository) UnblockUser(ctx context.Context, userID, blockedID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnblockUser", ctx, userID, blockedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnblockUser indicates an expected call of UnblockUser.
+func (mr *MockUsersRepositoryMockRecorder) UnblockUser(ctx, userID, blockedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnblockUser", reflect.TypeOf((*MockUsersRepository)(nil).UnblockUser), ctx, userID, blockedID)
+}
+
+// UnmuteUser mocks base method.
+func (m *MockUsersRepository) UnmuteUser(ctx context.Context, userID, mutedID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmuteUser", ctx, userID, mutedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmuteUser indicates an expected call of UnmuteUser.
+func (mr *MockUsersRepositoryMockRecorder) UnmuteUser(ctx, userID, mutedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteUser", reflect.TypeOf((*MockUsersRepository)(nil).UnmuteUser), ctx, userID, mutedID)
+}
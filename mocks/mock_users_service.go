@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: ../internal/ports/services/users_services.go
+// Source: ../internal/services/users_services.go
 //
 // Generated by this command:
 //
-//	mockgen -source=../internal/ports/services/users_services.go -destination=./mock_users_service.go
+//	mockgen -source=../internal/services/users_services.go -destination=./mock_users_service.go -package=mock_ports
 //
 
 // Package mock_ports is a generated GoMock package.
@@ -41,6 +41,49 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 	return m.recorder
 }
 
+// ApproveFollowRequest mocks base method.
+func (m *MockUserService) ApproveFollowRequest(ctx context.Context, userID, followerID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveFollowRequest", ctx, userID, followerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveFollowRequest indicates an expected call of ApproveFollowRequest.
+func (mr *MockUserServiceMockRecorder) ApproveFollowRequest(ctx, userID, followerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveFollowRequest", reflect.TypeOf((*MockUserService)(nil).ApproveFollowRequest), ctx, userID, followerID)
+}
+
+// BlockUser mocks base method.
+func (m *MockUserService) BlockUser(ctx context.Context, userID, blockedID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUser", ctx, userID, blockedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockUser indicates an expected call of BlockUser.
+func (mr *MockUserServiceMockRecorder) BlockUser(ctx, userID, blockedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUser", reflect.TypeOf((*MockUserService)(nil).BlockUser), ctx, userID, blockedID)
+}
+
+// CanViewTweets mocks base method.
+func (m *MockUserService) CanViewTweets(ctx context.Context, viewerID, authorID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanViewTweets", ctx, viewerID, authorID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanViewTweets indicates an expected call of CanViewTweets.
+func (mr *MockUserServiceMockRecorder) CanViewTweets(ctx, viewerID, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanViewTweets", reflect.TypeOf((*MockUserService)(nil).CanViewTweets), ctx, viewerID, authorID)
+}
+
 // CreateUser mocks base method.
 func (m *MockUserService) CreateUser(ctx context.Context, name, mail string) (domain.User, error) {
 	m.ctrl.T.Helper()
@@ -57,11 +100,12 @@ func (mr *MockUserServiceMockRecorder) CreateUser(ctx, name, mail any) *gomock.C
 }
 
 // FollowUser mocks base method.
-func (m *MockUserService) FollowUser(ctx context.Context, userID, followedID uuid.UUID) error {
+func (m *MockUserService) FollowUser(ctx context.Context, userID, followedID uuid.UUID) (domain.FollowStatus, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FollowUser", ctx, userID, followedID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(domain.FollowStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // FollowUser indicates an expected call of FollowUser.
@@ -70,6 +114,66 @@ func (mr *MockUserServiceMockRecorder) FollowUser(ctx, userID, followedID any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowUser", reflect.TypeOf((*MockUserService)(nil).FollowUser), ctx, userID, followedID)
 }
 
+// GetFollowRequests mocks base method.
+func (m *MockUserService) GetFollowRequests(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowRequests", ctx, userID, page)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowRequests indicates an expected call of GetFollowRequests.
+func (mr *MockUserServiceMockRecorder) GetFollowRequests(ctx, userID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowRequests", reflect.TypeOf((*MockUserService)(nil).GetFollowRequests), ctx, userID, page)
+}
+
+// GetFollowers mocks base method.
+func (m *MockUserService) GetFollowers(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowers", ctx, userID, page)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowers indicates an expected call of GetFollowers.
+func (mr *MockUserServiceMockRecorder) GetFollowers(ctx, userID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowers", reflect.TypeOf((*MockUserService)(nil).GetFollowers), ctx, userID, page)
+}
+
+// GetFollowing mocks base method.
+func (m *MockUserService) GetFollowing(ctx context.Context, userID uuid.UUID, page domain.Pagination) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowing", ctx, userID, page)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowing indicates an expected call of GetFollowing.
+func (mr *MockUserServiceMockRecorder) GetFollowing(ctx, userID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowing", reflect.TypeOf((*MockUserService)(nil).GetFollowing), ctx, userID, page)
+}
+
+// GetTimelineAuthorIDs mocks base method.
+func (m *MockUserService) GetTimelineAuthorIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimelineAuthorIDs", ctx, userID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimelineAuthorIDs indicates an expected call of GetTimelineAuthorIDs.
+func (mr *MockUserServiceMockRecorder) GetTimelineAuthorIDs(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimelineAuthorIDs", reflect.TypeOf((*MockUserService)(nil).GetTimelineAuthorIDs), ctx, userID)
+}
+
 // GetUser mocks base method.
 func (m *MockUserService) GetUser(ctx context.Context, id uuid.UUID) (domain.User, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +189,21 @@ func (mr *MockUserServiceMockRecorder) GetUser(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserService)(nil).GetUser), ctx, id)
 }
 
+// GetUserAsViewer mocks base method.
+func (m *MockUserService) GetUserAsViewer(ctx context.Context, viewerID, id uuid.UUID) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAsViewer", ctx, viewerID, id)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAsViewer indicates an expected call of GetUserAsViewer.
+func (mr *MockUserServiceMockRecorder) GetUserAsViewer(ctx, viewerID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAsViewer", reflect.TypeOf((*MockUserService)(nil).GetUserAsViewer), ctx, viewerID, id)
+}
+
 // GetUserTimeline mocks base method.
 func (m *MockUserService) GetUserTimeline(ctx context.Context, userID uuid.UUID) ([]domain.Tweet, error) {
 	m.ctrl.T.Helper()
@@ -99,3 +218,118 @@ func (mr *MockUserServiceMockRecorder) GetUserTimeline(ctx, userID any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTimeline", reflect.TypeOf((*MockUserService)(nil).GetUserTimeline), ctx, userID)
 }
+
+// GetUsers mocks base method.
+func (m *MockUserService) GetUsers(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, ids)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockUserServiceMockRecorder) GetUsers(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockUserService)(nil).GetUsers), ctx, ids)
+}
+
+// IsFollowing mocks base method.
+func (m *MockUserService) IsFollowing(ctx context.Context, userID, followedID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFollowing", ctx, userID, followedID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsFollowing indicates an expected call of IsFollowing.
+func (mr *MockUserServiceMockRecorder) IsFollowing(ctx, userID, followedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFollowing", reflect.TypeOf((*MockUserService)(nil).IsFollowing), ctx, userID, followedID)
+}
+
+// IsMuted mocks base method.
+func (m *MockUserService) IsMuted(ctx context.Context, userID, mutedID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsMuted", ctx, userID, mutedID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsMuted indicates an expected call of IsMuted.
+func (mr *MockUserServiceMockRecorder) IsMuted(ctx, userID, mutedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMuted", reflect.TypeOf((*MockUserService)(nil).IsMuted), ctx, userID, mutedID)
+}
+
+// MuteUser mocks base method.
+func (m *MockUserService) MuteUser(ctx context.Context, userID, mutedID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteUser", ctx, userID, mutedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MuteUser indicates an expected call of MuteUser.
+func (mr *MockUserServiceMockRecorder) MuteUser(ctx, userID, mutedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteUser", reflect.TypeOf((*MockUserService)(nil).MuteUser), ctx, userID, mutedID)
+}
+
+// RejectFollowRequest mocks base method.
+func (m *MockUserService) RejectFollowRequest(ctx context.Context, userID, followerID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectFollowRequest", ctx, userID, followerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectFollowRequest indicates an expected call of RejectFollowRequest.
+func (mr *MockUserServiceMockRecorder) RejectFollowRequest(ctx, userID, followerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectFollowRequest", reflect.TypeOf((*MockUserService)(nil).RejectFollowRequest), ctx, userID, followerID)
+}
+
+// SetProtected mocks base method.
+func (m *MockUserService) SetProtected(ctx context.Context, userID uuid.UUID, protected bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProtected", ctx, userID, protected)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProtected indicates an expected call of SetProtected.
+func (mr *MockUserServiceMockRecorder) SetProtected(ctx, userID, protected any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProtected", reflect.TypeOf((*MockUserService)(nil).SetProtected), ctx, userID, protected)
+}
+
+// UnblockUser mocks base method.
+func (m *MockUserService) UnblockUser(ctx context.Context, userID, blockedID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnblockUser", ctx, userID, blockedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnblockUser indicates an expected call of UnblockUser.
+func (mr *MockUserServiceMockRecorder) UnblockUser(ctx, userID, blockedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnblockUser", reflect.TypeOf((*MockUserService)(nil).UnblockUser), ctx, userID, blockedID)
+}
+
+// UnmuteUser mocks base method.
+func (m *MockUserService) UnmuteUser(ctx context.Context, userID, mutedID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmuteUser", ctx, userID, mutedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmuteUser indicates an expected call of UnmuteUser.
+func (mr *MockUserServiceMockRecorder) UnmuteUser(ctx, userID, mutedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteUser", reflect.TypeOf((*MockUserService)(nil).UnmuteUser), ctx, userID, mutedID)
+}